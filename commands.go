@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+)
+
+// providerCommand defines the signature of the commands supported by the provider binary when executed directly instead
+// of being executed by Terraform, e,g: terraform-provider-openapi generate-config -output-dir ./generated
+type providerCommand func(p *openapi.ProviderOpenAPI, args []string) error
+
+var providerCommands = map[string]providerCommand{
+	"generate-config": generateConfigCommand,
+	"sweep":           sweepCommand,
+	"mock-server":     mockServerCommand,
+	"conformance":     conformanceCommand,
+	"lint":            lintCommand,
+	"explain":         lintCommand,
+}
+
+func runProviderCommand(binaryName string, command providerCommand, args []string) error {
+	providerName, err := getProviderName(binaryName)
+	if err != nil {
+		return fmt.Errorf("error getting the provider's name from the binary '%s': %s", binaryName, err)
+	}
+	return command(&openapi.ProviderOpenAPI{ProviderName: providerName, EmbeddedFS: embeddedFS}, args)
+}
+
+// generateConfigCommand writes Terraform configuration files with resource and import blocks for the objects already
+// existing in the service provider's API
+func generateConfigCommand(p *openapi.ProviderOpenAPI, args []string) error {
+	flagSet := flag.NewFlagSet("generate-config", flag.ContinueOnError)
+	outputDir := flagSet.String("output-dir", ".", "directory where the generated .tf files will be written to")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	files, err := p.GenerateConfig(*outputDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		fmt.Println(file)
+	}
+	return nil
+}
+
+// sweepCommand deletes the remote objects that match the given patterns, typically used to clean up the resources leaked
+// by aborted acceptance test runs
+func sweepCommand(p *openapi.ProviderOpenAPI, args []string) error {
+	flagSet := flag.NewFlagSet("sweep", flag.ContinueOnError)
+	namePrefix := flagSet.String("name-prefix", "", "sweep only the objects whose name starts with the given prefix")
+	patterns := &attributePatternsFlag{}
+	flagSet.Var(patterns, "pattern", "sweep only the objects whose attribute matches the given regular expression, in the form attribute=regex (can be specified multiple times)")
+	olderThan := flagSet.Duration("older-than", 0, "sweep only the objects created longer than the given duration ago (e,g: 24h)")
+	ageAttribute := flagSet.String("age-attribute", "", "attribute containing the objects' creation time (defaults to created_at)")
+	dryRun := flagSet.Bool("dry-run", false, "only report the objects that would be swept without deleting them")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	attributePatterns := map[string]string(*patterns)
+	if *namePrefix != "" {
+		if attributePatterns == nil {
+			attributePatterns = map[string]string{}
+		}
+		attributePatterns["name"] = fmt.Sprintf("^%s", regexp.QuoteMeta(*namePrefix))
+	}
+	sweptInstances, err := p.Sweep(openapi.SweeperConfig{
+		AttributePatterns: attributePatterns,
+		OlderThan:         *olderThan,
+		AgeAttribute:      *ageAttribute,
+		DryRun:            *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	failures := 0
+	for _, sweptInstance := range sweptInstances {
+		switch {
+		case *dryRun:
+			fmt.Printf("would delete %s %s\n", sweptInstance.ResourceName, sweptInstance.ID)
+		case sweptInstance.Deleted:
+			fmt.Printf("deleted %s %s\n", sweptInstance.ResourceName, sweptInstance.ID)
+		default:
+			failures++
+			fmt.Printf("failed to delete %s %s: %s\n", sweptInstance.ResourceName, sweptInstance.ID, sweptInstance.Err)
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to delete %d objects", failures)
+	}
+	return nil
+}
+
+// mockServerCommand serves an in-memory implementation of the API described in the provider's OpenAPI document, so
+// Terraform configurations can be tested offline with no external services
+func mockServerCommand(p *openapi.ProviderOpenAPI, args []string) error {
+	flagSet := flag.NewFlagSet("mock-server", flag.ContinueOnError)
+	address := flagSet.String("address", ":8080", "address the mock API server will listen on")
+	specURL := flagSet.String("spec", "", "URL or file path of the OpenAPI document (defaults to the provider's OpenAPI document)")
+	tlsCert := flagSet.String("tls-cert", "", "TLS certificate file, if provided along with -tls-key the mock API server will serve HTTPS")
+	tlsKey := flagSet.String("tls-key", "", "TLS private key file")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	var mockAPIServer *openapi.MockAPIServer
+	var err error
+	if *specURL != "" {
+		mockAPIServer, err = openapi.NewMockAPIServer(*specURL)
+	} else {
+		mockAPIServer, err = p.CreateMockAPIServer()
+	}
+	if err != nil {
+		return err
+	}
+	if *tlsCert != "" && *tlsKey != "" {
+		log.Printf("[INFO] mock API server listening on https://%s", *address)
+		return http.ListenAndServeTLS(*address, *tlsCert, *tlsKey, mockAPIServer)
+	}
+	log.Printf("[INFO] mock API server listening on http://%s", *address)
+	return http.ListenAndServe(*address, mockAPIServer)
+}
+
+// conformanceCommand exercises the lifecycle of each resource against the API and reports the deviations from the
+// OpenAPI document and from the lifecycle expectations the provider relies on
+func conformanceCommand(p *openapi.ProviderOpenAPI, args []string) error {
+	flagSet := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	baseURL := flagSet.String("base-url", "", "base URL of the API to check (e,g: http://localhost:8080), defaults to the host documented in the OpenAPI document")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	report, err := p.CheckConformance(*baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Print(openapi.FormatConformanceReport(report))
+	if !report.IsConformant() {
+		return fmt.Errorf("the API implementation does not conform to the OpenAPI document: %d deviations found", len(report.Deviations))
+	}
+	return nil
+}
+
+// lintCommand explains how the provider interprets the OpenAPI document: which paths become resources and data sources
+// (or why they are rejected), which extensions are applied and which extensions are not recognised
+func lintCommand(p *openapi.ProviderOpenAPI, args []string) error {
+	flagSet := flag.NewFlagSet("lint", flag.ContinueOnError)
+	specURL := flagSet.String("spec", "", "URL or file path of the OpenAPI document (defaults to the provider's OpenAPI document)")
+	format := flagSet.String("format", "text", "output format, supported values are text and json")
+	failOnWarnings := flagSet.Bool("fail-on-warnings", false, "exit with an error if unknown or misspelled extensions are found")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("output format '%s' not supported, supported values are text and json", *format)
+	}
+	var report *openapi.SpecLintReport
+	var err error
+	if *specURL != "" {
+		report, err = openapi.LintOpenAPIDocument(p.ProviderName, *specURL)
+	} else {
+		report, err = p.Lint()
+	}
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		fmt.Print(openapi.FormatSpecLintReport(report))
+	}
+	if *failOnWarnings && !report.IsClean() {
+		return fmt.Errorf("%d warnings found in the OpenAPI document", len(report.Warnings))
+	}
+	return nil
+}
+
+// attributePatternsFlag is a flag.Value that collects multiple attribute=regex values
+type attributePatternsFlag map[string]string
+
+func (a *attributePatternsFlag) String() string {
+	patterns := []string{}
+	for attribute, pattern := range *a {
+		patterns = append(patterns, fmt.Sprintf("%s=%s", attribute, pattern))
+	}
+	return strings.Join(patterns, ",")
+}
+
+func (a *attributePatternsFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("pattern '%s' not valid, the expected format is attribute=regex", value)
+	}
+	if *a == nil {
+		*a = attributePatternsFlag{}
+	}
+	(*a)[kv[0]] = kv[1]
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunProviderCommand(t *testing.T) {
+	Convey("Given a valid binary name and a provider command", t, func() {
+		binaryName := "terraform-provider-openapi"
+		var providerNameReceived string
+		var argsReceived []string
+		command := func(p *openapi.ProviderOpenAPI, args []string) error {
+			providerNameReceived = p.ProviderName
+			argsReceived = args
+			return nil
+		}
+		Convey("When runProviderCommand is called", func() {
+			err := runProviderCommand(binaryName, command, []string{"-output-dir", "generated"})
+			Convey("Then the command should be executed with a provider named after the binary and the given args", func() {
+				So(err, ShouldBeNil)
+				So(providerNameReceived, ShouldEqual, "openapi")
+				So(argsReceived, ShouldResemble, []string{"-output-dir", "generated"})
+			})
+		})
+	})
+	Convey("Given an invalid binary name", t, func() {
+		binaryName := "some-invalid-binary-name"
+		Convey("When runProviderCommand is called", func() {
+			err := runProviderCommand(binaryName, generateConfigCommand, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "error getting the provider's name from the binary 'some-invalid-binary-name': provider binary name (some-invalid-binary-name) does not match terraform naming convention 'terraform-provider-{name}', please rename the provider binary")
+			})
+		})
+	})
+}
+
+func TestProviderCommands(t *testing.T) {
+	Convey("Given the provider commands supported by the binary", t, func() {
+		Convey("Then the generate-config command should be registered", func() {
+			So(providerCommands, ShouldContainKey, "generate-config")
+		})
+		Convey("Then the sweep command should be registered", func() {
+			So(providerCommands, ShouldContainKey, "sweep")
+		})
+		Convey("Then the mock-server command should be registered", func() {
+			So(providerCommands, ShouldContainKey, "mock-server")
+		})
+		Convey("Then the conformance command should be registered", func() {
+			So(providerCommands, ShouldContainKey, "conformance")
+		})
+		Convey("Then the lint command should be registered along with its explain alias", func() {
+			So(providerCommands, ShouldContainKey, "lint")
+			So(providerCommands, ShouldContainKey, "explain")
+		})
+	})
+}
+
+func TestAttributePatternsFlag(t *testing.T) {
+	Convey("Given an attributePatternsFlag", t, func() {
+		patterns := &attributePatternsFlag{}
+		Convey("When Set is called with valid attribute=regex values", func() {
+			So(patterns.Set("name=^tf-acc-"), ShouldBeNil)
+			So(patterns.Set("label=a=b"), ShouldBeNil)
+			Convey("Then the patterns should be keyed by attribute", func() {
+				So(map[string]string(*patterns), ShouldResemble, map[string]string{"name": "^tf-acc-", "label": "a=b"})
+			})
+		})
+		Convey("When Set is called with a value that is not in the form attribute=regex", func() {
+			err := patterns.Set("^tf-acc-")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "pattern '^tf-acc-' not valid, the expected format is attribute=regex")
+			})
+		})
+	})
+}
@@ -0,0 +1,25 @@
+//go:build embedded
+// +build embedded
+
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"log"
+)
+
+// embeddedDir defines the directory containing the plugin configuration file (terraform-provider-openapi.yaml) and the
+// OpenAPI documents compiled into the provider binary when building with the embedded build tag (make build-embedded)
+const embeddedDir = "embedded"
+
+//go:embed embedded
+var embeddedFiles embed.FS
+
+func init() {
+	var err error
+	embeddedFS, err = fs.Sub(embeddedFiles, embeddedDir)
+	if err != nil {
+		log.Fatalf("[ERROR] There was an error when reading the files embedded in the provider binary: %s", err)
+	}
+}
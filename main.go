@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io/fs"
 	"log"
 
 	"fmt"
@@ -21,10 +22,27 @@ import (
 
 var otfProviderSourceAddressVar = "OTF_PROVIDER_SOURCE_ADDRESS"
 
+// embeddedFS contains the plugin configuration and the OpenAPI documents compiled into the provider binary when built
+// with the embedded build tag; nil otherwise, in which case they are loaded externally
+var embeddedFS fs.FS
+
 func main() {
 
 	log.Printf("Running OpenAPI Terraform Provider v%s-%s; Released on: %s", version.Version, version.Commit, version.Date)
 
+	if len(os.Args) > 1 {
+		if command, exists := providerCommands[os.Args[1]]; exists {
+			binaryName, err := os.Executable()
+			if err != nil {
+				log.Fatalf("[ERROR] There was an error when getting the provider binary name: %s", err)
+			}
+			if err := runProviderCommand(binaryName, command, os.Args[2:]); err != nil {
+				log.Fatalf("[ERROR] %s command failed: %s", os.Args[1], err)
+			}
+			return
+		}
+	}
+
 	var debugMode bool
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
@@ -85,7 +103,7 @@ func initProvider(binaryName string) (*schema.Provider, error) {
 		return nil, fmt.Errorf("error getting the provider's name from the binary '%s': %s", binaryName, err)
 	}
 	log.Printf("[INFO] Initializing '%s' provider", providerName)
-	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+	p := openapi.ProviderOpenAPI{ProviderName: providerName, EmbeddedFS: embeddedFS}
 	provider, err := p.CreateSchemaProvider()
 	if err != nil {
 		return nil, fmt.Errorf("error initialising the terraform provider: %s", err)
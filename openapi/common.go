@@ -99,6 +99,16 @@ func getParentIDs(openAPIResource SpecResource, data *schema.ResourceData) ([]st
 	return []string{}, nil
 }
 
+// configureResourceInstance returns the given resource configured with the values set in the resource data that override
+// the ones configured in the provider, that is the region (multi-region providers) and the global path parameters
+func configureResourceInstance(openAPIResource SpecResource, data *schema.ResourceData, providerClient ClientOpenAPI) (SpecResource, error) {
+	openAPIResource, err := configureRegion(openAPIResource, data, providerClient)
+	if err != nil {
+		return nil, err
+	}
+	return configureGlobalPathParameters(openAPIResource, data, providerClient)
+}
+
 // updateStateWithPayloadData is in charge of saving the given payload into the state file keeping for list properties the
 // same order as the input (if the list property has the IgnoreItemsOrder set to true). The property names are converted into compliant terraform names if needed.
 // The property names are converted into compliant terraform names if needed.
@@ -201,6 +211,9 @@ func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty,
 	dataValueKind := reflect.TypeOf(propertyValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
+		if property.isMapProperty() {
+			return propertyValue, nil
+		}
 		objectInput := map[string]interface{}{}
 		mapValue := propertyValue.(map[string]interface{})
 		for propertyName, propertyValue := range mapValue {
@@ -270,25 +283,35 @@ func setResourceDataProperty(schemaDefinitionProperty SpecSchemaDefinitionProper
 // setStateID sets the local resource's data ID with the newly identifier created in the POST API request. Refer to
 // r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier.
 func setStateID(openAPIres SpecResource, resourceLocalData *schema.ResourceData, payload map[string]interface{}) error {
-	resourceSchema, err := openAPIres.GetResourceSchema()
+	id, err := getPayloadID(openAPIres, payload)
 	if err != nil {
 		return err
 	}
+	resourceLocalData.SetId(id)
+	return nil
+}
+
+// getPayloadID returns the value of the resource's identifier property contained in the given payload. Refer to
+// r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier.
+func getPayloadID(openAPIres SpecResource, payload map[string]interface{}) (string, error) {
+	resourceSchema, err := openAPIres.GetResourceSchema()
+	if err != nil {
+		return "", err
+	}
 	identifierProperty, err := resourceSchema.getResourceIdentifier()
 	if err != nil {
-		return err
+		return "", err
 	}
 	if payload[identifierProperty] == nil {
-		return fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
+		return "", fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
 	}
 
 	switch payload[identifierProperty].(type) {
 	case int:
-		resourceLocalData.SetId(strconv.Itoa(payload[identifierProperty].(int)))
+		return strconv.Itoa(payload[identifierProperty].(int)), nil
 	case float64:
-		resourceLocalData.SetId(strconv.Itoa(int(payload[identifierProperty].(float64))))
+		return strconv.Itoa(int(payload[identifierProperty].(float64))), nil
 	default:
-		resourceLocalData.SetId(payload[identifierProperty].(string))
+		return payload[identifierProperty].(string), nil
 	}
-	return nil
 }
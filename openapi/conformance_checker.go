@@ -0,0 +1,563 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Conformance check steps reported in the ConformanceDeviation
+const (
+	ConformanceStepCreate          = "create"
+	ConformanceStepReadAfterCreate = "read after create"
+	ConformanceStepUpdate          = "update"
+	ConformanceStepReadAfterUpdate = "read after update"
+	ConformanceStepDelete          = "delete"
+	ConformanceStepReadAfterDelete = "read after delete"
+)
+
+// ConformanceDeviation describes a deviation of the API implementation from the OpenAPI document or from the lifecycle
+// expectations the provider relies on
+type ConformanceDeviation struct {
+	// ResourceName is the name of the resource the deviation was found on
+	ResourceName string
+	// Step is the lifecycle step where the deviation was found (e,g: create, read after create, etc)
+	Step string
+	// Message describes the deviation
+	Message string
+}
+
+func (d ConformanceDeviation) String() string {
+	return fmt.Sprintf("[resource='%s'] %s: %s", d.ResourceName, d.Step, d.Message)
+}
+
+// ConformanceReport contains the results of the conformance checks
+type ConformanceReport struct {
+	// Resources contains the names of the resources checked
+	Resources []string
+	// Deviations contains all the deviations found
+	Deviations []ConformanceDeviation
+}
+
+// IsConformant returns true if no deviations were found
+func (r ConformanceReport) IsConformant() bool {
+	return len(r.Deviations) == 0
+}
+
+// CheckConformance exercises the lifecycle of each resource exposed by the provider (create, read, update, read, delete
+// and read expecting a 404) using generated payloads, and reports all the deviations from the resource schemas and the
+// lifecycle expectations the provider relies on. If baseURL is provided (e,g: http://localhost:8080), the API calls are
+// made against it instead of the host (and base path) documented in the OpenAPI document. The provider is configured
+// using the environment variables matching the provider's property names in upper case (e,g: APIKEY_AUTH).
+// Note the checks create and delete instances; they should be run against local or testing environments only.
+func (p *ProviderOpenAPI) CheckConformance(baseURL string) (*ConformanceReport, error) {
+	if _, err := p.CreateSchemaProvider(); err != nil {
+		return nil, err
+	}
+	return p.checkConformance(baseURL)
+}
+
+func (p *ProviderOpenAPI) checkConformance(baseURL string) (*ConformanceReport, error) {
+	providerClient, err := p.createProviderClient()
+	if err != nil {
+		return nil, err
+	}
+	resources, err := p.getProviderResources()
+	if err != nil {
+		return nil, err
+	}
+	if baseURL != "" {
+		client, ok := providerClient.(*ProviderClient)
+		if !ok {
+			return nil, fmt.Errorf("base URL can not be configured for provider client %T", providerClient)
+		}
+		backendConfiguration, err := newConformanceBackendConfiguration(client.openAPIBackendConfiguration, baseURL)
+		if err != nil {
+			return nil, err
+		}
+		client.openAPIBackendConfiguration = backendConfiguration
+		for i, resource := range resources {
+			resources[i] = conformanceResource{resource}
+		}
+	}
+	return newConformanceChecker(resources, providerClient).check(), nil
+}
+
+// conformanceBackendConfiguration overrides the API backend configuration so the API calls are made against the base URL provided
+type conformanceBackendConfiguration struct {
+	SpecBackendConfiguration
+	baseURL *url.URL
+}
+
+func newConformanceBackendConfiguration(backendConfiguration SpecBackendConfiguration, baseURL string) (*conformanceBackendConfiguration, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("base URL '%s' not valid: %s", baseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base URL '%s' not valid: scheme and host are required (e,g: http://localhost:8080)", baseURL)
+	}
+	return &conformanceBackendConfiguration{SpecBackendConfiguration: backendConfiguration, baseURL: u}, nil
+}
+
+func (c *conformanceBackendConfiguration) getHost() (string, error) {
+	return c.baseURL.Host, nil
+}
+
+func (c *conformanceBackendConfiguration) getHTTPScheme() (string, error) {
+	return c.baseURL.Scheme, nil
+}
+
+func (c *conformanceBackendConfiguration) getBasePath() string {
+	if c.baseURL.Path != "" && c.baseURL.Path != "/" {
+		return c.baseURL.Path
+	}
+	return c.SpecBackendConfiguration.getBasePath()
+}
+
+func (c *conformanceBackendConfiguration) IsMultiRegion() (bool, string, []string, error) {
+	return false, "", nil, nil
+}
+
+// conformanceResource ignores the resource's host override so the API calls are made against the base URL provided
+type conformanceResource struct {
+	SpecResource
+}
+
+func (c conformanceResource) getHost() (string, error) {
+	return "", nil
+}
+
+type conformanceChecker struct {
+	resources       []SpecResource
+	resourcesByName map[string]SpecResource
+	providerClient  ClientOpenAPI
+	report          *ConformanceReport
+}
+
+func newConformanceChecker(resources []SpecResource, providerClient ClientOpenAPI) *conformanceChecker {
+	resourcesByName := map[string]SpecResource{}
+	for _, resource := range resources {
+		resourcesByName[resource.GetResourceName()] = resource
+	}
+	return &conformanceChecker{
+		resources:       sortResourcesByParentDepth(resources),
+		resourcesByName: resourcesByName,
+		providerClient:  providerClient,
+		report:          &ConformanceReport{Resources: []string{}, Deviations: []ConformanceDeviation{}},
+	}
+}
+
+func (c *conformanceChecker) check() *ConformanceReport {
+	for _, resource := range c.resources {
+		c.report.Resources = append(c.report.Resources, resource.GetResourceName())
+		c.checkResource(resource)
+	}
+	return c.report
+}
+
+func (c *conformanceChecker) addDeviation(resource SpecResource, step, message string, args ...interface{}) {
+	deviation := ConformanceDeviation{ResourceName: resource.GetResourceName(), Step: step, Message: fmt.Sprintf(message, args...)}
+	log.Printf("[WARN] conformance deviation found: %s", deviation)
+	c.report.Deviations = append(c.report.Deviations, deviation)
+}
+
+// checkResource runs the lifecycle checks for the given resource. For sub-resources, the parent instances are created
+// beforehand and deleted once the checks are completed.
+func (c *conformanceChecker) checkResource(resource SpecResource) {
+	log.Printf("[INFO] [resource='%s'] checking conformance", resource.GetResourceName())
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		c.addDeviation(resource, ConformanceStepCreate, "failed to get the resource schema: %s", err)
+		return
+	}
+	parentIDs, err := c.createParents(resource)
+	defer c.deleteParents(resource, parentIDs)
+	if err != nil {
+		c.addDeviation(resource, ConformanceStepCreate, "failed to create the parent instances: %s", err)
+		return
+	}
+
+	expectedPayload := c.generatePayload(resourceSchema, nil)
+	instance, responsePayload, err := c.create(resource, expectedPayload, parentIDs)
+	if err != nil {
+		c.addDeviation(resource, ConformanceStepCreate, "%s", err)
+		return
+	}
+	c.validatePayload(resource, ConformanceStepCreate, resourceSchema, expectedPayload, responsePayload, "")
+	c.read(resource, ConformanceStepReadAfterCreate, resourceSchema, instance, expectedPayload)
+
+	if resource.getResourceOperations().Put != nil {
+		expectedPayload = c.generatePayload(resourceSchema, expectedPayload)
+		responsePayload, err := c.update(resource, instance, expectedPayload)
+		if err != nil {
+			c.addDeviation(resource, ConformanceStepUpdate, "%s", err)
+		} else if responsePayload != nil {
+			c.validatePayload(resource, ConformanceStepUpdate, resourceSchema, expectedPayload, responsePayload, "")
+		}
+		c.read(resource, ConformanceStepReadAfterUpdate, resourceSchema, instance, expectedPayload)
+	}
+
+	if resource.getResourceOperations().Delete == nil {
+		log.Printf("[WARN] [resource='%s'] resource does not support the delete operation, instance '%s' will not be deleted", resource.GetResourceName(), instance.getImportID())
+		return
+	}
+	if err := c.delete(instance); err != nil {
+		c.addDeviation(resource, ConformanceStepDelete, "%s", err)
+		return
+	}
+	res, err := c.providerClient.Get(resource, instance.id, &map[string]interface{}{}, parentIDs...)
+	if err != nil {
+		c.addDeviation(resource, ConformanceStepReadAfterDelete, "GET request failed: %s", err)
+		return
+	}
+	if res.StatusCode != http.StatusNotFound {
+		c.addDeviation(resource, ConformanceStepReadAfterDelete, "GET returned status code %d, expected %d since the instance was deleted", res.StatusCode, http.StatusNotFound)
+	}
+}
+
+// createParents creates the parent instances required by the given sub-resource and returns their IDs
+func (c *conformanceChecker) createParents(resource SpecResource) ([]string, error) {
+	parentResourceInfo := resource.GetParentResourceInfo()
+	if parentResourceInfo == nil {
+		return []string{}, nil
+	}
+	parentResource, exists := c.resourcesByName[parentResourceInfo.fullParentResourceName]
+	if !exists {
+		return nil, fmt.Errorf("parent resource '%s' not found", parentResourceInfo.fullParentResourceName)
+	}
+	parentParentIDs, err := c.createParents(parentResource)
+	if err != nil {
+		return parentParentIDs, err
+	}
+	parentSchema, err := parentResource.GetResourceSchema()
+	if err != nil {
+		return parentParentIDs, err
+	}
+	parentInstance, _, err := c.create(parentResource, c.generatePayload(parentSchema, nil), parentParentIDs)
+	if err != nil {
+		return parentParentIDs, fmt.Errorf("[resource='%s'] %s", parentResource.GetResourceName(), err)
+	}
+	return parentInstance.getInstanceParentIDs(), nil
+}
+
+// deleteParents deletes the parent instances created by createParents, children first
+func (c *conformanceChecker) deleteParents(resource SpecResource, parentIDs []string) {
+	parentResourceInfo := resource.GetParentResourceInfo()
+	if parentResourceInfo == nil || len(parentIDs) == 0 {
+		return
+	}
+	parentResource, exists := c.resourcesByName[parentResourceInfo.fullParentResourceName]
+	if !exists {
+		return
+	}
+	parentInstance := remoteInstance{resource: parentResource, id: parentIDs[len(parentIDs)-1], parentIDs: parentIDs[:len(parentIDs)-1]}
+	if parentResource.getResourceOperations().Delete != nil {
+		if err := c.delete(parentInstance); err != nil {
+			log.Printf("[WARN] [resource='%s'] failed to delete parent instance '%s': %s", parentResource.GetResourceName(), parentInstance.getImportID(), err)
+		}
+	}
+	c.deleteParents(parentResource, parentInstance.parentIDs)
+}
+
+func (c *conformanceChecker) create(resource SpecResource, requestPayload map[string]interface{}, parentIDs []string) (remoteInstance, map[string]interface{}, error) {
+	responsePayload := map[string]interface{}{}
+	res, err := c.providerClient.Post(resource, requestPayload, &responsePayload, parentIDs...)
+	if err != nil {
+		return remoteInstance{}, nil, fmt.Errorf("POST request failed: %s", err)
+	}
+	if err := checkHTTPStatusCode(resource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+		return remoteInstance{}, nil, fmt.Errorf("POST returned an unexpected status code: %s", err)
+	}
+	id, err := getPayloadID(resource, responsePayload)
+	if err != nil {
+		return remoteInstance{}, nil, fmt.Errorf("POST response can not be identified: %s", err)
+	}
+	instance := remoteInstance{resource: resource, id: id, parentIDs: parentIDs}
+	if err := c.waitForPolling(instance, &responsePayload, resource.getResourceOperations().Post, res.StatusCode, schema.TimeoutCreate); err != nil {
+		return instance, nil, err
+	}
+	return instance, responsePayload, nil
+}
+
+func (c *conformanceChecker) read(resource SpecResource, step string, resourceSchema *SpecSchemaDefinition, instance remoteInstance, expectedPayload map[string]interface{}) {
+	responsePayload := map[string]interface{}{}
+	res, err := c.providerClient.Get(resource, instance.id, &responsePayload, instance.parentIDs...)
+	if err != nil {
+		c.addDeviation(resource, step, "GET request failed: %s", err)
+		return
+	}
+	if err := checkHTTPStatusCode(resource, res, []int{http.StatusOK}); err != nil {
+		c.addDeviation(resource, step, "GET returned an unexpected status code: %s", err)
+		return
+	}
+	c.validatePayload(resource, step, resourceSchema, expectedPayload, responsePayload, "")
+}
+
+// update performs the update of the given instance. A nil payload is returned if the update operation is documented to
+// return 204 No Content, in which case the provider does not expect a response payload
+func (c *conformanceChecker) update(resource SpecResource, instance remoteInstance, requestPayload map[string]interface{}) (map[string]interface{}, error) {
+	operation := resource.getResourceOperations().Put
+	if operation.responses.getResponse(http.StatusNoContent) != nil {
+		res, err := c.providerClient.Put(resource, instance.id, requestPayload, nil, instance.parentIDs...)
+		if err != nil {
+			return nil, fmt.Errorf("PUT request failed: %s", err)
+		}
+		if err := checkHTTPStatusCode(resource, res, []int{http.StatusNoContent}); err != nil {
+			return nil, fmt.Errorf("PUT returned an unexpected status code: %s", err)
+		}
+		return nil, nil
+	}
+	responsePayload := map[string]interface{}{}
+	res, err := c.providerClient.Put(resource, instance.id, requestPayload, &responsePayload, instance.parentIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("PUT request failed: %s", err)
+	}
+	if err := checkHTTPStatusCode(resource, res, []int{http.StatusOK, http.StatusAccepted}); err != nil {
+		return nil, fmt.Errorf("PUT returned an unexpected status code: %s", err)
+	}
+	if err := c.waitForPolling(instance, &responsePayload, operation, res.StatusCode, schema.TimeoutUpdate); err != nil {
+		return nil, err
+	}
+	return responsePayload, nil
+}
+
+// delete deletes the given instance reusing the resource's delete operation, including the polling mechanism
+func (c *conformanceChecker) delete(instance remoteInstance) error {
+	data, err := instance.createResourceData()
+	if err != nil {
+		return err
+	}
+	return newResourceFactory(instance.resource).delete(data, c.providerClient)
+}
+
+// waitForPolling waits for the instance to reach the target status if the operation's response has polling enabled
+func (c *conformanceChecker) waitForPolling(instance remoteInstance, responsePayload *map[string]interface{}, operation *specResourceOperation, statusCode int, timeoutFor string) error {
+	data, err := instance.createResourceData()
+	if err != nil {
+		return err
+	}
+	if err := newResourceFactory(instance.resource).handlePollingIfConfigured(responsePayload, data, c.providerClient, operation, statusCode, timeoutFor); err != nil {
+		return fmt.Errorf("polling mechanism failed with response status code (%d): %s", statusCode, err)
+	}
+	return nil
+}
+
+// generatePayload generates a request payload containing values for all the properties that are not readOnly. If
+// previousPayload is provided the values are changed (except for the immutable and force new properties which keep
+// their previous values) so it can be used as the update payload.
+func (c *conformanceChecker) generatePayload(schemaDefinition *SpecSchemaDefinition, previousPayload map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{}
+	update := previousPayload != nil
+	for _, property := range schemaDefinition.Properties {
+		if property.isReadOnly() || property.IsParentProperty {
+			continue
+		}
+		if update && (property.Immutable || property.ForceNew || property.isPropertyNamedID() || property.IsIdentifier) {
+			if value, exists := previousPayload[property.Name]; exists {
+				payload[property.Name] = value
+			}
+			continue
+		}
+		var previousValue interface{}
+		if update {
+			previousValue = previousPayload[property.Name]
+		}
+		payload[property.Name] = c.generateValue(property, property.Type, previousValue, update)
+	}
+	return payload
+}
+
+func (c *conformanceChecker) generateValue(property *SpecSchemaDefinitionProperty, propertyType schemaDefinitionPropertyType, previousValue interface{}, update bool) interface{} {
+	switch propertyType {
+	case TypeString:
+		value := fmt.Sprintf("conformance-%s", property.Name)
+		if property.isPropertyNamedID() || property.IsIdentifier {
+			value = fmt.Sprintf("%s-%d", value, time.Now().UnixNano())
+		}
+		if update {
+			value = fmt.Sprintf("%s-updated", value)
+		}
+		return value
+	case TypeInt:
+		if update {
+			return 2
+		}
+		return 1
+	case TypeFloat:
+		if update {
+			return 2.5
+		}
+		return 1.5
+	case TypeBool:
+		return !update
+	case TypeMap:
+		value := map[string]interface{}{"conformance": fmt.Sprintf("conformance-%s", property.Name)}
+		if update {
+			value["conformance"] = fmt.Sprintf("conformance-%s-updated", property.Name)
+		}
+		return value
+	case TypeObject:
+		previousObject, _ := previousValue.(map[string]interface{})
+		if update && previousObject == nil {
+			previousObject = map[string]interface{}{}
+		}
+		return c.generatePayload(property.SpecSchemaDefinition, previousObject)
+	case TypeList:
+		if property.isArrayOfObjectsProperty() {
+			return []interface{}{c.generateValue(property, TypeObject, nil, update)}
+		}
+		items := []interface{}{c.generateValue(property, property.ArrayItemsType, nil, false)}
+		if update {
+			items = append(items, c.generateValue(property, property.ArrayItemsType, nil, true))
+		}
+		return items
+	}
+	return nil
+}
+
+// validatePayload reports the deviations of the given payload received from the API compared to the schema and the
+// expected values, that is: missing properties, properties not documented in the schema, values with the wrong type
+// and values different from the ones sent in the request
+func (c *conformanceChecker) validatePayload(resource SpecResource, step string, schemaDefinition *SpecSchemaDefinition, expectedPayload, payload map[string]interface{}, prefix string) {
+	for _, property := range schemaDefinition.Properties {
+		if property.IsParentProperty {
+			continue
+		}
+		propertyName := prefix + property.Name
+		value, exists := payload[property.Name]
+		expectedValue, expected := expectedPayload[property.Name]
+		if !exists || value == nil {
+			switch {
+			case property.isReadOnly():
+				c.addDeviation(resource, step, "response is missing the readOnly property '%s'", propertyName)
+			case expected:
+				c.addDeviation(resource, step, "response is missing the property '%s' sent in the request", propertyName)
+			case property.IsRequired():
+				c.addDeviation(resource, step, "response is missing the required property '%s'", propertyName)
+			}
+			continue
+		}
+		if !c.isValueOfType(property, property.Type, value) {
+			c.addDeviation(resource, step, "property '%s' is expected to be of type '%s' but the response contains '%v'", propertyName, property.Type, value)
+			continue
+		}
+		if !expected || property.isReadOnly() {
+			continue
+		}
+		switch {
+		case property.isObjectProperty():
+			expectedObject, _ := expectedValue.(map[string]interface{})
+			c.validatePayload(resource, step, property.SpecSchemaDefinition, expectedObject, value.(map[string]interface{}), propertyName+".")
+		case property.isArrayOfObjectsProperty():
+			expectedItems, _ := expectedValue.([]interface{})
+			items := value.([]interface{})
+			if len(items) != len(expectedItems) {
+				c.addDeviation(resource, step, "property '%s' contains %d items but %d were sent in the request", propertyName, len(items), len(expectedItems))
+				continue
+			}
+			for i, item := range items {
+				expectedItem, _ := expectedItems[i].(map[string]interface{})
+				c.validatePayload(resource, step, property.SpecSchemaDefinition, expectedItem, item.(map[string]interface{}), fmt.Sprintf("%s[%d].", propertyName, i))
+			}
+		default:
+			if !c.equalValues(property, expectedValue, value) {
+				c.addDeviation(resource, step, "property '%s' value '%v' does not match the value sent in the request '%v'", propertyName, value, expectedValue)
+			}
+		}
+	}
+	propertyNames := []string{}
+	for propertyName := range payload {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		if _, err := schemaDefinition.getProperty(propertyName); err != nil {
+			c.addDeviation(resource, step, "response contains the property '%s%s' which is not documented in the schema", prefix, propertyName)
+		}
+	}
+}
+
+func (c *conformanceChecker) isValueOfType(property *SpecSchemaDefinitionProperty, propertyType schemaDefinitionPropertyType, value interface{}) bool {
+	switch propertyType {
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeInt:
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case TypeFloat:
+		_, ok := value.(float64)
+		return ok
+	case TypeBool:
+		_, ok := value.(bool)
+		return ok
+	case TypeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	case TypeMap:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range object {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	case TypeList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		itemsType := property.ArrayItemsType
+		for _, item := range items {
+			if !c.isValueOfType(property, itemsType, item) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func (c *conformanceChecker) equalValues(property *SpecSchemaDefinitionProperty, expectedValue, value interface{}) bool {
+	toStrings := func(v interface{}) []string {
+		items, ok := v.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%v", v)}
+		}
+		values := []string{}
+		for _, item := range items {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		if property.shouldIgnoreOrder() {
+			sort.Strings(values)
+		}
+		return values
+	}
+	return reflect.DeepEqual(toStrings(expectedValue), toStrings(value))
+}
+
+// FormatConformanceReport returns a human readable representation of the given report
+func FormatConformanceReport(report *ConformanceReport) string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "resources checked: %d\n", len(report.Resources))
+	for _, deviation := range report.Deviations {
+		fmt.Fprintf(builder, "- %s\n", deviation)
+	}
+	if report.IsConformant() {
+		builder.WriteString("no deviations found\n")
+	} else {
+		fmt.Fprintf(builder, "deviations found: %d\n", len(report.Deviations))
+	}
+	return builder.String()
+}
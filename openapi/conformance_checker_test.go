@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const conformanceCheckerTestSwagger = `swagger: "2.0"
+host: "api.example.com"
+basePath: "/api"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        202:
+          x-terraform-resource-poll-enabled: true
+          x-terraform-resource-poll-completed-statuses: "deployed"
+          x-terraform-resource-poll-pending-statuses: "deploy_pending"
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{cdn_id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    put:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+  /v1/cdns/{cdn_id}/v1/firewalls:
+    post:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+  /v1/cdns/{cdn_id}/v1/firewalls/{id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      port:
+        type: "integer"
+      ips:
+        type: "array"
+        items:
+          type: "string"
+      settings:
+        type: "object"
+        properties:
+          enabled:
+            type: "boolean"
+      status:
+        type: "string"
+        readOnly: true
+  ContentDeliveryNetworkFirewallV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      name:
+        type: "string"`
+
+func TestCheckConformance(t *testing.T) {
+	Convey("Given a ProviderOpenAPI created from an OpenAPI document containing a resource configured with polling and a sub-resource", t, func() {
+		defer func(delay, interval, minTimeout time.Duration) {
+			defaultPollDelay, defaultPollInterval, defaultPollMinTimeout = delay, interval, minTimeout
+		}(defaultPollDelay, defaultPollInterval, defaultPollMinTimeout)
+		defaultPollDelay, defaultPollInterval, defaultPollMinTimeout = 0, 10*time.Millisecond, 10*time.Millisecond
+
+		file, err := ioutil.TempFile("", "conformance_checker*.yaml")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		_, err = file.Write([]byte(conformanceCheckerTestSwagger))
+		So(err, ShouldBeNil)
+		p := ProviderOpenAPI{ProviderName: "openapi"}
+		_, err = p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: file.Name()})
+		So(err, ShouldBeNil)
+
+		Convey("When checkConformance is called with the base URL of a MockAPIServer serving the same OpenAPI document", func() {
+			mockAPIServer, err := NewMockAPIServer(file.Name())
+			So(err, ShouldBeNil)
+			server := httptest.NewServer(mockAPIServer)
+			defer server.Close()
+			report, err := p.checkConformance(server.URL)
+			Convey("Then the report should contain all the resources checked and no deviations", func() {
+				So(err, ShouldBeNil)
+				So(report.Resources, ShouldResemble, []string{"cdns_v1", "cdns_v1_firewalls_v1"})
+				So(report.Deviations, ShouldBeEmpty)
+				So(report.IsConformant(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When checkConformance is called with the base URL of an API that misbehaves", func() {
+			instances := map[string]map[string]interface{}{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				path := strings.TrimPrefix(r.URL.Path, "/api")
+				switch {
+				case r.Method == http.MethodPost && path == "/v1/cdns":
+					payload := map[string]interface{}{}
+					json.NewDecoder(r.Body).Decode(&payload)
+					// the response is missing the readOnly status and returns the label under a different name
+					instances["1"] = map[string]interface{}{"id": "1", "name": payload["label"], "port": payload["port"], "ips": payload["ips"], "settings": payload["settings"]}
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(instances["1"])
+				case r.Method == http.MethodGet && path == "/v1/cdns/1":
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(instances["1"])
+				case r.Method == http.MethodPut && path == "/v1/cdns/1":
+					// the update is ignored and the port is returned with the wrong type
+					instances["1"]["port"] = "80"
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(instances["1"])
+				case r.Method == http.MethodDelete && path == "/v1/cdns/1":
+					// the instance is not deleted
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			report, err := p.checkConformance(server.URL)
+			Convey("Then the report should contain all the deviations found", func() {
+				So(err, ShouldBeNil)
+				So(report.IsConformant(), ShouldBeFalse)
+				deviations := []string{}
+				for _, deviation := range report.Deviations {
+					deviations = append(deviations, deviation.String())
+				}
+				So(deviations, ShouldContain, "[resource='cdns_v1'] create: response is missing the property 'label' sent in the request")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] create: response is missing the readOnly property 'status'")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] create: response contains the property 'name' which is not documented in the schema")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] read after create: response is missing the property 'label' sent in the request")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] update: property 'port' is expected to be of type 'integer' but the response contains '80'")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] read after update: property 'ips' value '[conformance-ips]' does not match the value sent in the request '[conformance-ips conformance-ips-updated]'")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] read after update: property 'settings.enabled' value 'true' does not match the value sent in the request 'false'")
+				So(deviations, ShouldContain, "[resource='cdns_v1'] read after delete: GET returned status code 200, expected 404 since the instance was deleted")
+				So(deviations, ShouldContain, "[resource='cdns_v1_firewalls_v1'] create: POST request failed: expected a response body but response body received was empty for request = 'POST "+server.URL+"/api/v1/cdns/1/v1/firewalls HTTP/1.1'. Response = '404 Not Found'")
+			})
+		})
+
+		Convey("When checkConformance is called with a base URL that is not valid", func() {
+			_, err := p.checkConformance("localhost")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "base URL 'localhost' not valid: scheme and host are required (e,g: http://localhost:8080)")
+			})
+		})
+	})
+}
+
+func TestConformanceCheckerGeneratePayload(t *testing.T) {
+	Convey("Given a conformanceChecker and a schema definition containing readOnly, immutable and mutable properties", t, func() {
+		c := newConformanceChecker(nil, nil)
+		schemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "region", Type: TypeString, Immutable: true},
+				&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt},
+				&SpecSchemaDefinitionProperty{Name: "ratio", Type: TypeFloat},
+				&SpecSchemaDefinitionProperty{Name: "enabled", Type: TypeBool},
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString},
+			},
+		}
+		Convey("When generatePayload is called with no previous payload", func() {
+			payload := c.generatePayload(schemaDefinition, nil)
+			Convey("Then the payload should contain values for all the properties except the readOnly ones", func() {
+				So(payload, ShouldResemble, map[string]interface{}{
+					"label":   "conformance-label",
+					"region":  "conformance-region",
+					"port":    1,
+					"ratio":   1.5,
+					"enabled": true,
+					"tags":    []interface{}{"conformance-tags"},
+				})
+			})
+			Convey("And when generatePayload is called again with the previous payload", func() {
+				updatePayload := c.generatePayload(schemaDefinition, payload)
+				Convey("Then the payload should contain different values except for the immutable properties", func() {
+					So(updatePayload, ShouldResemble, map[string]interface{}{
+						"label":   "conformance-label-updated",
+						"region":  "conformance-region",
+						"port":    2,
+						"ratio":   2.5,
+						"enabled": false,
+						"tags":    []interface{}{"conformance-tags", "conformance-tags-updated"},
+					})
+				})
+			})
+		})
+	})
+}
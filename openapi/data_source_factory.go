@@ -84,6 +84,12 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
 
+	openAPIResource, err := configureResourceInstance(d.openAPIResource, data, openAPIClient)
+	if err != nil {
+		return err
+	}
+	d.openAPIResource = openAPIResource
+
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
 		return err
@@ -104,13 +110,7 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[data source='%s'] GET %s failed: %s", resourceName, resourcePath, err)
 	}
 
-	var filteredResults []map[string]interface{}
-	for _, payloadItem := range responsePayload {
-		match := d.filterMatch(filters, payloadItem)
-		if match {
-			filteredResults = append(filteredResults, payloadItem)
-		}
-	}
+	filteredResults := d.filterResults(filters, responsePayload)
 
 	if len(filteredResults) == 0 {
 		return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
@@ -128,6 +128,17 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, filteredResults[0], data)
 }
 
+// filterResults returns the items from the given payload that match all the filters provided
+func (d dataSourceFactory) filterResults(filters filters, payload []map[string]interface{}) []map[string]interface{} {
+	var filteredResults []map[string]interface{}
+	for _, payloadItem := range payload {
+		if d.filterMatch(filters, payloadItem) {
+			filteredResults = append(filteredResults, payloadItem)
+		}
+	}
+	return filteredResults
+}
+
 func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]interface{}) bool {
 	specSchemaDefinition, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
 	for _, filter := range filters {
@@ -164,18 +175,10 @@ func (d dataSourceFactory) validateInput(data *schema.ResourceData) (filters, er
 	for _, inputFilter := range inputFilters.(*schema.Set).List() {
 		f := inputFilter.(map[string]interface{})
 		filterPropertyName := f[dataSourceFilterSchemaNamePropertyName].(string)
-		s, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
-
-		specSchemaDefinitionProperty, err := s.getProperty(filterPropertyName)
-		if err != nil {
-			return nil, fmt.Errorf("filter name does not match any of the schema properties: %s", err)
-		}
-
-		if !specSchemaDefinitionProperty.isPrimitiveProperty() {
-			return nil, fmt.Errorf("property not supported as as filter: %s", specSchemaDefinitionProperty.GetTerraformCompliantPropertyName())
-		}
-
 		filterValue := f[dataSourceFilterSchemaValuesPropertyName].([]interface{})
+		if err := d.validateFilterProperty(filterPropertyName); err != nil {
+			return nil, err
+		}
 		if len(filterValue) > 1 {
 			return nil, fmt.Errorf("filters for primitive properties can not have more than one value in the values field")
 		}
@@ -183,3 +186,19 @@ func (d dataSourceFactory) validateInput(data *schema.ResourceData) (filters, er
 	}
 	return filters, nil
 }
+
+// validateFilterProperty checks that the given filter property name matches one of the schema properties and that the
+// property is a primitive (only primitive properties can be used as filters)
+func (d dataSourceFactory) validateFilterProperty(filterPropertyName string) error {
+	s, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
+
+	specSchemaDefinitionProperty, err := s.getProperty(filterPropertyName)
+	if err != nil {
+		return fmt.Errorf("filter name does not match any of the schema properties: %s", err)
+	}
+
+	if !specSchemaDefinitionProperty.isPrimitiveProperty() {
+		return fmt.Errorf("property not supported as as filter: %s", specSchemaDefinitionProperty.GetTerraformCompliantPropertyName())
+	}
+	return nil
+}
@@ -64,6 +64,12 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
 
+	openAPIResource, err := configureResourceInstance(d.openAPIResource, data, openAPIClient)
+	if err != nil {
+		return err
+	}
+	d.openAPIResource = openAPIResource
+
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
 		return err
@@ -0,0 +1,551 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// mockAPIServerParentIDPlaceholder is used to resolve the sub-resources paths into the regular expressions used to route
+// the requests, each parent ID is replaced by a capturing group
+const mockAPIServerParentIDPlaceholder = "mockapiserverparentid"
+
+// MockAPIServer is an http.Handler that serves an in-memory implementation of all the terraform compliant resources (and
+// sub-resources) described in an OpenAPI document. It's meant to be used to run Terraform configurations using the
+// provider offline with no external services. The server:
+// - Generates the IDs for the new instances
+// - Populates the readOnly properties and the properties with default values not provided in the requests
+// - Simulates the polling status transitions configured in the operations responses with x-terraform-resource-poll-enabled
+// - Keeps the instances of each region separately if the API is multi-region, the region is resolved from the request Host
+type MockAPIServer struct {
+	backendConfiguration SpecBackendConfiguration
+	routes               []*mockAPIServerRoute
+	routesByResourceName map[string]*mockAPIServerRoute
+
+	mutex sync.Mutex
+	// collections contains the instances keyed by region and collection path (resolved with the parent IDs)
+	collections map[string]map[string]*mockAPIServerCollection
+	lastID      int
+}
+
+type mockAPIServerRoute struct {
+	resource     SpecResource
+	basePath     string
+	pathRegex    *regexp.Regexp
+	parentsCount int
+}
+
+type mockAPIServerCollection struct {
+	ids       []string
+	instances map[string]*mockAPIServerInstance
+}
+
+type mockAPIServerInstance struct {
+	payload map[string]interface{}
+	// pendingStatuses contains the statuses the instance will go through (one per GET request) before reaching the targetStatus
+	pendingStatuses []string
+	targetStatus    string
+	// deleting is true if the instance was deleted with polling enabled; the instance will be removed once the pendingStatuses are exhausted
+	deleting bool
+}
+
+// NewMockAPIServer creates a MockAPIServer for the OpenAPI document located at the given URL (or local file path)
+func NewMockAPIServer(openAPIDocumentURL string) (*MockAPIServer, error) {
+	specAnalyser, err := CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("mock API server OpenAPI spec analyser error: %s", err)
+	}
+	return newMockAPIServer(specAnalyser)
+}
+
+// CreateMockAPIServer creates a MockAPIServer for the OpenAPI document configured for the provider
+func (p *ProviderOpenAPI) CreateMockAPIServer() (*MockAPIServer, error) {
+	serviceConfiguration, err := getServiceConfiguration(p.ProviderName, p.EmbeddedFS)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	specAnalyser, err := createServiceSpecAnalyser(serviceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("mock API server %s", err)
+	}
+	return newMockAPIServer(specAnalyser)
+}
+
+func newMockAPIServer(specAnalyser SpecAnalyser) (*MockAPIServer, error) {
+	backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	m := &MockAPIServer{
+		backendConfiguration: backendConfiguration,
+		routesByResourceName: map[string]*mockAPIServerRoute{},
+		collections:          map[string]map[string]*mockAPIServerCollection{},
+	}
+	for _, resource := range resources {
+		if resource.ShouldIgnoreResource() {
+			continue
+		}
+		// the resource (e,g: the resources of merged documents) may override the base path of the backend configuration
+		basePath := resource.getBasePath()
+		if basePath == "" {
+			basePath = backendConfiguration.getBasePath()
+		}
+		route, err := newMockAPIServerRoute(resource, basePath)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] mock API server serving resource '%s' at %s", resource.GetResourceName(), route.pathRegex)
+		m.routes = append(m.routes, route)
+		m.routesByResourceName[resource.GetResourceName()] = route
+	}
+	// sub-resources are matched first so their paths are not mistaken by their parents' instance paths
+	sort.SliceStable(m.routes, func(i, j int) bool {
+		return m.routes[i].parentsCount > m.routes[j].parentsCount
+	})
+	return m, nil
+}
+
+func newMockAPIServerRoute(resource SpecResource, basePath string) (*mockAPIServerRoute, error) {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = fmt.Sprintf("/%s", basePath)
+	}
+	parentIDs := []string{}
+	if parentResourceInfo := resource.GetParentResourceInfo(); parentResourceInfo != nil {
+		for i := range parentResourceInfo.parentResourceNames {
+			parentIDs = append(parentIDs, fmt.Sprintf("%s%d", mockAPIServerParentIDPlaceholder, i))
+		}
+	}
+	resourcePath, err := resource.getResourcePath(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourcePath = strings.TrimSuffix(resourcePath, "/")
+	if !strings.HasPrefix(resourcePath, "/") {
+		resourcePath = fmt.Sprintf("/%s", resourcePath)
+	}
+	pathRegex := regexp.QuoteMeta(basePath + resourcePath)
+	for _, parentID := range parentIDs {
+		pathRegex = strings.Replace(pathRegex, parentID, "([^/]+)", 1)
+	}
+	r, err := regexp.Compile(fmt.Sprintf("^%s(?:/([^/]*))?/?$", pathRegex))
+	if err != nil {
+		return nil, err
+	}
+	return &mockAPIServerRoute{resource: resource, basePath: basePath, pathRegex: r, parentsCount: len(parentIDs)}, nil
+}
+
+// ServeHTTP routes the request to the corresponding resource operation
+func (m *MockAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	log.Printf("[DEBUG] mock API server received %s %s (host: %s)", r.Method, r.URL.Path, r.Host)
+	for _, route := range m.routes {
+		matches := route.pathRegex.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			continue
+		}
+		parentIDs := matches[1 : len(matches)-1]
+		id := matches[len(matches)-1]
+		region := m.getRegion(r)
+		if len(parentIDs) > 0 && !m.parentExists(region, route.resource, parentIDs) {
+			m.writeError(w, http.StatusNotFound, fmt.Sprintf("parent instance %s not found", strings.Join(parentIDs, "/")))
+			return
+		}
+		collection := m.getCollection(region, strings.TrimPrefix(r.URL.Path, route.basePath), id)
+		if id == "" {
+			m.serveCollection(w, r, route.resource, collection)
+			return
+		}
+		m.serveInstance(w, r, route.resource, collection, id)
+		return
+	}
+	m.writeError(w, http.StatusNotFound, fmt.Sprintf("path %s does not match any resource", r.URL.Path))
+}
+
+func (m *MockAPIServer) serveCollection(w http.ResponseWriter, r *http.Request, resource SpecResource, collection *mockAPIServerCollection) {
+	operations := resource.getResourceOperations()
+	switch {
+	case r.Method == http.MethodGet && operations.List != nil:
+		items := []map[string]interface{}{}
+		for _, id := range collection.ids {
+			items = append(items, collection.instances[id].payload)
+		}
+		m.writeJSON(w, http.StatusOK, items)
+	case r.Method == http.MethodPost && operations.Post != nil:
+		m.create(w, r, resource, operations.Post, collection)
+	default:
+		m.writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not supported by resource '%s'", r.Method, resource.GetResourceName()))
+	}
+}
+
+func (m *MockAPIServer) serveInstance(w http.ResponseWriter, r *http.Request, resource SpecResource, collection *mockAPIServerCollection, id string) {
+	operations := resource.getResourceOperations()
+	instance, exists := collection.instances[id]
+	if !exists || (r.Method != http.MethodGet && instance.deleting) {
+		m.writeError(w, http.StatusNotFound, fmt.Sprintf("instance '%s' not found", id))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && operations.Get != nil:
+		if m.transitionStatus(resource, instance) {
+			collection.remove(id)
+			m.writeError(w, http.StatusNotFound, fmt.Sprintf("instance '%s' not found", id))
+			return
+		}
+		m.writeJSON(w, http.StatusOK, instance.payload)
+	case r.Method == http.MethodPut && operations.Put != nil:
+		m.update(w, r, resource, operations.Put, instance)
+	case r.Method == http.MethodDelete && operations.Delete != nil:
+		statusCode, response := m.getResponse(operations.Delete, http.StatusNoContent, http.StatusOK, http.StatusAccepted)
+		if response != nil && response.isPollingEnabled && len(response.pollPendingStatuses) > 0 {
+			instance.deleting = true
+			instance.pendingStatuses = append([]string{}, response.pollPendingStatuses...)
+			instance.targetStatus = ""
+		} else {
+			collection.remove(id)
+		}
+		w.WriteHeader(statusCode)
+	default:
+		m.writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not supported by resource '%s'", r.Method, resource.GetResourceName()))
+	}
+}
+
+func (m *MockAPIServer) create(w http.ResponseWriter, r *http.Request, resource SpecResource, operation *specResourceOperation, collection *mockAPIServerCollection) {
+	resourceSchema, input, err := m.readRequest(r, resource)
+	if err != nil {
+		m.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	payload, err := m.populatePayload(resourceSchema, input, nil)
+	if err != nil {
+		m.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	identifier, err := resourceSchema.getResourceIdentifier()
+	if err != nil {
+		m.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id := ""
+	if value, exists := input[identifier]; exists && value != nil {
+		id = fmt.Sprintf("%v", value)
+	} else {
+		m.lastID++
+		id = strconv.Itoa(m.lastID)
+		if property, err := resourceSchema.getProperty(identifier); err == nil && property.Type == TypeInt {
+			payload[identifier] = m.lastID
+		} else {
+			payload[identifier] = id
+		}
+	}
+	if _, exists := collection.instances[id]; exists {
+		m.writeError(w, http.StatusConflict, fmt.Sprintf("instance '%s' already exists", id))
+		return
+	}
+	instance := &mockAPIServerInstance{payload: payload}
+	statusCode, response := m.getResponse(operation, http.StatusCreated, http.StatusOK, http.StatusAccepted)
+	m.startPolling(resource, instance, response)
+	collection.ids = append(collection.ids, id)
+	collection.instances[id] = instance
+	m.writeJSON(w, statusCode, payload)
+}
+
+func (m *MockAPIServer) update(w http.ResponseWriter, r *http.Request, resource SpecResource, operation *specResourceOperation, instance *mockAPIServerInstance) {
+	resourceSchema, input, err := m.readRequest(r, resource)
+	if err != nil {
+		m.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	payload, err := m.populatePayload(resourceSchema, input, instance.payload)
+	if err != nil {
+		m.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	identifier, err := resourceSchema.getResourceIdentifier()
+	if err != nil {
+		m.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	payload[identifier] = instance.payload[identifier]
+	instance.payload = payload
+	statusCode, response := m.getResponse(operation, http.StatusOK, http.StatusAccepted)
+	m.startPolling(resource, instance, response)
+	m.writeJSON(w, statusCode, payload)
+}
+
+func (m *MockAPIServer) readRequest(r *http.Request, resource SpecResource) (*SpecSchemaDefinition, map[string]interface{}, error) {
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+	input := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, nil, fmt.Errorf("request body is not a valid JSON object: %s", err)
+	}
+	return resourceSchema, input, nil
+}
+
+// populatePayload returns the payload to be stored for the given input. The readOnly properties are populated with the
+// current values (if any) or their defaults/zero values otherwise, and the properties not provided in the input are
+// populated with their default values if specified. An error is returned if any required property is missing.
+func (m *MockAPIServer) populatePayload(schemaDefinition *SpecSchemaDefinition, input, current map[string]interface{}) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	for _, property := range schemaDefinition.Properties {
+		if property.IsParentProperty {
+			continue
+		}
+		if property.isReadOnly() {
+			if value, exists := current[property.Name]; exists {
+				payload[property.Name] = value
+				continue
+			}
+			payload[property.Name] = m.getDefaultValue(property)
+			continue
+		}
+		value, exists := input[property.Name]
+		if !exists || value == nil {
+			switch {
+			case property.Default != nil:
+				payload[property.Name] = property.Default
+			case property.isComputed() && current[property.Name] != nil:
+				payload[property.Name] = current[property.Name]
+			case property.IsRequired():
+				return nil, fmt.Errorf("missing required property '%s'", property.Name)
+			}
+			continue
+		}
+		populatedValue, err := m.populateValue(property, value, current[property.Name])
+		if err != nil {
+			return nil, err
+		}
+		payload[property.Name] = populatedValue
+	}
+	return payload, nil
+}
+
+func (m *MockAPIServer) populateValue(property *SpecSchemaDefinitionProperty, value, current interface{}) (interface{}, error) {
+	switch {
+	case property.isObjectProperty():
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("property '%s' is supposed to be an object", property.Name)
+		}
+		currentObject, _ := current.(map[string]interface{})
+		return m.populatePayload(property.SpecSchemaDefinition, object, currentObject)
+	case property.isArrayOfObjectsProperty():
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("property '%s' is supposed to be an array", property.Name)
+		}
+		populatedItems := []interface{}{}
+		for _, item := range items {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("property '%s' is supposed to be an array of objects", property.Name)
+			}
+			populatedItem, err := m.populatePayload(property.SpecSchemaDefinition, object, nil)
+			if err != nil {
+				return nil, err
+			}
+			populatedItems = append(populatedItems, populatedItem)
+		}
+		return populatedItems, nil
+	}
+	return value, nil
+}
+
+// getDefaultValue returns the property's default value if specified; otherwise the zero value for the property's type
+func (m *MockAPIServer) getDefaultValue(property *SpecSchemaDefinitionProperty) interface{} {
+	if property.Default != nil {
+		return property.Default
+	}
+	switch property.Type {
+	case TypeString:
+		return ""
+	case TypeInt:
+		return 0
+	case TypeFloat:
+		return 0.0
+	case TypeBool:
+		return false
+	case TypeList:
+		return []interface{}{}
+	case TypeMap:
+		return map[string]interface{}{}
+	case TypeObject:
+		object, _ := m.populatePayload(property.SpecSchemaDefinition, map[string]interface{}{}, nil)
+		return object
+	}
+	return nil
+}
+
+// getResponse returns the status code and response the operation should reply with. Responses with polling enabled take
+// precedence so the polling mechanism can be exercised; otherwise the first of the given status codes documented in the
+// operation is returned or the first of them if none is documented.
+func (m *MockAPIServer) getResponse(operation *specResourceOperation, statusCodes ...int) (int, *specResponse) {
+	for _, statusCode := range statusCodes {
+		if response := operation.responses.getResponse(statusCode); response != nil && response.isPollingEnabled {
+			return statusCode, response
+		}
+	}
+	for _, statusCode := range statusCodes {
+		if response := operation.responses.getResponse(statusCode); response != nil {
+			return statusCode, response
+		}
+	}
+	return statusCodes[0], nil
+}
+
+// startPolling sets up the instance status transitions if the given response has polling enabled. The instance status is
+// set to the first pending status and each GET request moves it forward until the target status is reached.
+func (m *MockAPIServer) startPolling(resource SpecResource, instance *mockAPIServerInstance, response *specResponse) {
+	if response == nil || !response.isPollingEnabled || len(response.pollTargetStatuses) == 0 {
+		return
+	}
+	instance.pendingStatuses = append([]string{}, response.pollPendingStatuses...)
+	instance.targetStatus = response.pollTargetStatuses[0]
+	if len(instance.pendingStatuses) > 0 {
+		m.setStatus(resource, instance, instance.pendingStatuses[0])
+	}
+}
+
+// transitionStatus moves forward the instance's status if the instance is going through polling transitions. It returns
+// true if the instance was being deleted and it has reached the end of its transitions, in which case it should be removed.
+func (m *MockAPIServer) transitionStatus(resource SpecResource, instance *mockAPIServerInstance) bool {
+	if len(instance.pendingStatuses) > 0 {
+		m.setStatus(resource, instance, instance.pendingStatuses[0])
+		instance.pendingStatuses = instance.pendingStatuses[1:]
+		return false
+	}
+	if instance.deleting {
+		return true
+	}
+	if instance.targetStatus != "" {
+		m.setStatus(resource, instance, instance.targetStatus)
+		instance.targetStatus = ""
+	}
+	return false
+}
+
+func (m *MockAPIServer) setStatus(resource SpecResource, instance *mockAPIServerInstance, status string) {
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return
+	}
+	statusHierarchy, err := resourceSchema.getStatusIdentifier()
+	if err != nil {
+		log.Printf("[WARN] mock API server can not simulate the polling transitions for resource '%s': %s", resource.GetResourceName(), err)
+		return
+	}
+	object := instance.payload
+	for _, propertyName := range statusHierarchy[:len(statusHierarchy)-1] {
+		nestedObject, ok := object[propertyName].(map[string]interface{})
+		if !ok {
+			nestedObject = map[string]interface{}{}
+			object[propertyName] = nestedObject
+		}
+		object = nestedObject
+	}
+	object[statusHierarchy[len(statusHierarchy)-1]] = status
+}
+
+// getRegion returns the region the request is targeting. If the API is multi-region the region is resolved matching the
+// request's host against the regional hosts, falling back to the default region.
+func (m *MockAPIServer) getRegion(r *http.Request) string {
+	isMultiRegion, _, regions, err := m.backendConfiguration.IsMultiRegion()
+	if err != nil || !isMultiRegion {
+		return ""
+	}
+	for _, region := range regions {
+		host, err := m.backendConfiguration.getHostByRegion(region)
+		if err != nil {
+			continue
+		}
+		if r.Host == host || mockAPIServerHostname(r.Host) == mockAPIServerHostname(host) {
+			return region
+		}
+	}
+	region, _ := m.backendConfiguration.GetDefaultRegion(regions)
+	return region
+}
+
+func mockAPIServerHostname(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		return hostname
+	}
+	return host
+}
+
+// parentExists checks whether the immediate parent instance of the sub-resource exists
+func (m *MockAPIServer) parentExists(region string, resource SpecResource, parentIDs []string) bool {
+	parentRoute, exists := m.routesByResourceName[resource.GetParentResourceInfo().fullParentResourceName]
+	if !exists {
+		return true
+	}
+	parentCollectionPath, err := parentRoute.resource.getResourcePath(parentIDs[:len(parentIDs)-1])
+	if err != nil {
+		return false
+	}
+	collection, exists := m.collections[region][m.collectionKey(parentCollectionPath)]
+	if !exists {
+		return false
+	}
+	instance, exists := collection.instances[parentIDs[len(parentIDs)-1]]
+	return exists && !instance.deleting
+}
+
+// getCollection returns the collection for the given request path (without the base path), creating it if it does not
+// exist yet
+func (m *MockAPIServer) getCollection(region, requestPath, id string) *mockAPIServerCollection {
+	collectionPath := strings.TrimSuffix(requestPath, "/")
+	if id != "" {
+		collectionPath = strings.TrimSuffix(collectionPath, fmt.Sprintf("/%s", id))
+	}
+	key := m.collectionKey(collectionPath)
+	if m.collections[region] == nil {
+		m.collections[region] = map[string]*mockAPIServerCollection{}
+	}
+	if m.collections[region][key] == nil {
+		m.collections[region][key] = &mockAPIServerCollection{instances: map[string]*mockAPIServerInstance{}}
+	}
+	return m.collections[region][key]
+}
+
+func (m *MockAPIServer) collectionKey(collectionPath string) string {
+	return fmt.Sprintf("/%s", strings.Trim(collectionPath, "/"))
+}
+
+func (c *mockAPIServerCollection) remove(id string) {
+	delete(c.instances, id)
+	for i, existingID := range c.ids {
+		if existingID == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *MockAPIServer) writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[WARN] mock API server failed to write the response: %s", err)
+	}
+}
+
+func (m *MockAPIServer) writeError(w http.ResponseWriter, statusCode int, message string) {
+	log.Printf("[DEBUG] mock API server replying with %d: %s", statusCode, message)
+	m.writeJSON(w, statusCode, map[string]interface{}{"code": statusCode, "message": message})
+}
@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const mockAPIServerTestSwagger = `swagger: "2.0"
+host: "api.${region}.example.com"
+x-terraform-provider-multiregion-fqdn: "api.${region}.example.com"
+x-terraform-provider-regions: "rst1,dub1"
+basePath: "/api"
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkV1"
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        202:
+          x-terraform-resource-poll-enabled: true
+          x-terraform-resource-poll-completed-statuses: "deployed"
+          x-terraform-resource-poll-pending-statuses: "deploy_pending,deploy_in_progress"
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{cdn_id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    put:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      responses:
+        202:
+          x-terraform-resource-poll-enabled: true
+          x-terraform-resource-poll-pending-statuses: "delete_pending"
+  /v1/cdns/{cdn_id}/v1/firewalls:
+    post:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+  /v1/cdns/{cdn_id}/v1/firewalls/{id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        type: "string"
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      port:
+        type: "integer"
+        default: 80
+      status:
+        type: "string"
+        readOnly: true
+  ContentDeliveryNetworkFirewallV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      name:
+        type: "string"`
+
+func TestMockAPIServer(t *testing.T) {
+	Convey("Given a MockAPIServer created from a multi-region OpenAPI document with a resource configured with polling and a sub-resource", t, func() {
+		file, err := ioutil.TempFile("", "mock_api_server*.yaml")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		_, err = file.Write([]byte(mockAPIServerTestSwagger))
+		So(err, ShouldBeNil)
+		mockAPIServer, err := NewMockAPIServer(file.Name())
+		So(err, ShouldBeNil)
+		server := httptest.NewServer(mockAPIServer)
+		defer server.Close()
+
+		doRequest := func(method, host, path string, body interface{}) (int, map[string]interface{}) {
+			var reqBody []byte
+			if body != nil {
+				reqBody, _ = json.Marshal(body)
+			}
+			req, _ := http.NewRequest(method, server.URL+path, bytes.NewReader(reqBody))
+			req.Host = host
+			res, err := http.DefaultClient.Do(req)
+			So(err, ShouldBeNil)
+			defer res.Body.Close()
+			payload := map[string]interface{}{}
+			json.NewDecoder(res.Body).Decode(&payload)
+			return res.StatusCode, payload
+		}
+
+		Convey("When a cdn is created", func() {
+			statusCode, payload := doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns", map[string]interface{}{"label": "my-cdn", "status": "ignored"})
+			Convey("Then the instance should be created with a generated ID, the default values and the first pending status", func() {
+				So(statusCode, ShouldEqual, http.StatusAccepted)
+				So(payload["id"], ShouldEqual, "1")
+				So(payload["label"], ShouldEqual, "my-cdn")
+				So(payload["port"], ShouldEqual, 80)
+				So(payload["status"], ShouldEqual, "deploy_pending")
+			})
+			Convey("And subsequent GET requests should go through the polling status transitions until the target status is reached", func() {
+				statuses := []interface{}{}
+				for i := 0; i < 3; i++ {
+					_, payload := doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/cdns/1", nil)
+					statuses = append(statuses, payload["status"])
+				}
+				So(statuses, ShouldResemble, []interface{}{"deploy_pending", "deploy_in_progress", "deployed"})
+			})
+			Convey("And the instance should be updated keeping the readOnly properties", func() {
+				doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/cdns/1", nil)
+				statusCode, payload := doRequest(http.MethodPut, "api.rst1.example.com", "/api/v1/cdns/1", map[string]interface{}{"label": "updated", "port": 8080})
+				So(statusCode, ShouldEqual, http.StatusOK)
+				So(payload["id"], ShouldEqual, "1")
+				So(payload["label"], ShouldEqual, "updated")
+				So(payload["port"], ShouldEqual, 8080)
+				So(payload["status"], ShouldEqual, "deploy_pending")
+			})
+			Convey("And the instance should not be visible in other regions", func() {
+				statusCode, _ := doRequest(http.MethodGet, "api.dub1.example.com", "/api/v1/cdns/1", nil)
+				So(statusCode, ShouldEqual, http.StatusNotFound)
+			})
+			Convey("And the sub-resource instances should be created under the existing parent instance", func() {
+				statusCode, payload := doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns/1/v1/firewalls", map[string]interface{}{"name": "my-fw"})
+				So(statusCode, ShouldEqual, http.StatusCreated)
+				So(payload["id"], ShouldEqual, "2")
+				statusCode, payload = doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/cdns/1/v1/firewalls/2", nil)
+				So(statusCode, ShouldEqual, http.StatusOK)
+				So(payload["name"], ShouldEqual, "my-fw")
+			})
+			Convey("And the sub-resource instances should not be created under non existing parent instances", func() {
+				statusCode, _ := doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns/999/v1/firewalls", map[string]interface{}{"name": "my-fw"})
+				So(statusCode, ShouldEqual, http.StatusNotFound)
+			})
+			Convey("And the instance should be deleted once the delete pending statuses are exhausted", func() {
+				statusCode, _ := doRequest(http.MethodDelete, "api.rst1.example.com", "/api/v1/cdns/1", nil)
+				So(statusCode, ShouldEqual, http.StatusAccepted)
+				statusCode, payload := doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/cdns/1", nil)
+				So(statusCode, ShouldEqual, http.StatusOK)
+				So(payload["status"], ShouldEqual, "delete_pending")
+				statusCode, _ = doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/cdns/1", nil)
+				So(statusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+		Convey("When a cdn is created with a missing required property", func() {
+			statusCode, payload := doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns", map[string]interface{}{"port": 8080})
+			Convey("Then the request should be rejected", func() {
+				So(statusCode, ShouldEqual, http.StatusBadRequest)
+				So(payload["message"], ShouldEqual, "missing required property 'label'")
+			})
+		})
+		Convey("When a path that does not match any resource is requested", func() {
+			statusCode, _ := doRequest(http.MethodGet, "api.rst1.example.com", "/api/v1/unknown", nil)
+			Convey("Then the response should be not found", func() {
+				So(statusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+		Convey("When an operation not documented for the resource is requested", func() {
+			doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns", map[string]interface{}{"label": "my-cdn"})
+			doRequest(http.MethodPost, "api.rst1.example.com", "/api/v1/cdns/1/v1/firewalls", map[string]interface{}{"name": "my-fw"})
+			statusCode, _ := doRequest(http.MethodPut, "api.rst1.example.com", "/api/v1/cdns/1/v1/firewalls/2", map[string]interface{}{})
+			Convey("Then the response should be method not allowed", func() {
+				So(statusCode, ShouldEqual, http.StatusMethodNotAllowed)
+			})
+		})
+	})
+}
@@ -1,9 +1,12 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
 	"runtime"
 	"strings"
 
@@ -29,6 +32,9 @@ type ClientOpenAPI interface {
 	Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
 	List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	GetTelemetryHandler() TelemetryHandler
+	GetDefaultLabels() map[string]string
+	GetGlobalPathParameters() map[string]string
+	GetRegion() (string, error)
 }
 
 // ProviderClient defines a client that is configured based on the OpenAPI server side documentation
@@ -97,7 +103,57 @@ func (o *ProviderClient) GetTelemetryHandler() TelemetryHandler {
 	return o.telemetryHandler
 }
 
+// GetDefaultLabels returns the default labels configured in the provider, which are merged into the labels of the resources
+func (o *ProviderClient) GetDefaultLabels() map[string]string {
+	return o.providerConfiguration.DefaultLabels
+}
+
+// GetGlobalPathParameters returns the values configured in the provider for the global path parameters keyed by the path
+// parameter name, the values of the path parameters not configured are empty
+func (o *ProviderClient) GetGlobalPathParameters() map[string]string {
+	return o.providerConfiguration.GlobalPathParameters
+}
+
+// GetRegion returns the region configured in the provider, or the default region if not configured. The region is empty
+// if the API is not multi-region
+func (o *ProviderClient) GetRegion() (string, error) {
+	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.IsMultiRegion()
+	if err != nil || !isMultiRegion {
+		return "", err
+	}
+	// get region value provided by user in the terraform configuration file
+	region := o.providerConfiguration.getRegion()
+	// otherwise, if not provided falling back to the default value specified in the service provider swagger file
+	if region == "" {
+		return o.openAPIBackendConfiguration.GetDefaultRegion(regions)
+	}
+	return region, nil
+}
+
+// performRequest sends the request to the API. If the API responds with 401 Unauthorized and the request was
+// authenticated with cached access tokens, the tokens are discarded and the request is sent once more with new ones.
 func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	reqContext, err := o.prepareRequestContext(method, resourceURL, operation, requestPayload)
+	if err != nil {
+		return nil, err
+	}
+	res, err := o.sendRequest(method, reqContext, requestPayload, responsePayload)
+	if err != nil || res == nil || res.StatusCode != http.StatusUnauthorized || len(reqContext.tokenInvalidators) == 0 {
+		return res, err
+	}
+	log.Printf("[INFO] %s %s responded with status code '%d', retrying the request with new access tokens", method, resourceURL, res.StatusCode)
+	for _, invalidateToken := range reqContext.tokenInvalidators {
+		invalidateToken()
+	}
+	resetResponsePayload(responsePayload)
+	reqContext, err = o.prepareRequestContext(method, resourceURL, operation, requestPayload)
+	if err != nil {
+		return nil, err
+	}
+	return o.sendRequest(method, reqContext, requestPayload, responsePayload)
+}
+
+func (o *ProviderClient) prepareRequestContext(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}) (*authContext, error) {
 	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
@@ -112,8 +168,51 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
 	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
 
+	if err := o.signRequest(method, reqContext, requestPayload); err != nil {
+		return nil, fmt.Errorf("failed to sign the API request for %s %s: %s", method, resourceURL, err)
+	}
+
 	o.logHeadersSafely(reqContext.headers)
+	return reqContext, nil
+}
 
+// signRequest builds the outgoing request the same way the http client does (same headers and JSON body) so the
+// authenticators that sign requests can compute the signature over the full request. The headers added by the signers
+// are then sent along with the request.
+func (o *ProviderClient) signRequest(method httpMethodSupported, reqContext *authContext, requestPayload interface{}) error {
+	if len(reqContext.requestSigners) == 0 {
+		return nil
+	}
+	var body []byte
+	if (method == httpPost || method == httpPut) && requestPayload != nil {
+		var err error
+		if body, err = json.Marshal(requestPayload); err != nil {
+			return err
+		}
+	}
+	if method == httpPost || method == httpPut {
+		reqContext.headers[contentType] = "application/json"
+	}
+	req, err := http.NewRequest(string(method), reqContext.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range reqContext.headers {
+		req.Header.Set(name, value)
+	}
+	for _, requestSigner := range reqContext.requestSigners {
+		if err := requestSigner.signRequest(req, body); err != nil {
+			return err
+		}
+	}
+	reqContext.headers = map[string]string{}
+	for name := range req.Header {
+		reqContext.headers[name] = req.Header.Get(name)
+	}
+	return nil
+}
+
+func (o *ProviderClient) sendRequest(method httpMethodSupported, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
 	switch method {
 	case httpPost:
 		return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, responsePayload)
@@ -127,6 +226,18 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	return nil, fmt.Errorf("method '%s' not supported", method)
 }
 
+// resetResponsePayload sets the value pointed by the response payload to its zero value, so the body of a rejected
+// response does not leak into the payload of the retried request
+func resetResponsePayload(responsePayload interface{}) {
+	if responsePayload == nil {
+		return
+	}
+	v := reflect.ValueOf(responsePayload)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().CanSet() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
+}
+
 func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value string) {
 	headers[userAgentHeader] = value
 }
@@ -159,28 +270,34 @@ func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderPara
 	return nil
 }
 
+// getResourceURL returns the URL of the given resource. For multi-region providers the host (and the base path and scheme
+// if the region overrides them) is resolved with the region configured in the resource, falling back to the region
+// configured in the provider. The resource may override the host, base path and scheme of the backend configuration. The
+// endpoint configured in the provider for the resource (or the base URL) overrides the host, and the scheme and base path
+// too if the endpoint is a URL. The global path parameters in the URL (e,g: in the host, base path or the resource path)
+// are resolved with the values configured in the provider
 func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
-	var host string
+	var host, basePath, scheme string
 	var err error
 
-	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.IsMultiRegion()
+	isMultiRegion, _, _, err := o.openAPIBackendConfiguration.IsMultiRegion()
 	if err != nil {
 		return "", err
 	}
 	if isMultiRegion {
-		// get region value provided by user in the terraform configuration file
-		region := o.providerConfiguration.getRegion()
-		// otherwise, if not provided falling back to the default value specified in the service provider swagger file
+		// the region configured in the resource takes precedence over the one configured in the provider
+		region := resource.getRegion()
 		if region == "" {
-			region, err = o.openAPIBackendConfiguration.GetDefaultRegion(regions)
+			region, err = o.GetRegion()
 			if err != nil {
 				return "", err
 			}
 		}
-		host, err = o.openAPIBackendConfiguration.getHostByRegion(region)
+		regionHost, err := o.openAPIBackendConfiguration.getRegionHost(region)
 		if err != nil {
 			return "", err
 		}
+		host, basePath, scheme = regionHost.Host, regionHost.BasePath, regionHost.Scheme
 	} else {
 		host, err = o.openAPIBackendConfiguration.getHost()
 		if err != nil {
@@ -188,7 +305,16 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		}
 	}
 
-	basePath := o.openAPIBackendConfiguration.getBasePath()
+	// the resource (e,g: the resources of merged documents) or the regions may override the global base path and scheme
+	if resourceBasePath := resource.getBasePath(); resourceBasePath != "" {
+		basePath = resourceBasePath
+	}
+	if resourceScheme := resource.getScheme(); resourceScheme != "" {
+		scheme = resourceScheme
+	}
+	if basePath == "" {
+		basePath = o.openAPIBackendConfiguration.getBasePath()
+	}
 	resourceRelativePath, err := resource.getResourcePath(parentIDs)
 	if err != nil {
 		return "", err
@@ -204,9 +330,19 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		host = hostOverride
 	}
 
-	if endPointHost := o.providerConfiguration.getEndPoint(resource.GetResourceName()); endPointHost != "" {
-		log.Printf("[INFO] resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointHost, host)
-		host = endPointHost
+	if endPointValue := o.providerConfiguration.getEndPoint(resource.GetResourceName()); endPointValue != "" {
+		endPoint, err := parseEndPoint(endPointValue)
+		if err != nil {
+			return "", fmt.Errorf("endpoint '%s' configured for resource '%s' is not valid: %s", endPointValue, resource.GetResourceName(), err)
+		}
+		log.Printf("[INFO] resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointValue, host)
+		host = endPoint.host
+		if endPoint.scheme != "" {
+			scheme = endPoint.scheme
+		}
+		if endPoint.basePath != "" {
+			basePath = endPoint.basePath
+		}
 	}
 
 	if host == "" || resourceRelativePath == "" {
@@ -214,9 +350,12 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 	}
 
 	// TODO: use resource operation schemes if specified
-	defaultScheme, err := o.openAPIBackendConfiguration.getHTTPScheme()
-	if err != nil {
-		return "", err
+	defaultScheme := scheme
+	if defaultScheme == "" {
+		defaultScheme, err = o.openAPIBackendConfiguration.getHTTPScheme()
+		if err != nil {
+			return "", err
+		}
 	}
 
 	path := resourceRelativePath
@@ -224,13 +363,15 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		path = fmt.Sprintf("/%s", resourceRelativePath)
 	}
 
+	resourceURL := fmt.Sprintf("%s://%s%s", defaultScheme, host, path)
 	if basePath != "" && basePath != "/" {
 		if strings.Index(basePath, "/") == 0 {
-			return fmt.Sprintf("%s://%s%s%s", defaultScheme, host, basePath, path), nil
+			resourceURL = fmt.Sprintf("%s://%s%s%s", defaultScheme, host, basePath, path)
+		} else {
+			resourceURL = fmt.Sprintf("%s://%s/%s%s", defaultScheme, host, basePath, path)
 		}
-		return fmt.Sprintf("%s://%s/%s%s", defaultScheme, host, basePath, path), nil
 	}
-	return fmt.Sprintf("%s://%s%s", defaultScheme, host, path), nil
+	return resolveGlobalPathParameters(resourceURL, o.providerConfiguration.GlobalPathParameters)
 }
 
 func (o ProviderClient) getResourceIDURL(resource SpecResource, parentIDs []string, id string) (string, error) {
@@ -8,15 +8,20 @@ import (
 
 // clientOpenAPIStub is a stubbed client used for testing purposes that implements the ClientOpenAPI interface
 type clientOpenAPIStub struct {
-	responsePayload     map[string]interface{}
-	responseListPayload []map[string]interface{}
-	error               error
-	returnHTTPCode      int
-	idReceived          string
-	parentIDsReceived   []string
-	telemetryHandler    TelemetryHandler
-
-	funcPut func() (*http.Response, error)
+	responsePayload      map[string]interface{}
+	responseListPayload  []map[string]interface{}
+	error                error
+	returnHTTPCode       int
+	idReceived           string
+	parentIDsReceived    []string
+	telemetryHandler     TelemetryHandler
+	defaultLabels        map[string]string
+	globalPathParameters map[string]string
+	region               string
+
+	funcPut    func() (*http.Response, error)
+	funcList   func(resource SpecResource, parentIDs ...string) []map[string]interface{}
+	funcDelete func(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
 }
 
 func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
@@ -75,6 +80,9 @@ func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interfac
 	switch p := responsePayload.(type) {
 	case *[]map[string]interface{}:
 		*p = c.responseListPayload
+		if c.funcList != nil {
+			*p = c.funcList(resource, parentIDs...)
+		}
 	default:
 		panic("unexpected type")
 	}
@@ -83,6 +91,9 @@ func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interfac
 }
 
 func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	if c.funcDelete != nil {
+		return c.funcDelete(resource, id, parentIDs...)
+	}
 	if c.error != nil {
 		return nil, c.error
 	}
@@ -96,6 +107,18 @@ func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 	return c.telemetryHandler
 }
 
+func (c *clientOpenAPIStub) GetDefaultLabels() map[string]string {
+	return c.defaultLabels
+}
+
+func (c *clientOpenAPIStub) GetGlobalPathParameters() map[string]string {
+	return c.globalPathParameters
+}
+
+func (c *clientOpenAPIStub) GetRegion() (string, error) {
+	return c.region, nil
+}
+
 func (c *clientOpenAPIStub) generateStubResponse(defaultHTTPCode int) *http.Response {
 	return &http.Response{
 		StatusCode: c.returnCode(defaultHTTPCode),
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/spec"
 
 	"github.com/dikhan/http_goclient"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProviderClient(t *testing.T) {
@@ -313,6 +317,277 @@ func TestGetResourceURL_edge_cases(t *testing.T) {
 	}
 }
 
+func TestGetResourceURLGlobalPathParameters(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		basePath             string
+		resource             SpecResource
+		globalPathParameters map[string]string
+		expectedResourceURL  string
+		expectedError        string
+	}{
+		{
+			name:                 "global path parameters in the resource path",
+			resource:             &SpecV2Resource{Path: "/v1/accounts/{account_id}/projects/{project_id}/cdns", globalPathParameters: []string{"account_id", "project_id"}},
+			globalPathParameters: map[string]string{"account_id": "1234", "project_id": "web"},
+			expectedResourceURL:  "https://www.host.com/v1/accounts/1234/projects/web/cdns",
+		},
+		{
+			name:                 "global path parameter in the base path",
+			basePath:             "/v1/accounts/{account_id}",
+			resource:             &SpecV2Resource{Path: "/cdns"},
+			globalPathParameters: map[string]string{"account_id": "1234"},
+			expectedResourceURL:  "https://www.host.com/v1/accounts/1234/cdns",
+		},
+		{
+			name:                 "global path parameter overridden in the resource",
+			resource:             globalPathParametersSpecResource{SpecResource: &SpecV2Resource{Path: "/v1/accounts/{account_id}/cdns", globalPathParameters: []string{"account_id"}}, values: map[string]string{"account_id": "5678"}},
+			globalPathParameters: map[string]string{"account_id": "1234"},
+			expectedResourceURL:  "https://www.host.com/v1/accounts/5678/cdns",
+		},
+		{
+			name:                 "global path parameter not configured",
+			resource:             &SpecV2Resource{Path: "/v1/accounts/{account_id}/cdns", globalPathParameters: []string{"account_id"}},
+			globalPathParameters: map[string]string{"account_id": ""},
+			expectedError:        "global path parameter 'account_id' is missing the value. Please make sure the property 'account_id' is configured with a value in the provider's terraform configuration",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{host: "www.host.com", basePath: tc.basePath, httpScheme: "https"},
+			providerConfiguration:       providerConfiguration{GlobalPathParameters: tc.globalPathParameters},
+		}
+		resourceURL, err := providerClient.getResourceURL(tc.resource, nil)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResourceURL, resourceURL, tc.name)
+	}
+}
+
+func TestGetResourceURLRegion(t *testing.T) {
+	testCases := []struct {
+		name                string
+		resource            SpecResource
+		providerRegion      string
+		expectedResourceURL string
+	}{
+		{
+			name:                "region configured in the provider",
+			resource:            &specStubResource{path: "/v1/cdns"},
+			providerRegion:      "dub",
+			expectedResourceURL: "https://www.dub.host.com/v1/cdns",
+		},
+		{
+			name:                "region not configured in the provider",
+			resource:            &specStubResource{path: "/v1/cdns"},
+			expectedResourceURL: "https://www.rst.host.com/v1/cdns",
+		},
+		{
+			name:                "region overridden in the resource",
+			resource:            &specStubResource{path: "/v1/cdns", region: "sfo"},
+			providerRegion:      "dub",
+			expectedResourceURL: "https://www.sfo.host.com/v1/cdns",
+		},
+		{
+			name:                "region overridden in a resource with global path parameters",
+			resource:            globalPathParametersSpecResource{SpecResource: &specStubResource{path: "/v1/cdns", region: "sfo"}},
+			providerRegion:      "dub",
+			expectedResourceURL: "https://www.sfo.host.com/v1/cdns",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", httpScheme: "https", regions: []string{"rst", "dub", "sfo"}},
+			providerConfiguration:       providerConfiguration{Region: tc.providerRegion},
+		}
+		resourceURL, err := providerClient.getResourceURL(tc.resource, nil)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResourceURL, resourceURL, tc.name)
+	}
+}
+
+func TestGetResourceURLRegionHost(t *testing.T) {
+	providerClient := ProviderClient{
+		openAPIBackendConfiguration: &specStubBackendConfiguration{
+			host:       "www.%s.host.com",
+			basePath:   "/api",
+			httpScheme: "https",
+			regions:    []string{"rst", "dub"},
+			regionHosts: map[string]specRegionHost{
+				"dub": {Host: "dub.other-host.io", BasePath: "/v2", Scheme: "http"},
+			},
+		},
+		providerConfiguration: providerConfiguration{Region: "dub"},
+	}
+	resourceURL, err := providerClient.getResourceURL(&specStubResource{path: "/v1/cdns"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://dub.other-host.io/v2/v1/cdns", resourceURL)
+
+	resourceURL, err = providerClient.getResourceURL(&specStubResource{path: "/v1/cdns", region: "rst"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://www.rst.host.com/api/v1/cdns", resourceURL)
+}
+
+func TestGetResourceURLEndPoint(t *testing.T) {
+	testCases := []struct {
+		name                string
+		resource            SpecResource
+		endpoints           map[string]string
+		baseURL             string
+		expectedResourceURL string
+		expectedErr         string
+	}{
+		{
+			name:                "host endpoint keeps the scheme and base path",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:           map[string]string{"cdns_v1": "localhost:8080"},
+			expectedResourceURL: "https://localhost:8080/api/v1/cdns",
+		},
+		{
+			name:                "URL endpoint overrides the scheme and base path",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:           map[string]string{"cdns_v1": "http://localhost:8080/mock/v2"},
+			expectedResourceURL: "http://localhost:8080/mock/v2/v1/cdns",
+		},
+		{
+			name:                "URL endpoint without base path overrides the base path with the root one",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:           map[string]string{"cdns_v1": "http://localhost:8080"},
+			expectedResourceURL: "http://localhost:8080/v1/cdns",
+		},
+		{
+			name:                "URL endpoint with root path overrides the base path with the root one",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:           map[string]string{"cdns_v1": "http://localhost:8080/"},
+			expectedResourceURL: "http://localhost:8080/v1/cdns",
+		},
+		{
+			name:                "base URL applies to all the resources",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			baseURL:             "http://canary.host.com/canary",
+			expectedResourceURL: "http://canary.host.com/canary/v1/cdns",
+		},
+		{
+			name:                "base URL with root path overrides the base path with the root one",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			baseURL:             "http://localhost:8080/",
+			expectedResourceURL: "http://localhost:8080/v1/cdns",
+		},
+		{
+			name:                "base URL overrides the resource host",
+			resource:            &specStubResource{name: "cdns_v1", path: "/v1/cdns", host: "cdn.host.com"},
+			baseURL:             "http://canary.host.com/canary",
+			expectedResourceURL: "http://canary.host.com/canary/v1/cdns",
+		},
+		{
+			name:                "resource endpoint takes precedence over the base URL",
+			resource:            newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:           map[string]string{"cdns_v1": "http://localhost:8080/mock/v2"},
+			baseURL:             "http://canary.host.com/canary",
+			expectedResourceURL: "http://localhost:8080/mock/v2/v1/cdns",
+		},
+		{
+			name:        "endpoint not valid",
+			resource:    newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			endpoints:   map[string]string{"cdns_v1": "ftp://localhost"},
+			expectedErr: "endpoint 'ftp://localhost' configured for resource 'cdns_v1' is not valid: scheme 'ftp' not supported - must use http or https",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("www.host.com", "/api", "https"),
+			providerConfiguration:       providerConfiguration{Endpoints: tc.endpoints, BaseURL: tc.baseURL},
+		}
+		resourceURL, err := providerClient.getResourceURL(tc.resource, nil)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResourceURL, resourceURL, tc.name)
+	}
+}
+
+func TestGetResourceURLMergedEndPoint(t *testing.T) {
+	merged, err := newSpecAnalyserMerged([]*mergedSpecDocument{
+		newMergedSpecDocumentStub("https://cdn.api.com/swagger.yaml", "", newStubBackendConfiguration("cdn.api.com", "/api", "https"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}, nil, newSpecStubResource("cdns_v1", "/v1/cdns", false, nil)),
+		newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "billing", newStubBackendConfiguration("billing.api.com", "/billing", "http"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}, nil, newSpecStubResource("invoices_v1", "/v1/invoices", false, nil)),
+	})
+	require.NoError(t, err)
+	backendConfiguration, err := merged.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	resources, err := merged.GetTerraformCompliantResources()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                 string
+		endpoints            map[string]string
+		baseURL              string
+		expectedResourceURLs []string
+	}{
+		{
+			name:                 "no endpoint configured, each document keeps its scheme and base path",
+			expectedResourceURLs: []string{"https://cdn.api.com/api/v1/cdns", "http://billing.api.com/billing/v1/invoices"},
+		},
+		{
+			name:                 "host endpoint keeps the base path of the document",
+			endpoints:            map[string]string{"billing_invoices_v1": "localhost:8080"},
+			expectedResourceURLs: []string{"https://cdn.api.com/api/v1/cdns", "http://localhost:8080/billing/v1/invoices"},
+		},
+		{
+			name:                 "URL endpoint overrides the base path of the document",
+			endpoints:            map[string]string{"billing_invoices_v1": "http://localhost:8080/mock/v2"},
+			expectedResourceURLs: []string{"https://cdn.api.com/api/v1/cdns", "http://localhost:8080/mock/v2/v1/invoices"},
+		},
+		{
+			name:                 "URL endpoint without base path overrides the base path of the document with the root one",
+			endpoints:            map[string]string{"billing_invoices_v1": "http://localhost:8080"},
+			expectedResourceURLs: []string{"https://cdn.api.com/api/v1/cdns", "http://localhost:8080/v1/invoices"},
+		},
+		{
+			name:                 "base URL overrides the base path of all the documents",
+			baseURL:              "http://canary.host.com/canary",
+			expectedResourceURLs: []string{"http://canary.host.com/canary/v1/cdns", "http://canary.host.com/canary/v1/invoices"},
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := ProviderClient{
+			openAPIBackendConfiguration: backendConfiguration,
+			providerConfiguration:       providerConfiguration{Endpoints: tc.endpoints, BaseURL: tc.baseURL},
+		}
+		for i, resource := range resources {
+			resourceURL, err := providerClient.getResourceURL(resource, nil)
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.expectedResourceURLs[i], resourceURL, tc.name)
+		}
+	}
+}
+
+func TestProviderClientGetRegion(t *testing.T) {
+	providerClient := ProviderClient{
+		openAPIBackendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"rst", "dub"}},
+		providerConfiguration:       providerConfiguration{Region: "dub"},
+	}
+	region, err := providerClient.GetRegion()
+	assert.NoError(t, err)
+	assert.Equal(t, "dub", region)
+
+	providerClient.providerConfiguration.Region = ""
+	region, err = providerClient.GetRegion()
+	assert.NoError(t, err)
+	assert.Equal(t, "rst", region)
+
+	providerClient.openAPIBackendConfiguration = &specStubBackendConfiguration{host: "www.host.com"}
+	region, err = providerClient.GetRegion()
+	assert.NoError(t, err)
+	assert.Empty(t, region)
+}
+
 func TestGetResourceURL(t *testing.T) {
 	Convey("Given a providerClient set up with auth that injects some headers to the request and is not multiregion", t, func() {
 		providerClient := &ProviderClient{
@@ -893,6 +1168,124 @@ func TestPerformRequest(t *testing.T) {
 	})
 }
 
+func TestPerformRequestRetriesWithNewAccessTokens(t *testing.T) {
+	Convey("Given a providerClient authenticating with a refresh token and an API that rejects the first access token", t, func() {
+		tokenRequests := 0
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenRequests++
+			w.Header().Set(authorizationHeader, fmt.Sprintf("Bearer token-%d", tokenRequests))
+		}))
+		defer tokenServer.Close()
+		apiRequests := 0
+		apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiRequests++
+			if r.Header.Get(authorizationHeader) == "Bearer token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"token revoked"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"someID"}`))
+		}))
+		defer apiServer.Close()
+		providerClient := &ProviderClient{
+			httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"refresh_token": newAPIRefreshTokenAuthenticator(authorizationHeader, "someRefreshToken", tokenServer.URL, "refresh_token"),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "refresh_token"}}),
+		}
+		Convey("When performRequest is called", func() {
+			responsePayload := map[string]interface{}{}
+			res, err := providerClient.performRequest(httpGet, apiServer.URL, &specResourceOperation{}, nil, &responsePayload)
+			Convey("Then the request should be retried once with a new access token and the response payload should only contain the retried response", func() {
+				So(err, ShouldBeNil)
+				So(res.StatusCode, ShouldEqual, http.StatusOK)
+				So(apiRequests, ShouldEqual, 2)
+				So(tokenRequests, ShouldEqual, 2)
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "someID"})
+			})
+			Convey("And the new access token should be cached for the following requests", func() {
+				res, err := providerClient.performRequest(httpGet, apiServer.URL, &specResourceOperation{}, nil, &responsePayload)
+				So(err, ShouldBeNil)
+				So(res.StatusCode, ShouldEqual, http.StatusOK)
+				So(apiRequests, ShouldEqual, 3)
+				So(tokenRequests, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a providerClient authenticating with a refresh token and an API that rejects all the access tokens", t, func() {
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(authorizationHeader, "Bearer token")
+		}))
+		defer tokenServer.Close()
+		apiRequests := 0
+		apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiRequests++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer apiServer.Close()
+		providerClient := &ProviderClient{
+			httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"refresh_token": newAPIRefreshTokenAuthenticator(authorizationHeader, "someRefreshToken", tokenServer.URL, "refresh_token"),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "refresh_token"}}),
+		}
+		Convey("When performRequest is called", func() {
+			res, err := providerClient.performRequest(httpDelete, apiServer.URL, &specResourceOperation{}, nil, nil)
+			Convey("Then the request should only be retried once and the unauthorized response returned", func() {
+				So(err, ShouldBeNil)
+				So(res.StatusCode, ShouldEqual, http.StatusUnauthorized)
+				So(apiRequests, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestPerformRequestSignsRequests(t *testing.T) {
+	Convey("Given a providerClient authenticating with a HMAC request signing security scheme", t, func() {
+		httpClient := &http_goclient.HttpClientStub{}
+		authenticator := newHMACAuthenticator(requestSigningCredentials{keyID: "keyID", secretKey: "secret"}, authorizationHeader, hmacSHA256Algorithm, "hmac_auth")
+		authenticator.now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+		providerClient := &ProviderClient{
+			httpClient: httpClient,
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{"hmac_auth": authenticator},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "hmac_auth"}}),
+		}
+		Convey("When performRequest POST method is called with a requestPayload", func() {
+			requestPayload := map[string]interface{}{"name": "some name"}
+			_, err := providerClient.performRequest(httpPost, "https://api.example.com/v1/resource", &specResourceOperation{}, requestPayload, nil)
+			Convey("Then the request sent should contain the signature computed over the JSON body that is sent", func() {
+				So(err, ShouldBeNil)
+				expectedReq, _ := http.NewRequest(http.MethodPost, "https://api.example.com/v1/resource", nil)
+				expectedReq.Header.Set(contentType, "application/json")
+				So(authenticator.signRequest(expectedReq, []byte(`{"name":"some name"}`)), ShouldBeNil)
+				So(httpClient.Headers[authorizationHeader], ShouldEqual, expectedReq.Header.Get(authorizationHeader))
+				So(httpClient.Headers[hmacDateHeader], ShouldEqual, "20200101T000000Z")
+				So(httpClient.Headers[hmacContentHashHeader], ShouldEqual, expectedReq.Header.Get(hmacContentHashHeader))
+				So(httpClient.Headers[contentType], ShouldEqual, "application/json")
+				So(httpClient.Headers, ShouldContainKey, userAgentHeader)
+			})
+		})
+	})
+}
+
+func TestResetResponsePayload(t *testing.T) {
+	responsePayload := map[string]interface{}{"error": "some error"}
+	resetResponsePayload(&responsePayload)
+	if responsePayload != nil {
+		t.Errorf("expected the response payload to be reset but got %+v", responsePayload)
+	}
+	resetResponsePayload(nil)
+}
+
 func TestProviderClientPost(t *testing.T) {
 
 	Convey("Given a providerClient set up with stub auth that injects some headers to the request", t, func() {
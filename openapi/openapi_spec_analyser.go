@@ -27,6 +27,10 @@ type SpecAnalyser interface {
 	// provider; so users can provide values for the headers that are meant to be sent along with the operations the headers
 	// are defined in.
 	GetAllHeaderParameters() SpecHeaderParameters
+	// GetGlobalPathParameters returns the path parameters declared once in the OpenAPI document which are exposed as
+	// configurable properties in the OpenAPI Terraform provider and resolved in the resources URLs with the values
+	// provided by the user.
+	GetGlobalPathParameters() (SpecGlobalPathParameters, error)
 	// GetAPIBackendConfiguration encapsulates all the information related to the backend in the OpenAPI doc
 	// (e,g: host, protocols, etc) which is then used in the ProviderClient to communicate with the API as specified in
 	// the configuration.
@@ -46,11 +50,17 @@ const (
 // Currently only OpenAPI v2 version is supported but this constructor is ready to handle new implementations such as v3
 // when the time comes
 func CreateSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string) (SpecAnalyser, error) {
+	return createSpecAnalyserWithLoader(specAnalyserVersion, openAPIDocumentURL, nil)
+}
+
+// createSpecAnalyserWithLoader returns the appropriate implementation of SpecAnalyser retrieving the OpenAPI document
+// with the given loader (e,g: configured with the provider's TLS configuration and swagger auth)
+func createSpecAnalyserWithLoader(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string, loader *specLoader) (SpecAnalyser, error) {
 	var err error
 	var specAnalyser SpecAnalyser
 	switch specAnalyserVersion {
 	case specAnalyserV2:
-		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL)
+		specAnalyser, err = newSpecAnalyserV2WithLoader(openAPIDocumentURL, loader)
 	default:
 		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, specAnalyserV2)
 	}
@@ -0,0 +1,386 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// mergedSpecDocument defines one of the OpenAPI documents merged by the specAnalyserMerged
+type mergedSpecDocument struct {
+	openAPIDocumentURL string
+	resourceNamePrefix string
+	specAnalyser       SpecAnalyser
+	// host and scheme are the host and scheme the API calls of the document's resources are made against, empty for the
+	// first document since its backend configuration is the provider's one
+	host                  string
+	scheme                string
+	basePath              string
+	globalSecuritySchemes SpecSecuritySchemes
+}
+
+// prefixResourceName returns the given resource name prefixed with the document's resource name prefix (if any)
+func (d *mergedSpecDocument) prefixResourceName(resourceName string) string {
+	if d.resourceNamePrefix == "" {
+		return resourceName
+	}
+	return fmt.Sprintf("%s_%s", d.resourceNamePrefix, resourceName)
+}
+
+// withGlobalSecurity returns a copy of the given operation configured with the document's global security schemes if the
+// operation does not define its own, since the global security schemes of the merged documents are not the same
+func (d *mergedSpecDocument) withGlobalSecurity(operation *specResourceOperation) *specResourceOperation {
+	if operation == nil || len(operation.SecuritySchemes) > 0 || len(d.globalSecuritySchemes) == 0 {
+		return operation
+	}
+	operationWithGlobalSecurity := *operation
+	operationWithGlobalSecurity.SecuritySchemes = d.globalSecuritySchemes
+	return &operationWithGlobalSecurity
+}
+
+// specAnalyserMerged merges the resources, data sources, security definitions, headers and global path parameters of several OpenAPI documents
+// into one provider. The backend configuration of the first document (scheme, regions, etc) is the provider's one, the
+// resources of the other documents are configured with their document's host and scheme. The conflicts between the documents (e,g:
+// two resources with the same name) are detected when the documents are merged.
+type specAnalyserMerged struct {
+	documents            []*mergedSpecDocument
+	resources            []SpecResource
+	dataSources          []SpecResource
+	security             *specSecurityMerged
+	headers              SpecHeaderParameters
+	globalPathParameters SpecGlobalPathParameters
+	backendConfiguration SpecBackendConfiguration
+}
+
+// newSpecAnalyserMerged merges the given documents, returning an error if they conflict with each other
+func newSpecAnalyserMerged(documents []*mergedSpecDocument) (*specAnalyserMerged, error) {
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("missing OpenAPI documents to merge")
+	}
+	merged := &specAnalyserMerged{
+		documents:   documents,
+		resources:   []SpecResource{},
+		dataSources: []SpecResource{},
+		security:    &specSecurityMerged{securityDefinitions: SpecSecurityDefinitions{}},
+		headers:     SpecHeaderParameters{},
+
+		globalPathParameters: SpecGlobalPathParameters{},
+	}
+	resourceDocuments := map[string]string{}
+	dataSourceDocuments := map[string]string{}
+	securityDefinitionDocuments := map[string]string{}
+	headerDocuments := map[string]string{}
+	globalPathParameterDocuments := map[string]string{}
+	for i, document := range documents {
+		backendConfiguration, err := document.specAnalyser.GetAPIBackendConfiguration()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the backend configuration of '%s': %s", document.openAPIDocumentURL, err)
+		}
+		if i == 0 {
+			if err := validateMergedRegionHosts(backendConfiguration); err != nil {
+				return nil, fmt.Errorf("failed to get the backend configuration of '%s': %s", document.openAPIDocumentURL, err)
+			}
+			merged.backendConfiguration = mergedBackendConfiguration{SpecBackendConfiguration: backendConfiguration}
+		} else {
+			isMultiRegion, _, _, err := backendConfiguration.IsMultiRegion()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the backend configuration of '%s': %s", document.openAPIDocumentURL, err)
+			}
+			if isMultiRegion {
+				return nil, fmt.Errorf("the OpenAPI document '%s' is multi-region, only the first document can be multi-region", document.openAPIDocumentURL)
+			}
+			if document.host, err = backendConfiguration.getHost(); err != nil {
+				return nil, fmt.Errorf("failed to get the host of '%s': %s", document.openAPIDocumentURL, err)
+			}
+			if document.scheme, err = backendConfiguration.getHTTPScheme(); err != nil {
+				return nil, fmt.Errorf("failed to get the scheme of '%s': %s", document.openAPIDocumentURL, err)
+			}
+		}
+		document.basePath = backendConfiguration.getBasePath()
+
+		security := document.specAnalyser.GetSecurity()
+		if document.globalSecuritySchemes, err = security.GetGlobalSecuritySchemes(); err != nil {
+			return nil, fmt.Errorf("failed to get the global security schemes of '%s': %s", document.openAPIDocumentURL, err)
+		}
+		securityDefinitions, err := security.GetAPIKeySecurityDefinitions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the security definitions of '%s': %s", document.openAPIDocumentURL, err)
+		}
+		for _, securityDefinition := range *securityDefinitions {
+			name := securityDefinition.GetTerraformConfigurationName()
+			if existingDocument, exists := securityDefinitionDocuments[name]; exists {
+				existingSecurityDefinition := merged.security.findSecurityDefinition(name)
+				if !reflect.DeepEqual(existingSecurityDefinition, securityDefinition) {
+					return nil, fmt.Errorf("security definition '%s' conflict: it is defined differently in '%s' and '%s'", name, existingDocument, document.openAPIDocumentURL)
+				}
+				continue
+			}
+			securityDefinitionDocuments[name] = document.openAPIDocumentURL
+			merged.security.securityDefinitions = append(merged.security.securityDefinitions, securityDefinition)
+		}
+
+		for _, header := range document.specAnalyser.GetAllHeaderParameters() {
+			name := header.GetHeaderTerraformConfigurationName()
+			if existingDocument, exists := headerDocuments[name]; exists {
+				existingHeader := merged.findHeader(name)
+				if existingHeader.Name != header.Name {
+					return nil, fmt.Errorf("header parameter '%s' conflict: it refers to the header '%s' in '%s' and '%s' in '%s'", name, existingHeader.Name, existingDocument, header.Name, document.openAPIDocumentURL)
+				}
+				continue
+			}
+			headerDocuments[name] = document.openAPIDocumentURL
+			merged.headers = append(merged.headers, header)
+		}
+
+		globalPathParameters, err := document.specAnalyser.GetGlobalPathParameters()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the global path parameters of '%s': %s", document.openAPIDocumentURL, err)
+		}
+		for _, globalPathParameter := range globalPathParameters {
+			if existingDocument, exists := globalPathParameterDocuments[globalPathParameter.Name]; exists {
+				if !reflect.DeepEqual(*merged.globalPathParameters.find(globalPathParameter.Name), globalPathParameter) {
+					return nil, fmt.Errorf("global path parameter '%s' conflict: it is defined differently in '%s' and '%s'", globalPathParameter.Name, existingDocument, document.openAPIDocumentURL)
+				}
+				continue
+			}
+			globalPathParameterDocuments[globalPathParameter.Name] = document.openAPIDocumentURL
+			merged.globalPathParameters = append(merged.globalPathParameters, globalPathParameter)
+		}
+
+		resources, err := document.specAnalyser.GetTerraformCompliantResources()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the resources of '%s': %s", document.openAPIDocumentURL, err)
+		}
+		for _, resource := range resources {
+			mergedResource := mergedSpecResource{SpecResource: resource, document: document}
+			if err := checkResourceNameConflict(mergedResource, "resource", resourceDocuments, document.openAPIDocumentURL); err != nil {
+				return nil, err
+			}
+			merged.resources = append(merged.resources, mergedResource)
+		}
+		for _, dataSource := range document.specAnalyser.GetTerraformCompliantDataSources() {
+			mergedDataSource := mergedSpecResource{SpecResource: dataSource, document: document}
+			if err := checkResourceNameConflict(mergedDataSource, "data source", dataSourceDocuments, document.openAPIDocumentURL); err != nil {
+				return nil, err
+			}
+			merged.dataSources = append(merged.dataSources, mergedDataSource)
+		}
+	}
+	merged.security.globalSecuritySchemes = mergeGlobalSecuritySchemes(documents)
+	return merged, nil
+}
+
+// checkResourceNameConflict returns an error if a resource with the same name was already registered by another document,
+// the ignored resources are not checked since they are not registered in the provider
+func checkResourceNameConflict(resource SpecResource, resourceType string, resourceDocuments map[string]string, openAPIDocumentURL string) error {
+	if resource.ShouldIgnoreResource() {
+		return nil
+	}
+	name := resource.GetResourceName()
+	if existingDocument, exists := resourceDocuments[name]; exists {
+		return fmt.Errorf("%s name '%s' conflict: it is exposed by both '%s' and '%s', please configure a 'resource_name_prefix' for one of them", resourceType, name, existingDocument, openAPIDocumentURL)
+	}
+	resourceDocuments[name] = openAPIDocumentURL
+	return nil
+}
+
+// mergeGlobalSecuritySchemes returns the global security schemes shared by all the documents. The operations of the
+// documents are configured with their document's global security schemes anyways, the merged ones are only used to figure
+// out which security definitions are required in the provider configuration.
+func mergeGlobalSecuritySchemes(documents []*mergedSpecDocument) SpecSecuritySchemes {
+	globalSecuritySchemes := SpecSecuritySchemes{}
+	for _, securityScheme := range documents[0].globalSecuritySchemes {
+		sharedByAllDocuments := true
+		for _, document := range documents[1:] {
+			if !containsSecurityScheme(document.globalSecuritySchemes, securityScheme.Name) {
+				sharedByAllDocuments = false
+				break
+			}
+		}
+		if sharedByAllDocuments {
+			globalSecuritySchemes = append(globalSecuritySchemes, securityScheme)
+		}
+	}
+	return globalSecuritySchemes
+}
+
+func containsSecurityScheme(securitySchemes SpecSecuritySchemes, name string) bool {
+	for _, securityScheme := range securitySchemes {
+		if securityScheme.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// lint returns the SpecLintReport of all the merged documents, the paths and warnings of each document are flagged with
+// the document they belong to and the resource names are prefixed with the document's resource name prefix (if any)
+func (s *specAnalyserMerged) lint() *SpecLintReport {
+	report := &SpecLintReport{Paths: []SpecPathLintResult{}, Warnings: []SpecLintWarning{}}
+	for _, document := range s.documents {
+		linter, ok := document.specAnalyser.(specLinter)
+		if !ok {
+			log.Printf("[WARN] skipping the OpenAPI document '%s' since its spec analyser %T does not support linting", document.openAPIDocumentURL, document.specAnalyser)
+			continue
+		}
+		documentReport := linter.lint()
+		for _, pathResult := range documentReport.Paths {
+			pathResult.Document = document.openAPIDocumentURL
+			for _, name := range []*string{&pathResult.Resource, &pathResult.ResourceRootPathOf, &pathResult.DataSource, &pathResult.DataSourceInstance} {
+				if *name != "" {
+					*name = document.prefixResourceName(*name)
+				}
+			}
+			report.Paths = append(report.Paths, pathResult)
+		}
+		for _, warning := range documentReport.Warnings {
+			warning.Document = document.openAPIDocumentURL
+			report.Warnings = append(report.Warnings, warning)
+		}
+	}
+	return report
+}
+
+func (s *specAnalyserMerged) findHeader(terraformName string) SpecHeaderParam {
+	for _, header := range s.headers {
+		if header.GetHeaderTerraformConfigurationName() == terraformName {
+			return header
+		}
+	}
+	return SpecHeaderParam{}
+}
+
+func (s *specAnalyserMerged) GetTerraformCompliantResources() ([]SpecResource, error) {
+	return s.resources, nil
+}
+
+func (s *specAnalyserMerged) GetTerraformCompliantDataSources() []SpecResource {
+	return s.dataSources
+}
+
+func (s *specAnalyserMerged) GetSecurity() SpecSecurity {
+	return s.security
+}
+
+func (s *specAnalyserMerged) GetAllHeaderParameters() SpecHeaderParameters {
+	return s.headers
+}
+
+func (s *specAnalyserMerged) GetGlobalPathParameters() (SpecGlobalPathParameters, error) {
+	return s.globalPathParameters, nil
+}
+
+func (s *specAnalyserMerged) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
+	return s.backendConfiguration, nil
+}
+
+// specSecurityMerged contains the security definitions of all the merged documents
+type specSecurityMerged struct {
+	securityDefinitions   SpecSecurityDefinitions
+	globalSecuritySchemes SpecSecuritySchemes
+}
+
+func (s *specSecurityMerged) findSecurityDefinition(terraformName string) SpecSecurityDefinition {
+	for _, securityDefinition := range s.securityDefinitions {
+		if securityDefinition.GetTerraformConfigurationName() == terraformName {
+			return securityDefinition
+		}
+	}
+	return nil
+}
+
+func (s *specSecurityMerged) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
+	return &s.securityDefinitions, nil
+}
+
+func (s *specSecurityMerged) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
+	return s.globalSecuritySchemes, nil
+}
+
+// validateMergedRegionHosts makes sure the regions of the given backend configuration do not override the base path, since
+// the resources of the merged documents are configured with the base path of their document
+func validateMergedRegionHosts(backendConfiguration SpecBackendConfiguration) error {
+	isMultiRegion, _, regions, err := backendConfiguration.IsMultiRegion()
+	if err != nil || !isMultiRegion {
+		return err
+	}
+	for _, region := range regions {
+		regionHost, err := backendConfiguration.getRegionHost(region)
+		if err != nil {
+			return err
+		}
+		if regionHost.BasePath != "" {
+			return fmt.Errorf("region '%s' overrides the base path, which is not supported when merging several OpenAPI documents", region)
+		}
+	}
+	return nil
+}
+
+// mergedBackendConfiguration is the backend configuration of the first document without its base path, since the resources
+// are configured with the base path of their document (see mergedSpecResource.getBasePath)
+type mergedBackendConfiguration struct {
+	SpecBackendConfiguration
+}
+
+func (c mergedBackendConfiguration) getBasePath() string {
+	return ""
+}
+
+// mergedSpecResource is a resource (or data source) of one of the merged documents, its name is prefixed with the document's
+// resource name prefix and the API calls are made against the document's host, base path and scheme
+type mergedSpecResource struct {
+	SpecResource
+	document *mergedSpecDocument
+}
+
+func (r mergedSpecResource) GetResourceName() string {
+	return r.document.prefixResourceName(r.SpecResource.GetResourceName())
+}
+
+// getHost returns the resource's host override if any, otherwise the host of its document
+func (r mergedSpecResource) getHost() (string, error) {
+	host, err := r.SpecResource.getHost()
+	if err != nil || host != "" {
+		return host, err
+	}
+	return r.document.host, nil
+}
+
+// getBasePath returns the resource's base path override if any, otherwise the base path of its document
+func (r mergedSpecResource) getBasePath() string {
+	if basePath := r.SpecResource.getBasePath(); basePath != "" {
+		return basePath
+	}
+	return r.document.basePath
+}
+
+// getScheme returns the resource's scheme override if any, otherwise the scheme of its document
+func (r mergedSpecResource) getScheme() string {
+	if scheme := r.SpecResource.getScheme(); scheme != "" {
+		return scheme
+	}
+	return r.document.scheme
+}
+
+func (r mergedSpecResource) getResourceOperations() specResourceOperations {
+	operations := r.SpecResource.getResourceOperations()
+	return specResourceOperations{
+		List:   r.document.withGlobalSecurity(operations.List),
+		Post:   r.document.withGlobalSecurity(operations.Post),
+		Get:    r.document.withGlobalSecurity(operations.Get),
+		Put:    r.document.withGlobalSecurity(operations.Put),
+		Delete: r.document.withGlobalSecurity(operations.Delete),
+	}
+}
+
+// GetParentResourceInfo returns the parent resource info with the full parent resource name prefixed, so it matches the
+// name of the parent resource registered in the provider
+func (r mergedSpecResource) GetParentResourceInfo() *ParentResourceInfo {
+	parentResourceInfo := r.SpecResource.GetParentResourceInfo()
+	if parentResourceInfo == nil || r.document.resourceNamePrefix == "" {
+		return parentResourceInfo
+	}
+	prefixedParentResourceInfo := *parentResourceInfo
+	prefixedParentResourceInfo.fullParentResourceName = r.document.prefixResourceName(parentResourceInfo.fullParentResourceName)
+	return &prefixedParentResourceInfo
+}
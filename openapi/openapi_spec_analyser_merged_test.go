@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergedSpecDocumentStub(openAPIDocumentURL, resourceNamePrefix string, backendConfiguration SpecBackendConfiguration, security *specSecurityStub, headers SpecHeaderParameters, resources ...SpecResource) *mergedSpecDocument {
+	return &mergedSpecDocument{
+		openAPIDocumentURL: openAPIDocumentURL,
+		resourceNamePrefix: resourceNamePrefix,
+		specAnalyser: &specAnalyserStub{
+			resources:            resources,
+			dataSources:          resources,
+			security:             security,
+			headers:              headers,
+			backendConfiguration: backendConfiguration,
+		},
+	}
+}
+
+func TestNewSpecAnalyserMerged(t *testing.T) {
+	apiKeyAuth := newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader)
+	tokenAuth := newAPIKeyHeaderSecurityDefinition("token_auth", "X-Token")
+	usersOperation := &specResourceOperation{}
+	users := newSpecStubResourceWithOperations("users", "/users", false, nil, usersOperation, nil, usersOperation, nil)
+	groupsOperation := &specResourceOperation{SecuritySchemes: SpecSecuritySchemes{{Name: "token_auth"}}}
+	groups := newSpecStubResourceWithOperations("groups", "/groups", false, nil, groupsOperation, nil, groupsOperation, nil)
+	members := newSpecStubResource("members", "/groups/{id}/members", false, nil)
+	members.parentResourceNames = []string{"groups"}
+	members.fullParentResourceName = "groups"
+	invoices := newSpecStubResource("invoices", "/invoices", false, nil)
+	invoices.host = "invoices.api.com"
+	payments := newSpecStubResource("payments", "/payments", false, nil)
+
+	merged, err := newSpecAnalyserMerged([]*mergedSpecDocument{
+		newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "iam", newStubBackendConfiguration("iam.api.com", "/api", "https"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{apiKeyAuth, tokenAuth}, globalSecuritySchemes: SpecSecuritySchemes{{Name: "apikey_auth"}}},
+			SpecHeaderParameters{{Name: "X-Request-ID"}}, users, groups, members),
+		newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", newStubBackendConfiguration("billing.api.com", "/", "http"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{apiKeyAuth}},
+			SpecHeaderParameters{{Name: "X-Request-ID"}, {Name: "X-Billing-Account"}}, invoices, payments),
+	})
+	require.NoError(t, err)
+
+	resources, err := merged.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 5)
+	require.Len(t, merged.GetTerraformCompliantDataSources(), 5)
+	assert.Equal(t, "iam_users", resources[0].GetResourceName())
+	assert.Equal(t, "iam_members", resources[2].GetResourceName())
+	assert.Equal(t, "invoices", resources[3].GetResourceName())
+
+	// the first document's resources are served from the provider's backend configuration, the others from their document's host
+	host, err := resources[0].getHost()
+	require.NoError(t, err)
+	assert.Empty(t, host)
+	host, err = resources[4].getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "billing.api.com", host)
+	host, err = resources[3].getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "invoices.api.com", host)
+
+	// the resources are configured with the base path of their document, their paths are not changed
+	resourcePath, err := resources[0].getResourcePath(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/users", resourcePath)
+	assert.Equal(t, "/api", resources[0].getBasePath())
+	assert.Equal(t, "/", resources[3].getBasePath())
+	// the first document's scheme is the provider's one, the other documents keep their own scheme
+	assert.Empty(t, resources[0].getScheme())
+	assert.Equal(t, "http", resources[3].getScheme())
+	backendConfiguration, err := merged.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	assert.Empty(t, backendConfiguration.getBasePath())
+	httpScheme, err := backendConfiguration.getHTTPScheme()
+	require.NoError(t, err)
+	assert.Equal(t, "https", httpScheme)
+
+	// the operations without security schemes are configured with their document's global security schemes
+	assert.Equal(t, SpecSecuritySchemes{{Name: "apikey_auth"}}, resources[0].getResourceOperations().Post.SecuritySchemes)
+	assert.Empty(t, usersOperation.SecuritySchemes)
+	assert.Equal(t, SpecSecuritySchemes{{Name: "token_auth"}}, resources[1].getResourceOperations().Post.SecuritySchemes)
+	assert.Nil(t, resources[1].getResourceOperations().Delete)
+
+	assert.Equal(t, "iam_groups", resources[2].GetParentResourceInfo().fullParentResourceName)
+	assert.Equal(t, []string{"groups_id"}, resources[2].GetParentResourceInfo().GetParentPropertiesNames())
+	assert.Nil(t, resources[0].GetParentResourceInfo())
+
+	securityDefinitions, err := merged.GetSecurity().GetAPIKeySecurityDefinitions()
+	require.NoError(t, err)
+	assert.Equal(t, SpecSecurityDefinitions{apiKeyAuth, tokenAuth}, *securityDefinitions)
+	// the billing document does not have global security schemes, hence none of them is required by all the documents
+	globalSecuritySchemes, err := merged.GetSecurity().GetGlobalSecuritySchemes()
+	require.NoError(t, err)
+	assert.Empty(t, globalSecuritySchemes)
+
+	assert.Equal(t, SpecHeaderParameters{{Name: "X-Request-ID"}, {Name: "X-Billing-Account"}}, merged.GetAllHeaderParameters())
+}
+
+func TestNewSpecAnalyserMergedGlobalSecuritySchemes(t *testing.T) {
+	apiKeyAuth := newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader)
+	tokenAuth := newAPIKeyHeaderSecurityDefinition("token_auth", "X-Token")
+	merged, err := newSpecAnalyserMerged([]*mergedSpecDocument{
+		newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", newStubBackendConfiguration("iam.api.com", "", "https"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{apiKeyAuth, tokenAuth}, globalSecuritySchemes: SpecSecuritySchemes{{Name: "apikey_auth"}, {Name: "token_auth"}}}, nil),
+		newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", newStubBackendConfiguration("billing.api.com", "", "https"),
+			&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{apiKeyAuth}, globalSecuritySchemes: SpecSecuritySchemes{{Name: "apikey_auth"}}}, nil),
+	})
+	require.NoError(t, err)
+	globalSecuritySchemes, err := merged.GetSecurity().GetGlobalSecuritySchemes()
+	require.NoError(t, err)
+	assert.Equal(t, SpecSecuritySchemes{{Name: "apikey_auth"}}, globalSecuritySchemes)
+}
+
+func TestNewSpecAnalyserMergedConflicts(t *testing.T) {
+	iamBackendConfiguration := newStubBackendConfiguration("iam.api.com", "", "https")
+	billingBackendConfiguration := newStubBackendConfiguration("billing.api.com", "", "https")
+	noSecurity := &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}
+	testCases := []struct {
+		name          string
+		documents     []*mergedSpecDocument
+		expectedError string
+	}{
+		{
+			name: "duplicate resource names",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration, noSecurity, nil, newSpecStubResource("users", "/users", false, nil)),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", billingBackendConfiguration, noSecurity, nil, newSpecStubResource("users", "/users", false, nil)),
+			},
+			expectedError: "resource name 'users' conflict: it is exposed by both 'https://iam.api.com/swagger.yaml' and 'https://billing.api.com/swagger.yaml', please configure a 'resource_name_prefix' for one of them",
+		},
+		{
+			name: "duplicate resource names after prefixing",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration, noSecurity, nil, newSpecStubResource("billing_users", "/billing/users", false, nil)),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "billing", billingBackendConfiguration, noSecurity, nil, newSpecStubResource("users", "/users", false, nil)),
+			},
+			expectedError: "resource name 'billing_users' conflict: it is exposed by both 'https://iam.api.com/swagger.yaml' and 'https://billing.api.com/swagger.yaml', please configure a 'resource_name_prefix' for one of them",
+		},
+		{
+			name: "security definitions defined differently",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration,
+					&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader)}}, nil),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", billingBackendConfiguration,
+					&specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{newAPIKeyQuerySecurityDefinition("apikey_auth", "api_key")}}, nil),
+			},
+			expectedError: "security definition 'apikey_auth' conflict: it is defined differently in 'https://iam.api.com/swagger.yaml' and 'https://billing.api.com/swagger.yaml'",
+		},
+		{
+			name: "header parameters referring to different headers",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration, noSecurity, SpecHeaderParameters{{Name: "X-Account-ID", TerraformName: "account"}}),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", billingBackendConfiguration, noSecurity, SpecHeaderParameters{{Name: "X-Billing-Account", TerraformName: "account"}}),
+			},
+			expectedError: "header parameter 'account' conflict: it refers to the header 'X-Account-ID' in 'https://iam.api.com/swagger.yaml' and 'X-Billing-Account' in 'https://billing.api.com/swagger.yaml'",
+		},
+		{
+			name: "global path parameters defined differently",
+			documents: []*mergedSpecDocument{
+				{openAPIDocumentURL: "https://iam.api.com/swagger.yaml", specAnalyser: &specAnalyserStub{security: noSecurity, backendConfiguration: iamBackendConfiguration, globalPathParameters: SpecGlobalPathParameters{{Name: "account_id"}}}},
+				{openAPIDocumentURL: "https://billing.api.com/swagger.yaml", specAnalyser: &specAnalyserStub{security: noSecurity, backendConfiguration: billingBackendConfiguration, globalPathParameters: SpecGlobalPathParameters{{Name: "account_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Default: "1234"}}}}},
+			},
+			expectedError: "global path parameter 'account_id' conflict: it is defined differently in 'https://iam.api.com/swagger.yaml' and 'https://billing.api.com/swagger.yaml'",
+		},
+		{
+			name: "multi-region document other than the first one",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration, noSecurity, nil),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", newStubBackendMultiRegionConfiguration("billing.${region}.api.com", []string{"rst1"}), noSecurity, nil),
+			},
+			expectedError: "the OpenAPI document 'https://billing.api.com/swagger.yaml' is multi-region, only the first document can be multi-region",
+		},
+		{
+			name: "multi-region document with regions overriding the base path",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", &specStubBackendConfiguration{host: "iam.${region}.api.com", regions: []string{"rst1", "dub1"}, regionHosts: map[string]specRegionHost{"dub1": {Host: "iam.dub1.api.io", BasePath: "/v2"}}}, noSecurity, nil),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", billingBackendConfiguration, noSecurity, nil),
+			},
+			expectedError: "failed to get the backend configuration of 'https://iam.api.com/swagger.yaml': region 'dub1' overrides the base path, which is not supported when merging several OpenAPI documents",
+		},
+		{
+			name: "document without a supported scheme",
+			documents: []*mergedSpecDocument{
+				newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", iamBackendConfiguration, noSecurity, nil),
+				newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", &specStubBackendConfiguration{host: "billing.api.com", getHTTPSchemeBehavior: func() (string, error) {
+					return "", errors.New("no schemes specified - must use http or https")
+				}}, noSecurity, nil),
+			},
+			expectedError: "failed to get the scheme of 'https://billing.api.com/swagger.yaml': no schemes specified - must use http or https",
+		},
+		{
+			name:          "no documents",
+			documents:     []*mergedSpecDocument{},
+			expectedError: "missing OpenAPI documents to merge",
+		},
+	}
+	for _, tc := range testCases {
+		_, err := newSpecAnalyserMerged(tc.documents)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestNewSpecAnalyserMergedGlobalPathParameters(t *testing.T) {
+	noSecurity := &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}
+	accountID := SpecGlobalPathParameter{Name: "account_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "The account"}}
+	projectID := SpecGlobalPathParameter{Name: "project_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Default: "default"}}
+	merged, err := newSpecAnalyserMerged([]*mergedSpecDocument{
+		{openAPIDocumentURL: "https://iam.api.com/swagger.yaml", specAnalyser: &specAnalyserStub{security: noSecurity, backendConfiguration: newStubBackendConfiguration("iam.api.com", "", "https"), globalPathParameters: SpecGlobalPathParameters{accountID}}},
+		{openAPIDocumentURL: "https://billing.api.com/swagger.yaml", specAnalyser: &specAnalyserStub{security: noSecurity, backendConfiguration: newStubBackendConfiguration("billing.api.com", "", "https"), globalPathParameters: SpecGlobalPathParameters{accountID, projectID}}},
+	})
+	require.NoError(t, err)
+	globalPathParameters, err := merged.GetGlobalPathParameters()
+	require.NoError(t, err)
+	assert.Equal(t, SpecGlobalPathParameters{accountID, projectID}, globalPathParameters)
+}
+
+func TestNewSpecAnalyserMergedIgnoredResourcesDoNotConflict(t *testing.T) {
+	noSecurity := &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}
+	_, err := newSpecAnalyserMerged([]*mergedSpecDocument{
+		newMergedSpecDocumentStub("https://iam.api.com/swagger.yaml", "", newStubBackendConfiguration("iam.api.com", "", "https"), noSecurity, nil, newSpecStubResource("users", "/users", false, nil)),
+		newMergedSpecDocumentStub("https://billing.api.com/swagger.yaml", "", newStubBackendConfiguration("billing.api.com", "", "https"), noSecurity, nil, newSpecStubResource("users", "/users", true, nil)),
+	})
+	assert.NoError(t, err)
+}
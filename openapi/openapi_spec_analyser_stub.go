@@ -6,6 +6,7 @@ type specAnalyserStub struct {
 	dataSources          []SpecResource
 	security             *specSecurityStub
 	headers              SpecHeaderParameters
+	globalPathParameters SpecGlobalPathParameters
 	backendConfiguration SpecBackendConfiguration
 	error                error
 }
@@ -29,6 +30,10 @@ func (s *specAnalyserStub) GetAllHeaderParameters() SpecHeaderParameters {
 	return s.headers
 }
 
+func (s *specAnalyserStub) GetGlobalPathParameters() (SpecGlobalPathParameters, error) {
+	return s.globalPathParameters, nil
+}
+
 func (s *specAnalyserStub) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
 	if s.error != nil {
 		return nil, s.error
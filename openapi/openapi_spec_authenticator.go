@@ -21,4 +21,9 @@ type specAuthenticator interface {
 type authContext struct {
 	headers map[string]string
 	url     string
+	// tokenInvalidators discard the cached access tokens used to authenticate the request, so new ones are obtained if
+	// the API rejects them (e,g: the token was revoked before its expiry)
+	tokenInvalidators []func()
+	// requestSigners are the authenticators that sign the outgoing request once it's fully built
+	requestSigners []specRequestSignerAuthenticator
 }
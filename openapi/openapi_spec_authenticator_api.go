@@ -42,7 +42,10 @@ func (oa apiAuth) fetchRequiredAuthenticators(operationSecuritySchemes SpecSecur
 	for _, operationSecurityScheme := range operationSecuritySchemes {
 		authenticator := providerConfig.getAuthenticatorFor(operationSecurityScheme)
 		if authenticator == nil {
-			return nil, fmt.Errorf("operation's security policy '%s' is not defined, please make sure the swagger file contains a security definition named '%s' under the securityDefinitions section", operationSecurityScheme, operationSecurityScheme)
+			return nil, fmt.Errorf("operation's security policy '%s' is not defined, please make sure the swagger file contains a security definition named '%s' under the securityDefinitions section", operationSecurityScheme.Name, operationSecurityScheme.Name)
+		}
+		if scopedAuthenticator, ok := authenticator.(specScopedAuthenticator); ok && len(operationSecurityScheme.Scopes) > 0 {
+			authenticator = scopedAuthenticator.withScopes(operationSecurityScheme.Scopes)
 		}
 		authenticators = append(authenticators, authenticator)
 	}
@@ -67,6 +70,9 @@ func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecurityS
 			if err := authenticator.prepareAuth(authContext); err != nil {
 				return authContext, err
 			}
+			if requestSigner, ok := authenticator.(specRequestSignerAuthenticator); ok {
+				authContext.requestSigners = append(authContext.requestSigners, requestSigner)
+			}
 		}
 	}
 	return authContext, nil
@@ -272,7 +272,7 @@ func TestPrepareAuth(t *testing.T) {
 			},
 			expectedHeaders: map[string]string{},
 			expectedURL:     "https://www.host.com/v1/resource",
-			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
+			expectedError:   errors.New("operation's security policy 'not_defined_scheme' is not defined, please make sure the swagger file contains a security definition named 'not_defined_scheme' under the securityDefinitions section"),
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation having specific security scheme that are not defined in the provider configuration ",
@@ -291,7 +291,7 @@ func TestPrepareAuth(t *testing.T) {
 			},
 			expectedHeaders: map[string]string{},
 			expectedURL:     "https://www.host.com/v1/resource",
-			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
+			expectedError:   errors.New("operation's security policy 'not_defined_scheme' is not defined, please make sure the swagger file contains a security definition named 'not_defined_scheme' under the securityDefinitions section"),
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
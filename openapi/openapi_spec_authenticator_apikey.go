@@ -1,5 +1,7 @@
 package openapi
 
+import "net/http"
+
 // specAPIKeyAuthenticator defines the behaviour for api key type authenticators (e,g: header/query)
 type specAPIKeyAuthenticator interface {
 	getContext() interface{}
@@ -21,6 +23,44 @@ func createAPIKeyAuthenticator(secDef SpecSecurityDefinition, value string) spec
 	return nil
 }
 
+// createSecurityDefinitionAuthenticator returns the authenticator for the security definition configured with the given
+// values keyed by provider property name
+func createSecurityDefinitionAuthenticator(secDef SpecSecurityDefinition, values map[string]string) specAPIKeyAuthenticator {
+	if credentialsSecDef, ok := secDef.(specCredentialsSecurityDefinition); ok {
+		return credentialsSecDef.createAuthenticator(values)
+	}
+	return createAPIKeyAuthenticator(secDef, values[secDef.GetTerraformConfigurationName()])
+}
+
+// specScopedAuthenticator defines the behaviour for authenticators whose credentials depend on the scopes required by
+// the operation's security requirement (e,g: OAuth2)
+type specScopedAuthenticator interface {
+	withScopes(scopes []string) specAPIKeyAuthenticator
+}
+
+// specRequestSignerAuthenticator defines the behaviour for authenticators that need to see the full outgoing request to
+// authenticate it (e,g: HMAC request signing). signRequest is called right before the request is sent with the request
+// containing the final method, URL and headers, and the body that will be sent. The authenticator can then add the headers
+// it requires to the request.
+type specRequestSignerAuthenticator interface {
+	signRequest(req *http.Request, body []byte) error
+}
+
+// specHTTPClientAuthenticator defines the behaviour for authenticators that make HTTP requests to obtain their credentials
+// (e,g: refresh token, OAuth2), so they use the same HTTP client (and TLS configuration) as the provider
+type specHTTPClientAuthenticator interface {
+	withHTTPClient(httpClient *http.Client) specAPIKeyAuthenticator
+}
+
+// configureAuthenticatorHTTPClient returns the authenticator configured with the given HTTP client if it makes HTTP requests,
+// or the authenticator as is otherwise
+func configureAuthenticatorHTTPClient(authenticator specAPIKeyAuthenticator, httpClient *http.Client) specAPIKeyAuthenticator {
+	if httpClientAuthenticator, ok := authenticator.(specHTTPClientAuthenticator); ok {
+		return httpClientAuthenticator.withHTTPClient(httpClient)
+	}
+	return authenticator
+}
+
 type apiKey struct {
 	name  string
 	value string
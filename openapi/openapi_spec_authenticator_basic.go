@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+const basicScheme = "Basic"
+
+// HTTP Basic Auth
+type basicAuthenticator struct {
+	terraformConfigurationName string
+	basicCredentials
+}
+
+type basicCredentials struct {
+	username string
+	password string
+}
+
+func newBasicAuthenticator(username, password, terraformConfigurationName string) basicAuthenticator {
+	return basicAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		basicCredentials: basicCredentials{
+			username: username,
+			password: password,
+		},
+	}
+}
+
+func (a basicAuthenticator) getContext() interface{} {
+	return a.basicCredentials
+}
+
+func (a basicAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth adds the Authorization header with the username and password encoded as specified in
+// https://tools.ietf.org/html/rfc7617#section-2. The url remains the same
+func (a basicAuthenticator) prepareAuth(authContext *authContext) error {
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", a.username, a.password)))
+	authContext.headers[authorizationHeader] = fmt.Sprintf("%s %s", basicScheme, credentials)
+	return nil
+}
+
+// validate checks the username is configured. The password is allowed to be empty as some APIs only expect a token
+// as the username
+func (a basicAuthenticator) validate() error {
+	if a.username == "" {
+		return fmt.Errorf("required security definition '%s' is missing the username. Please make sure the property '%s_%s' is configured with a value in the provider's terraform configuration", a.terraformConfigurationName, a.terraformConfigurationName, basicUsernamePropertySuffix)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuthenticatorPrepareAuth(t *testing.T) {
+	t.Run("happy path -- the Authorization header is populated with the base64 encoded credentials when the authContext have no headers map", func(t *testing.T) {
+		ctx := &authContext{}
+		err := newBasicAuthenticator("Aladdin", "open sesame", "basic_auth").prepareAuth(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==", ctx.headers[authorizationHeader])
+	})
+	t.Run("happy path -- the Authorization header is populated with the base64 encoded credentials when the password is empty", func(t *testing.T) {
+		ctx := &authContext{headers: map[string]string{}}
+		err := newBasicAuthenticator("token", "", "basic_auth").prepareAuth(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "Basic dG9rZW46", ctx.headers[authorizationHeader])
+	})
+}
+
+func TestBasicAuthenticatorValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authenticator basicAuthenticator
+		expectedError string
+	}{
+		{name: "validate passes since username and password are populated", authenticator: newBasicAuthenticator("user", "secret", "basic_auth"), expectedError: ""},
+		{name: "validate passes since username is populated and password is empty", authenticator: newBasicAuthenticator("user", "", "basic_auth"), expectedError: ""},
+		{name: "validate does not pass since username is NOT populated", authenticator: newBasicAuthenticator("", "secret", "basic_auth"), expectedError: "required security definition 'basic_auth' is missing the username. Please make sure the property 'basic_auth_username' is configured with a value in the provider's terraform configuration"},
+	}
+	for _, tc := range testCases {
+		err := tc.authenticator.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProcess defines an external command that prints the value of a provider property (e,g: a security definition
+// token) along with its expiry, similarly to the AWS credential_process
+type CredentialProcess struct {
+	Command []string
+	Timeout time.Duration
+}
+
+// credentialProcessOutput is the JSON object the credential process is expected to print to stdout
+type credentialProcessOutput struct {
+	Value     string `json:"value"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// getCredential executes the credential process and returns the value printed as an accessToken that must be refreshed
+// when it expires (or is about to). Values without expiry are kept until they are rejected by the API.
+func (c CredentialProcess) getCredential(requestedAt time.Time) (*accessToken, error) {
+	log.Printf("[INFO] executing credential process '%s'", c.Command)
+	stdout, err := runCommand(c.Command, c.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("credential process '%s' %s", c.Command, err)
+	}
+	output := credentialProcessOutput{}
+	// the output is not included in the errors as it may contain the credential
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return nil, fmt.Errorf("credential process '%s' output is not a valid JSON object: %s", c.Command, err)
+	}
+	if output.Value == "" {
+		return nil, fmt.Errorf("credential process '%s' output is missing the value", c.Command)
+	}
+	credential := &accessToken{value: output.Value}
+	if output.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, output.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("credential process '%s' output expires_at '%s' is not a valid RFC3339 time: %s", c.Command, output.ExpiresAt, err)
+		}
+		credential.refreshAt = newAccessTokenRefreshAt(requestedAt, expiresAt)
+	}
+	return credential, nil
+}
+
+// credentialProcessAuthenticator wraps the authenticator of a security definition whose values (all or some of them) are
+// obtained from credential processes. The processes are executed lazily the first time an API request requires the
+// security definition, and their values are cached until they expire or the API rejects them.
+type credentialProcessAuthenticator struct {
+	terraformConfigurationName string
+	// values contains the values configured by the user keyed by provider property name
+	values map[string]string
+	// processes contains the credential processes keyed by the provider property name they provide the value for
+	processes map[string]CredentialProcess
+	// createAuthenticator returns the security definition authenticator configured with the given values
+	createAuthenticator func(values map[string]string) specAPIKeyAuthenticator
+	credentials         *accessTokenCache
+	current             *credentialProcessAuthenticatorState
+}
+
+// credentialProcessAuthenticatorState holds the authenticator created with the latest values so it's only recreated
+// when the values change (preserving any state the authenticator may have, e,g: cached access tokens)
+type credentialProcessAuthenticatorState struct {
+	sync.Mutex
+	key           string
+	authenticator specAPIKeyAuthenticator
+}
+
+func newCredentialProcessAuthenticator(terraformConfigurationName string, values map[string]string, processes map[string]CredentialProcess, createAuthenticator func(values map[string]string) specAPIKeyAuthenticator) credentialProcessAuthenticator {
+	return credentialProcessAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		values:                     values,
+		processes:                  processes,
+		createAuthenticator:        createAuthenticator,
+		credentials:                newAccessTokenCache(),
+		current:                    &credentialProcessAuthenticatorState{},
+	}
+}
+
+func (a credentialProcessAuthenticator) getContext() interface{} {
+	a.current.Lock()
+	defer a.current.Unlock()
+	if a.current.authenticator == nil {
+		return nil
+	}
+	return a.current.authenticator.getContext()
+}
+
+// withHTTPClient returns a copy of the authenticator whose security definition authenticators are configured with the
+// given HTTP client
+func (a credentialProcessAuthenticator) withHTTPClient(httpClient *http.Client) specAPIKeyAuthenticator {
+	createAuthenticator := a.createAuthenticator
+	a.createAuthenticator = func(values map[string]string) specAPIKeyAuthenticator {
+		return configureAuthenticatorHTTPClient(createAuthenticator(values), httpClient)
+	}
+	return a
+}
+
+func (a credentialProcessAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth obtains the values from the credential processes (or the cache) and delegates the preparation of the auth
+// context to the security definition authenticator configured with them
+func (a credentialProcessAuthenticator) prepareAuth(authContext *authContext) error {
+	values := map[string]string{}
+	for propertyName, value := range a.values {
+		values[propertyName] = value
+	}
+	var propertyNames []string
+	for propertyName := range a.processes {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	var invalidators []func()
+	for _, propertyName := range propertyNames {
+		propertyName := propertyName
+		value, err := a.credentials.get(propertyName, a.processes[propertyName].getCredential)
+		if err != nil {
+			return fmt.Errorf("failed to obtain the value of '%s' for security definition '%s': %s", propertyName, a.terraformConfigurationName, err)
+		}
+		values[propertyName] = value
+		invalidators = append(invalidators, func() { a.credentials.invalidate(propertyName, value) })
+	}
+	authenticator := a.getAuthenticator(values, propertyNames)
+	if err := authenticator.validate(); err != nil {
+		return err
+	}
+	if err := authenticator.prepareAuth(authContext); err != nil {
+		return err
+	}
+	authContext.tokenInvalidators = append(authContext.tokenInvalidators, invalidators...)
+	if requestSigner, ok := authenticator.(specRequestSignerAuthenticator); ok {
+		authContext.requestSigners = append(authContext.requestSigners, requestSigner)
+	}
+	return nil
+}
+
+func (a credentialProcessAuthenticator) getAuthenticator(values map[string]string, propertyNames []string) specAPIKeyAuthenticator {
+	var keyValues []string
+	for _, propertyName := range propertyNames {
+		keyValues = append(keyValues, values[propertyName])
+	}
+	key := strings.Join(keyValues, "\x00")
+	a.current.Lock()
+	defer a.current.Unlock()
+	if a.current.authenticator == nil || a.current.key != key {
+		a.current.key = key
+		a.current.authenticator = a.createAuthenticator(values)
+	}
+	return a.current.authenticator
+}
+
+// validate does not check the values as they are only known once the credential processes are executed, the values are
+// validated in prepareAuth instead
+func (a credentialProcessAuthenticator) validate() error {
+	return nil
+}
@@ -0,0 +1,135 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCredentialProcess returns a credential process that prints the given output (formatted with the number of
+// times the process has been executed) and the func to read the number of executions
+func newTestCredentialProcess(t *testing.T, output string) (CredentialProcess, func() int) {
+	dir, err := ioutil.TempDir("", "credential_process")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	counter := filepath.Join(dir, "executions")
+	script := fmt.Sprintf(`echo x >> %s; n=$(wc -l < %s | tr -d ' '); printf '%s' $n`, counter, counter, output)
+	executions := func() int {
+		content, err := ioutil.ReadFile(counter)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(content), "x")
+	}
+	return CredentialProcess{Command: []string{"sh", "-c", script}, Timeout: 5 * time.Second}, executions
+}
+
+func TestCredentialProcessGetCredential(t *testing.T) {
+	requestedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name              string
+		command           []string
+		expectedValue     string
+		expectedRefreshAt time.Time
+		expectedError     string
+	}{
+		{name: "output with value and expiry", command: []string{"echo", `{"value":"token","expires_at":"2020-01-01T01:00:00Z"}`}, expectedValue: "token", expectedRefreshAt: requestedAt.Add(time.Hour - accessTokenExpiryDelta)},
+		{name: "output with value and no expiry", command: []string{"echo", `{"value":"token"}`}, expectedValue: "token"},
+		{name: "output is not JSON", command: []string{"echo", `token`}, expectedError: "credential process '[echo token]' output is not a valid JSON object: invalid character 'o' in literal true (expecting 'r')"},
+		{name: "output is missing the value", command: []string{"echo", `{"expires_at":"2020-01-01T01:00:00Z"}`}, expectedError: `credential process '[echo {"expires_at":"2020-01-01T01:00:00Z"}]' output is missing the value`},
+		{name: "output expiry is not RFC3339", command: []string{"echo", `{"value":"token","expires_at":"tomorrow"}`}, expectedError: `credential process '[echo {"value":"token","expires_at":"tomorrow"}]' output expires_at 'tomorrow' is not a valid RFC3339 time: parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`},
+		{name: "command fails", command: []string{"cat", "nonexistingfile"}, expectedError: "credential process '[cat nonexistingfile]' failed: cat: nonexistingfile: No such file or directory\n(exit status 1)"},
+	}
+	for _, tc := range testCases {
+		credential, err := CredentialProcess{Command: tc.command, Timeout: 5 * time.Second}.getCredential(requestedAt)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedValue, credential.value, tc.name)
+		assert.Equal(t, tc.expectedRefreshAt, credential.refreshAt, tc.name)
+	}
+
+	t.Run("crappy path -- the command does not finish within the timeout", func(t *testing.T) {
+		_, err := CredentialProcess{Command: []string{"sleep", "2"}, Timeout: time.Second}.getCredential(requestedAt)
+		assert.EqualError(t, err, "credential process '[sleep 2]' did not finish executing within the expected time 1s (signal: killed)")
+	})
+}
+
+func TestCredentialProcessAuthenticator(t *testing.T) {
+	createAPIKeyHeaderAuthenticator := func(values map[string]string) specAPIKeyAuthenticator {
+		return newAPIKeyHeaderAuthenticator(authorizationHeader, values["apikey_auth"], "apikey_auth")
+	}
+
+	t.Run("happy path -- the credential process is executed lazily and its value cached", func(t *testing.T) {
+		credentialProcess, executions := newTestCredentialProcess(t, `{"value":"token-%s"}`)
+		authenticator := newCredentialProcessAuthenticator("apikey_auth", map[string]string{}, map[string]CredentialProcess{"apikey_auth": credentialProcess}, createAPIKeyHeaderAuthenticator)
+		assert.NoError(t, authenticator.validate())
+		assert.Equal(t, 0, executions())
+
+		for i := 0; i < 3; i++ {
+			ctx := &authContext{headers: map[string]string{}}
+			assert.NoError(t, authenticator.prepareAuth(ctx))
+			assert.Equal(t, "token-1", ctx.headers[authorizationHeader])
+		}
+		assert.Equal(t, 1, executions())
+	})
+
+	t.Run("happy path -- the credential process is executed again when the value expires", func(t *testing.T) {
+		credentialProcess, executions := newTestCredentialProcess(t, `{"value":"token-%s","expires_at":"2020-01-01T01:00:00Z"}`)
+		authenticator := newCredentialProcessAuthenticator("apikey_auth", map[string]string{}, map[string]CredentialProcess{"apikey_auth": credentialProcess}, createAPIKeyHeaderAuthenticator)
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		authenticator.credentials.now = func() time.Time { return now }
+
+		ctx := &authContext{headers: map[string]string{}}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "token-1", ctx.headers[authorizationHeader])
+
+		now = now.Add(time.Hour)
+		ctx = &authContext{headers: map[string]string{}}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "token-2", ctx.headers[authorizationHeader])
+		assert.Equal(t, 2, executions())
+	})
+
+	t.Run("happy path -- the credential process is executed again when the value is rejected by the API", func(t *testing.T) {
+		credentialProcess, executions := newTestCredentialProcess(t, `{"value":"token-%s"}`)
+		authenticator := newCredentialProcessAuthenticator("apikey_auth", map[string]string{}, map[string]CredentialProcess{"apikey_auth": credentialProcess}, createAPIKeyHeaderAuthenticator)
+
+		ctx := &authContext{headers: map[string]string{}}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Len(t, ctx.tokenInvalidators, 1)
+		ctx.tokenInvalidators[0]()
+
+		ctx = &authContext{headers: map[string]string{}}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "token-2", ctx.headers[authorizationHeader])
+		assert.Equal(t, 2, executions())
+	})
+
+	t.Run("happy path -- the values configured by the user are combined with the ones obtained from the credential process", func(t *testing.T) {
+		credentialProcess, _ := newTestCredentialProcess(t, `{"value":"secret-%s"}`)
+		authenticator := newCredentialProcessAuthenticator("basic_auth", map[string]string{"basic_auth_username": "user"}, map[string]CredentialProcess{"basic_auth_password": credentialProcess}, func(values map[string]string) specAPIKeyAuthenticator {
+			return newBasicSecurityDefinition("basic_auth").createAuthenticator(values)
+		})
+
+		ctx := &authContext{}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, basicCredentials{username: "user", password: "secret-1"}, authenticator.getContext())
+		assert.Equal(t, "Basic dXNlcjpzZWNyZXQtMQ==", ctx.headers[authorizationHeader])
+	})
+
+	t.Run("crappy path -- the credential process fails", func(t *testing.T) {
+		authenticator := newCredentialProcessAuthenticator("apikey_auth", map[string]string{}, map[string]CredentialProcess{"apikey_auth": {Command: []string{"cat", "nonexistingfile"}, Timeout: 5 * time.Second}}, createAPIKeyHeaderAuthenticator)
+		err := authenticator.prepareAuth(&authContext{headers: map[string]string{}})
+		assert.EqualError(t, err, "failed to obtain the value of 'apikey_auth' for security definition 'apikey_auth': credential process '[cat nonexistingfile]' failed: cat: nonexistingfile: No such file or directory\n(exit status 1)")
+		assert.Nil(t, authenticator.getContext())
+	})
+}
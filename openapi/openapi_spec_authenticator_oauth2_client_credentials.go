@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OAuth2 client credentials flow auth
+type oauth2ClientCredentialsAuthenticator struct {
+	terraformConfigurationName string
+	oauth2ClientCredentials
+	tokenURL   string
+	scopes     []string
+	httpClient *http.Client
+	// tokens is shared by all the copies of the authenticator (e,g: the ones returned by withScopes) so the access tokens
+	// are requested once per provider instance and scopes
+	tokens *accessTokenCache
+}
+
+type oauth2ClientCredentials struct {
+	clientID     string
+	clientSecret string
+}
+
+// oauth2TokenResponse is the token endpoint successful response as defined in https://tools.ietf.org/html/rfc6749#section-5.1
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func newOAuth2ClientCredentialsAuthenticator(clientID, clientSecret, tokenURL, terraformConfigurationName string) oauth2ClientCredentialsAuthenticator {
+	return oauth2ClientCredentialsAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		oauth2ClientCredentials: oauth2ClientCredentials{
+			clientID:     clientID,
+			clientSecret: clientSecret,
+		},
+		tokenURL:   tokenURL,
+		httpClient: &http.Client{},
+		tokens:     newAccessTokenCache(),
+	}
+}
+
+func (a oauth2ClientCredentialsAuthenticator) getContext() interface{} {
+	return a.oauth2ClientCredentials
+}
+
+func (a oauth2ClientCredentialsAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// withScopes returns a copy of the authenticator that requests access tokens for the given scopes, as specified in the
+// operation's security requirement
+func (a oauth2ClientCredentialsAuthenticator) withScopes(scopes []string) specAPIKeyAuthenticator {
+	a.scopes = scopes
+	return a
+}
+
+// withHTTPClient returns a copy of the authenticator that requests the access tokens with the given HTTP client
+func (a oauth2ClientCredentialsAuthenticator) withHTTPClient(httpClient *http.Client) specAPIKeyAuthenticator {
+	a.httpClient = httpClient
+	return a
+}
+
+// prepareAuth adds the Authorization header with the access token (using the Bearer scheme) obtained from the token URL.
+// The access token is cached per scopes and only requested again when it is about to expire or the API rejects it.
+func (a oauth2ClientCredentialsAuthenticator) prepareAuth(authContext *authContext) error {
+	scopes := append([]string{}, a.scopes...)
+	sort.Strings(scopes)
+	cacheKey := strings.Join(scopes, " ")
+	token, err := a.tokens.get(cacheKey, func(requestedAt time.Time) (*accessToken, error) {
+		return a.requestAccessToken(scopes, requestedAt)
+	})
+	if err != nil {
+		return err
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	authContext.headers[authorizationHeader] = fmt.Sprintf("%s %s", bearerScheme, token)
+	authContext.tokenInvalidators = append(authContext.tokenInvalidators, func() { a.tokens.invalidate(cacheKey, token) })
+	return nil
+}
+
+// requestAccessToken performs the client credentials grant request as defined in https://tools.ietf.org/html/rfc6749#section-4.4
+func (a oauth2ClientCredentialsAuthenticator) requestAccessToken(scopes []string, requestedAt time.Time) (*accessToken, error) {
+	log.Printf("[DEBUG] requesting OAuth2 access token from '%s' for scopes %v", a.tokenURL, scopes)
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.clientID), url.QueryEscape(a.clientSecret))
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OAuth2 token POST response '%s' status code '%d' not matching expected response status code [%d]", a.tokenURL, res.StatusCode, http.StatusOK)
+	}
+	tokenResponse := oauth2TokenResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("OAuth2 token POST response '%s' is not valid: %s", a.tokenURL, err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("OAuth2 token POST response '%s' is missing the access token", a.tokenURL)
+	}
+	if tokenResponse.TokenType != "" && !strings.EqualFold(tokenResponse.TokenType, bearerScheme) {
+		return nil, fmt.Errorf("OAuth2 token POST response '%s' token type '%s' not supported, only '%s' tokens are supported", a.tokenURL, tokenResponse.TokenType, bearerScheme)
+	}
+	token := &accessToken{value: tokenResponse.AccessToken}
+	if tokenResponse.ExpiresIn > 0 {
+		token.refreshAt = newAccessTokenRefreshAt(requestedAt, requestedAt.Add(time.Duration(tokenResponse.ExpiresIn)*time.Second))
+	}
+	return token, nil
+}
+
+func (a oauth2ClientCredentialsAuthenticator) validate() error {
+	if a.clientID == "" || a.clientSecret == "" {
+		return fmt.Errorf("required security definition '%s' is missing the client credentials. Please make sure the properties '%s_%s' and '%s_%s' are configured with a value in the provider's terraform configuration", a.terraformConfigurationName, a.terraformConfigurationName, oauth2ClientIDPropertySuffix, a.terraformConfigurationName, oauth2ClientSecretPropertySuffix)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newOAuth2TokenFakeServer(t *testing.T, expiresIn int, requests *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		*requests = append(*requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, len(*requests), expiresIn)
+	}))
+}
+
+func Test_OAuth2ClientCredentialsAuthenticator_Successfully_Prepares_Authorization(t *testing.T) {
+	t.Run("happy path -- the access token is requested with the client credentials and injected as a Bearer token", func(t *testing.T) {
+		requests := []*http.Request{}
+		tokenServer := newOAuth2TokenFakeServer(t, 3600, &requests)
+		defer tokenServer.Close()
+		authenticator := newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", tokenServer.URL, "oauth2_auth")
+
+		ctx := &authContext{}
+		err := authenticator.prepareAuth(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+		assert.Len(t, requests, 1)
+		clientID, clientSecret, ok := requests[0].BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "clientID", clientID)
+		assert.Equal(t, "clientSecret", clientSecret)
+		assert.Equal(t, "client_credentials", requests[0].PostForm.Get("grant_type"))
+		assert.Empty(t, requests[0].PostForm.Get("scope"))
+	})
+
+	t.Run("happy path -- the access token is cached and shared with the copies of the authenticator", func(t *testing.T) {
+		requests := []*http.Request{}
+		tokenServer := newOAuth2TokenFakeServer(t, 3600, &requests)
+		defer tokenServer.Close()
+		authenticator := newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", tokenServer.URL, "oauth2_auth")
+		authenticatorCopy := authenticator
+
+		for _, a := range []oauth2ClientCredentialsAuthenticator{authenticator, authenticatorCopy, authenticator} {
+			ctx := &authContext{headers: map[string]string{}}
+			assert.NoError(t, a.prepareAuth(ctx))
+			assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+		}
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("happy path -- a new access token is requested before the cached one expires", func(t *testing.T) {
+		requests := []*http.Request{}
+		tokenServer := newOAuth2TokenFakeServer(t, 3600, &requests)
+		defer tokenServer.Close()
+		authenticator := newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", tokenServer.URL, "oauth2_auth")
+		now := time.Now()
+		authenticator.tokens.now = func() time.Time { return now }
+
+		ctx := &authContext{}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+
+		now = now.Add(time.Hour - accessTokenExpiryDelta - time.Second)
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+
+		now = now.Add(time.Second)
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer token-2", ctx.headers[authorizationHeader])
+	})
+
+	t.Run("happy path -- the access tokens are requested and cached per scopes required by the operation", func(t *testing.T) {
+		requests := []*http.Request{}
+		tokenServer := newOAuth2TokenFakeServer(t, 3600, &requests)
+		defer tokenServer.Close()
+		providerConfig := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"oauth2_auth": newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", tokenServer.URL, "oauth2_auth"),
+			},
+		}
+		apiAuthenticator := newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "oauth2_auth", Scopes: []string{"read"}}})
+
+		ctx, err := apiAuthenticator.prepareAuth("https://www.host.com/v1/resource", SpecSecuritySchemes{}, providerConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+		ctx, err = apiAuthenticator.prepareAuth("https://www.host.com/v1/resource", SpecSecuritySchemes{SpecSecurityScheme{Name: "oauth2_auth", Scopes: []string{"write", "read"}}}, providerConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token-2", ctx.headers[authorizationHeader])
+		ctx, err = apiAuthenticator.prepareAuth("https://www.host.com/v1/resource", SpecSecuritySchemes{SpecSecurityScheme{Name: "oauth2_auth", Scopes: []string{"read", "write"}}}, providerConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token-2", ctx.headers[authorizationHeader])
+
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "read", requests[0].PostForm.Get("scope"))
+		assert.Equal(t, "read write", requests[1].PostForm.Get("scope"))
+	})
+}
+
+func Test_OAuth2ClientCredentialsAuthenticator_Fails_To_Prepare_Authorization(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError string
+	}{
+		{name: "the token endpoint returns a non expected status code", statusCode: http.StatusUnauthorized, body: `{"error":"invalid_client"}`, expectedError: "OAuth2 token POST response '%s' status code '401' not matching expected response status code [200]"},
+		{name: "the token endpoint response is missing the access token", statusCode: http.StatusOK, body: `{"token_type":"bearer"}`, expectedError: "OAuth2 token POST response '%s' is missing the access token"},
+		{name: "the token endpoint response is not valid JSON", statusCode: http.StatusOK, body: `not json`, expectedError: "OAuth2 token POST response '%s' is not valid: invalid character 'o' in literal null (expecting 'u')"},
+		{name: "the token endpoint response contains an unsupported token type", statusCode: http.StatusOK, body: `{"access_token":"token","token_type":"mac"}`, expectedError: "OAuth2 token POST response '%s' token type 'mac' not supported, only 'Bearer' tokens are supported"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("crappy path -- %s", tc.name), func(t *testing.T) {
+			tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}))
+			defer tokenServer.Close()
+			authenticator := newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", tokenServer.URL, "oauth2_auth")
+			ctx := &authContext{}
+			err := authenticator.prepareAuth(ctx)
+			assert.EqualError(t, err, fmt.Sprintf(tc.expectedError, tokenServer.URL))
+			assert.Empty(t, ctx.headers[authorizationHeader])
+		})
+	}
+}
+
+func Test_OAuth2ClientCredentialsAuthenticator_Validate(t *testing.T) {
+	t.Run("happy path -- the client credentials are configured", func(t *testing.T) {
+		assert.NoError(t, newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", "https://api.iam.com/oauth2/token", "oauth2_auth").validate())
+	})
+	t.Run("crappy path -- the client secret is missing", func(t *testing.T) {
+		err := newOAuth2ClientCredentialsAuthenticator("clientID", "", "https://api.iam.com/oauth2/token", "oauth2_auth").validate()
+		assert.EqualError(t, err, "required security definition 'oauth2_auth' is missing the client credentials. Please make sure the properties 'oauth2_auth_client_id' and 'oauth2_auth_client_secret' are configured with a value in the provider's terraform configuration")
+	})
+}
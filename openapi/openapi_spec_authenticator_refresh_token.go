@@ -1,10 +1,14 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/dikhan/http_goclient"
+	"io/ioutil"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Api Key Header Auth
@@ -13,6 +17,13 @@ type apiRefreshTokenAuthenticator struct {
 	apiKey
 	refreshTokenURL string
 	httpClient      http_goclient.HttpClientIface
+	// tokens is shared by all the copies of the authenticator so the access token is requested once per provider instance
+	tokens *accessTokenCache
+}
+
+// refreshTokenResponse is the optional body returned along with the access token, containing its lifetime in seconds
+type refreshTokenResponse struct {
+	ExpiresIn float64 `json:"expires_in"`
 }
 
 func newAPIRefreshTokenAuthenticator(name, refreshToken, refreshTokenURL, terraformConfigurationName string) apiRefreshTokenAuthenticator {
@@ -24,6 +35,7 @@ func newAPIRefreshTokenAuthenticator(name, refreshToken, refreshTokenURL, terraf
 		},
 		refreshTokenURL: refreshTokenURL,
 		httpClient:      &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		tokens:          newAccessTokenCache(),
 	}
 }
 
@@ -35,27 +47,71 @@ func (a apiRefreshTokenAuthenticator) getType() authType {
 	return authTypeAPIKeyHeader
 }
 
+// withHTTPClient returns a copy of the authenticator that requests the access tokens with the given HTTP client
+func (a apiRefreshTokenAuthenticator) withHTTPClient(httpClient *http.Client) specAPIKeyAuthenticator {
+	a.httpClient = &http_goclient.HttpClient{HttpClient: httpClient}
+	return a
+}
+
 // prepareAuth will send a post request to the refreshTokenURL and get the access token from the response Authorization
-// header. Otherwise, it will fail.
+// header. Otherwise, it will fail. The access token is cached and only requested again when it is about to expire or
+// the API rejects it.
 func (a apiRefreshTokenAuthenticator) prepareAuth(authContext *authContext) error {
+	token, err := a.tokens.get("", a.requestAccessToken)
+	if err != nil {
+		return err
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	authContext.headers[authorizationHeader] = token
+	authContext.tokenInvalidators = append(authContext.tokenInvalidators, func() { a.tokens.invalidate("", token) })
+	return nil
+}
+
+// requestAccessToken posts the refresh token to the refreshTokenURL. The expiry of the access token is read from the
+// 'expires_in' property of the response body if present, or from the 'exp' claim if the access token is a JWT. Tokens
+// with an unknown expiry are cached until the API rejects them.
+func (a apiRefreshTokenAuthenticator) requestAccessToken(requestedAt time.Time) (*accessToken, error) {
+	log.Printf("[DEBUG] requesting access token from '%s'", a.refreshTokenURL)
 	apiKey := a.getContext().(apiKey)
 	headers := map[string]string{apiKey.name: apiKey.value}
 	r, err := a.httpClient.PostJson(a.refreshTokenURL, headers, nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
+		return nil, fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
 	}
-	accessToken := r.Header.Get(authorizationHeader)
-	if accessToken == "" {
-		return fmt.Errorf("refresh token POST response '%s' is missing the access token", a.refreshTokenURL)
+	token := &accessToken{value: r.Header.Get(authorizationHeader)}
+	if token.value == "" {
+		return nil, fmt.Errorf("refresh token POST response '%s' is missing the access token", a.refreshTokenURL)
 	}
-	if authContext.headers == nil {
-		authContext.headers = map[string]string{}
+	if expiresIn := a.getExpiresIn(r); expiresIn > 0 {
+		token.refreshAt = newAccessTokenRefreshAt(requestedAt, requestedAt.Add(expiresIn))
+	} else if expiresAt, ok := getJWTExpiry(token.value); ok {
+		token.refreshAt = newAccessTokenRefreshAt(requestedAt, expiresAt)
 	}
-	authContext.headers[authorizationHeader] = accessToken
-	return nil
+	return token, nil
+}
+
+// getExpiresIn returns the lifetime of the access token from the response body 'expires_in' property, zero if the body
+// is empty or does not contain it
+func (a apiRefreshTokenAuthenticator) getExpiresIn(r *http.Response) time.Duration {
+	if r.Body == nil {
+		return 0
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		return 0
+	}
+	tokenResponse := refreshTokenResponse{}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		log.Printf("[DEBUG] refresh token POST response '%s' body is not a JSON object, ignoring it: %s", a.refreshTokenURL, err)
+		return 0
+	}
+	return time.Duration(tokenResponse.ExpiresIn * float64(time.Second))
 }
 
 func (a apiRefreshTokenAuthenticator) validate() error {
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/dikhan/http_goclient"
 
@@ -42,6 +45,89 @@ func Test_ApiKeyRefreshTokenAuthenticator_Successfully_Prepares_Authorization(t
 
 }
 
+func Test_ApiKeyRefreshTokenAuthenticator_Caches_The_Access_Token(t *testing.T) {
+	t.Run("happy path -- the access token is requested once and shared by concurrent requests and the copies of the authenticator", func(t *testing.T) {
+		var tokenRequests int32
+		accessTokenFakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&tokenRequests, 1)
+			w.Header().Add(authorizationHeader, "Bearer token")
+		}))
+		defer accessTokenFakeServer.Close()
+		refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator(authorizationHeader, "refreshToken", accessTokenFakeServer.URL, "refresh_token")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(a apiRefreshTokenAuthenticator) {
+				defer wg.Done()
+				ctx := &authContext{}
+				assert.NoError(t, a.prepareAuth(ctx))
+				assert.Equal(t, "Bearer token", ctx.headers[authorizationHeader])
+			}(refreshTokenAuthenticator)
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), tokenRequests)
+	})
+
+	testCases := []struct {
+		name              string
+		accessToken       string
+		body              string
+		expectedRefreshAt time.Duration
+	}{
+		{name: "the expiry is read from the expires_in property of the response body", accessToken: "Bearer token", body: `{"expires_in":3600}`, expectedRefreshAt: time.Hour - accessTokenExpiryDelta},
+		{name: "the expiry is read from the exp claim of the JWT access token", accessToken: "Bearer " + newTestJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(2*time.Hour).Unix())), body: "", expectedRefreshAt: 2*time.Hour - accessTokenExpiryDelta},
+		{name: "the expires_in property takes precedence over the exp claim of the JWT access token", accessToken: "Bearer " + newTestJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(2*time.Hour).Unix())), body: `{"expires_in":3600}`, expectedRefreshAt: time.Hour - accessTokenExpiryDelta},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("happy path -- %s and the token is refreshed before it expires", tc.name), func(t *testing.T) {
+			tokenRequests := 0
+			accessTokenFakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tokenRequests++
+				w.Header().Add(authorizationHeader, tc.accessToken)
+				w.Write([]byte(tc.body))
+			}))
+			defer accessTokenFakeServer.Close()
+			refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator(authorizationHeader, "refreshToken", accessTokenFakeServer.URL, "refresh_token")
+			now := time.Now().Truncate(time.Second)
+			refreshTokenAuthenticator.tokens.now = func() time.Time { return now }
+			start := now
+
+			assert.NoError(t, refreshTokenAuthenticator.prepareAuth(&authContext{}))
+			now = start.Add(tc.expectedRefreshAt - 2*time.Second)
+			assert.NoError(t, refreshTokenAuthenticator.prepareAuth(&authContext{}))
+			assert.Equal(t, 1, tokenRequests)
+			now = start.Add(tc.expectedRefreshAt + 2*time.Second)
+			assert.NoError(t, refreshTokenAuthenticator.prepareAuth(&authContext{}))
+			assert.Equal(t, 2, tokenRequests)
+		})
+	}
+
+	t.Run("happy path -- the access token with an unknown expiry is cached until it is invalidated", func(t *testing.T) {
+		tokenRequests := 0
+		accessTokenFakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenRequests++
+			w.Header().Add(authorizationHeader, fmt.Sprintf("Bearer token-%d", tokenRequests))
+			w.Write([]byte(`not json`))
+		}))
+		defer accessTokenFakeServer.Close()
+		refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator(authorizationHeader, "refreshToken", accessTokenFakeServer.URL, "refresh_token")
+
+		ctx := &authContext{}
+		assert.NoError(t, refreshTokenAuthenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer token-1", ctx.headers[authorizationHeader])
+		assert.Len(t, ctx.tokenInvalidators, 1)
+		assert.NoError(t, refreshTokenAuthenticator.prepareAuth(&authContext{}))
+		assert.Equal(t, 1, tokenRequests)
+
+		ctx.tokenInvalidators[0]()
+		ctx = &authContext{}
+		assert.NoError(t, refreshTokenAuthenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer token-2", ctx.headers[authorizationHeader])
+		assert.Equal(t, 2, tokenRequests)
+	})
+}
+
 func Test_ApiKeyRefreshTokenAuthenticator_Fails_To_Prepare_Authorization(t *testing.T) {
 	t.Run("crappy path -- the API Server providing the access token does not return the expected Authorization header containing the access token", func(t *testing.T) {
 		fakeRefreshToken := `eyJ[...]RW.eyJ[...]WQi.eyd[...]SWr`
@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const hmacDateHeader = "X-Date"
+const hmacContentHashHeader = "X-Content-Sha256"
+const awsSigV4DateHeader = "X-Amz-Date"
+const awsSigV4SecurityTokenHeader = "X-Amz-Security-Token"
+const awsSigV4Algorithm = "AWS4-HMAC-SHA256"
+
+// signingTimeFormat is the ISO8601 basic format used in the date headers of the signed requests
+const signingTimeFormat = "20060102T150405Z"
+
+type requestSigningCredentials struct {
+	keyID     string
+	secretKey string
+	// sessionToken is the token of temporary credentials (AWS SigV4 only), empty for long-term credentials
+	sessionToken string
+}
+
+// HMAC request signing auth
+type hmacAuthenticator struct {
+	terraformConfigurationName string
+	requestSigningCredentials
+	headerName string
+	algorithm  string
+	now        func() time.Time
+}
+
+// AWS Signature Version 4 request signing auth
+type awsSigV4Authenticator struct {
+	terraformConfigurationName string
+	requestSigningCredentials
+	region  string
+	service string
+	now     func() time.Time
+}
+
+func newHMACAuthenticator(credentials requestSigningCredentials, headerName, algorithm, terraformConfigurationName string) hmacAuthenticator {
+	return hmacAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		requestSigningCredentials:  credentials,
+		headerName:                 headerName,
+		algorithm:                  algorithm,
+		now:                        time.Now,
+	}
+}
+
+func newAWSSigV4Authenticator(credentials requestSigningCredentials, region, service, terraformConfigurationName string) awsSigV4Authenticator {
+	return awsSigV4Authenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		requestSigningCredentials:  credentials,
+		region:                     region,
+		service:                    service,
+		now:                        time.Now,
+	}
+}
+
+func (a hmacAuthenticator) getContext() interface{} {
+	return a.requestSigningCredentials
+}
+
+func (a hmacAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth does not alter the auth context as the signature can only be computed once the full request is known, see signRequest
+func (a hmacAuthenticator) prepareAuth(authContext *authContext) error {
+	return nil
+}
+
+// signRequest adds the date and content hash headers to the request and the signature header containing the HMAC of
+// the canonical request (method, path, query, signed headers and body hash), with the following format:
+// HMAC-SHA256 Credential=<key_id>, SignedHeaders=content-type;host;x-content-sha256;x-date, Signature=<hex encoded HMAC>
+func (a hmacAuthenticator) signRequest(req *http.Request, body []byte) error {
+	newHash := sha256.New
+	if a.algorithm == hmacSHA512Algorithm {
+		newHash = sha512.New
+	}
+	date := a.now().UTC().Format(signingTimeFormat)
+	payloadHash := hashHex(newHash, body)
+	req.Header.Set(hmacDateHeader, date)
+	req.Header.Set(hmacContentHashHeader, payloadHash)
+
+	signedHeaders := getSignedHeaders(req, hmacDateHeader, hmacContentHashHeader)
+	canonicalRequest := buildCanonicalRequest(req, req.URL.EscapedPath(), signedHeaders, payloadHash)
+	algorithm := strings.ToUpper(a.algorithm)
+	stringToSign := strings.Join([]string{algorithm, date, hashHex(newHash, []byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSum(newHash, []byte(a.secretKey), stringToSign))
+	req.Header.Set(a.headerName, fmt.Sprintf("%s Credential=%s, SignedHeaders=%s, Signature=%s", algorithm, a.keyID, strings.Join(signedHeaders, ";"), signature))
+	return nil
+}
+
+func (a hmacAuthenticator) validate() error {
+	return a.requestSigningCredentials.validate(a.terraformConfigurationName)
+}
+
+func (a awsSigV4Authenticator) getContext() interface{} {
+	return a.requestSigningCredentials
+}
+
+func (a awsSigV4Authenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth does not alter the auth context as the signature can only be computed once the full request is known, see signRequest
+func (a awsSigV4Authenticator) prepareAuth(authContext *authContext) error {
+	return nil
+}
+
+// signRequest adds the X-Amz-Date and Authorization headers (and the X-Amz-Security-Token header if the credentials are
+// temporary) to the request following the AWS Signature Version 4 signing process:
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+func (a awsSigV4Authenticator) signRequest(req *http.Request, body []byte) error {
+	now := a.now().UTC()
+	date := now.Format(signingTimeFormat)
+	req.Header.Set(awsSigV4DateHeader, date)
+	signerHeaders := []string{awsSigV4DateHeader}
+	if a.sessionToken != "" {
+		req.Header.Set(awsSigV4SecurityTokenHeader, a.sessionToken)
+		signerHeaders = append(signerHeaders, awsSigV4SecurityTokenHeader)
+	}
+
+	payloadHash := hashHex(sha256.New, body)
+	signedHeaders := getSignedHeaders(req, signerHeaders...)
+	canonicalRequest := buildCanonicalRequest(req, a.getCanonicalURI(req), signedHeaders, payloadHash)
+	scope := strings.Join([]string{now.Format("20060102"), a.region, a.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{awsSigV4Algorithm, date, scope, hashHex(sha256.New, []byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSum(sha256.New, []byte("AWS4"+a.secretKey), now.Format("20060102"))
+	for _, scopePart := range []string{a.region, a.service, "aws4_request"} {
+		signingKey = hmacSum(sha256.New, signingKey, scopePart)
+	}
+	signature := hex.EncodeToString(hmacSum(sha256.New, signingKey, stringToSign))
+	req.Header.Set(authorizationHeader, fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", awsSigV4Algorithm, a.keyID, scope, strings.Join(signedHeaders, ";"), signature))
+	return nil
+}
+
+func (a awsSigV4Authenticator) validate() error {
+	return a.requestSigningCredentials.validate(a.terraformConfigurationName)
+}
+
+// getCanonicalURI returns the normalized path of the request with each segment URI-encoded twice, except for the S3
+// service which paths are neither normalized nor encoded twice
+func (a awsSigV4Authenticator) getCanonicalURI(req *http.Request) string {
+	escapedPath := req.URL.EscapedPath()
+	if a.service != "s3" && escapedPath != "" {
+		cleanPath := path.Clean(escapedPath)
+		if strings.HasSuffix(escapedPath, "/") && cleanPath != "/" {
+			cleanPath += "/"
+		}
+		escapedPath = cleanPath
+	}
+	segments := strings.Split(escapedPath, "/")
+	for i, segment := range segments {
+		if unescapedSegment, err := url.PathUnescape(segment); err == nil {
+			segment = unescapedSegment
+		}
+		segments[i] = uriEncode(segment)
+		if a.service != "s3" {
+			segments[i] = uriEncode(segments[i])
+		}
+	}
+	if canonicalURI := strings.Join(segments, "/"); canonicalURI != "" {
+		return canonicalURI
+	}
+	return "/"
+}
+
+func (c requestSigningCredentials) validate(terraformConfigurationName string) error {
+	if c.keyID == "" || c.secretKey == "" {
+		return fmt.Errorf("required security definition '%s' is missing the signing credentials. Please make sure the properties '%s_%s' and '%s_%s' are configured with a value in the provider's terraform configuration", terraformConfigurationName, terraformConfigurationName, requestSigningKeyIDPropertySuffix, terraformConfigurationName, requestSigningSecretKeyPropertySuffix)
+	}
+	return nil
+}
+
+// getSignedHeaders returns the sorted lower case names of the headers included in the signature: the host, the content
+// type if present and the given headers set by the signer
+func getSignedHeaders(req *http.Request, signerHeaders ...string) []string {
+	signedHeaders := []string{"host"}
+	if req.Header.Get(contentType) != "" {
+		signedHeaders = append(signedHeaders, "content-type")
+	}
+	for _, header := range signerHeaders {
+		signedHeaders = append(signedHeaders, strings.ToLower(header))
+	}
+	sort.Strings(signedHeaders)
+	return signedHeaders
+}
+
+// buildCanonicalRequest returns the canonical representation of the request as defined in the AWS Signature Version 4
+// signing process: method, canonical path, sorted query, signed headers (name:value) and payload hash, separated by new lines
+func buildCanonicalRequest(req *http.Request, canonicalURI string, signedHeaders []string, payloadHash string) string {
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalHeaders := ""
+	for _, header := range signedHeaders {
+		value := req.Header.Get(header)
+		if header == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		canonicalHeaders += fmt.Sprintf("%s:%s\n", header, strings.Join(strings.Fields(value), " "))
+	}
+	return strings.Join([]string{req.Method, canonicalURI, buildCanonicalQuery(req.URL.Query()), canonicalHeaders, strings.Join(signedHeaders, ";"), payloadHash}, "\n")
+}
+
+func buildCanonicalQuery(query url.Values) string {
+	var params []string
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, fmt.Sprintf("%s=%s", uriEncode(key), uriEncode(value)))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes the value as specified in RFC 3986 (spaces encoded as %20 and '~' not encoded)
+func uriEncode(value string) string {
+	return strings.Replace(strings.Replace(url.QueryEscape(value), "+", "%20", -1), "%7E", "~", -1)
+}
+
+func hashHex(newHash func() hash.Hash, data []byte) string {
+	h := newHash()
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hmacSum(newHash func() hash.Hash, key []byte, data string) []byte {
+	mac := hmac.New(newHash, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// awsSigV4TestSuiteSessionToken is the session token used by the AWS SigV4 test suite (post-sts-token)
+const awsSigV4TestSuiteSessionToken = "AQoDYXdzEPT//////////wEXAMPLEtc764bNrC9SAPBSM22wDOk4x4HIZ8j4FZTwdQWLWsKWHGBuFqwAeMicRXmxfpSPfIeoIYRqTflfKD8YUuwthAx7mSEI/qkPpKPi/kMcGdQrmGdeehM4IC1NtBmUpp2wUE8phUZampKsburEDy0KPkyQDYwT7WZ0wq5VSXDvp75YU9HFvlRd8Tx6q6fE8YQcHNVXAkiY9q6d+xo0rKwT38xVqr7ZD0u0iPPkUL64lIZbqBAz+scqKmlzm8FDrypNC9Yjc8fPOLn9FX9KSYvKTr4rvx3iSIlTJabIQwj2ICCR/oLxBA=="
+
+func TestAWSSigV4AuthenticatorSignRequest(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		method                string
+		url                   string
+		sessionToken          string
+		expectedSignedHeaders string
+		expectedSignature     string
+	}{
+		{name: "get-vanilla", method: http.MethodGet, url: "https://example.amazonaws.com/", expectedSignedHeaders: "host;x-amz-date", expectedSignature: "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{name: "get-unreserved", method: http.MethodGet, url: "https://example.amazonaws.com/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", expectedSignedHeaders: "host;x-amz-date", expectedSignature: "07ef7494c76fa4850883e2b006601f940f8a34d404d0cfa977f52a65bbf5f24f"},
+		{name: "normalize-path/get-slashes", method: http.MethodGet, url: "https://example.amazonaws.com//example//", expectedSignedHeaders: "host;x-amz-date", expectedSignature: "9a624bd73a37c9a373b5312afbebe7a714a789de108f0bdfe846570885f57e84"},
+		{name: "normalize-path/get-relative", method: http.MethodGet, url: "https://example.amazonaws.com/example/..", expectedSignedHeaders: "host;x-amz-date", expectedSignature: "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{name: "post-sts-token/post-sts-header-before", method: http.MethodPost, url: "https://example.amazonaws.com/", sessionToken: awsSigV4TestSuiteSessionToken, expectedSignedHeaders: "host;x-amz-date;x-amz-security-token", expectedSignature: "85d96828115b5dc0cfc3bd16ad9e210dd772bbebba041836c64533a82be05ead"},
+	}
+	for _, tc := range testCases {
+		authenticator := newAWSSigV4Authenticator(requestSigningCredentials{keyID: "AKIDEXAMPLE", secretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", sessionToken: tc.sessionToken}, "us-east-1", "service", "sigv4_auth")
+		authenticator.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+		req, _ := http.NewRequest(tc.method, tc.url, nil)
+
+		err := authenticator.signRequest(req, nil)
+
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, "20150830T123600Z", req.Header.Get(awsSigV4DateHeader), tc.name)
+		assert.Equal(t, tc.sessionToken, req.Header.Get(awsSigV4SecurityTokenHeader), tc.name)
+		assert.Equal(t, fmt.Sprintf("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=%s, Signature=%s", tc.expectedSignedHeaders, tc.expectedSignature), req.Header.Get(authorizationHeader), tc.name)
+	}
+}
+
+func TestAWSSigV4AuthenticatorGetCanonicalURI(t *testing.T) {
+	// the canonical paths of the AWS SigV4 test suite (get-space and get-utf8) are encoded once, as done for S3. The
+	// rest of the services encode each path segment twice.
+	testCases := []struct {
+		name                 string
+		service              string
+		url                  string
+		expectedCanonicalURI string
+	}{
+		{name: "get-space", service: "s3", url: "https://example.amazonaws.com/example space/", expectedCanonicalURI: "/example%20space/"},
+		{name: "get-utf8", service: "s3", url: "https://example.amazonaws.com/ሴ", expectedCanonicalURI: "/%E1%88%B4"},
+		{name: "get-space encoded twice", service: "execute-api", url: "https://example.amazonaws.com/example space/", expectedCanonicalURI: "/example%2520space/"},
+		{name: "get-utf8 encoded twice", service: "execute-api", url: "https://example.amazonaws.com/ሴ", expectedCanonicalURI: "/%25E1%2588%25B4"},
+		{name: "reserved characters encoded twice", service: "execute-api", url: "https://example.amazonaws.com/v1/users/a=b&c:d/", expectedCanonicalURI: "/v1/users/a%253Db%2526c%253Ad/"},
+		{name: "encoded slash within a segment", service: "execute-api", url: "https://example.amazonaws.com/v1/files/a%2Fb", expectedCanonicalURI: "/v1/files/a%252Fb"},
+		{name: "S3 paths are not normalized", service: "s3", url: "https://example.amazonaws.com//example//", expectedCanonicalURI: "//example//"},
+		{name: "empty path", service: "execute-api", url: "https://example.amazonaws.com", expectedCanonicalURI: "/"},
+	}
+	for _, tc := range testCases {
+		authenticator := newAWSSigV4Authenticator(requestSigningCredentials{}, "us-east-1", tc.service, "sigv4_auth")
+		req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+		assert.Equal(t, tc.expectedCanonicalURI, authenticator.getCanonicalURI(req), tc.name)
+	}
+}
+
+func TestHMACAuthenticatorSignRequest(t *testing.T) {
+	t.Run("happy path -- the request is signed with the HMAC of the canonical request", func(t *testing.T) {
+		authenticator := newHMACAuthenticator(requestSigningCredentials{keyID: "keyID", secretKey: "secret"}, "X-Signature", hmacSHA256Algorithm, "hmac_auth")
+		authenticator.now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+		body := []byte(`{"name":"some name"}`)
+		req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/v1/resource?b=2+3&a=1", bytes.NewReader(body))
+		req.Header.Set(contentType, "application/json")
+
+		err := authenticator.signRequest(req, body)
+
+		bodyHash := sha256.Sum256(body)
+		expectedBodyHash := hex.EncodeToString(bodyHash[:])
+		canonicalRequest := fmt.Sprintf("POST\n/v1/resource\na=1&b=2%%203\ncontent-type:application/json\nhost:api.example.com\nx-content-sha256:%s\nx-date:20200101T000000Z\n\ncontent-type;host;x-content-sha256;x-date\n%s", expectedBodyHash, expectedBodyHash)
+		canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte("HMAC-SHA256\n20200101T000000Z\n" + hex.EncodeToString(canonicalRequestHash[:])))
+		expectedSignature := hex.EncodeToString(mac.Sum(nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "20200101T000000Z", req.Header.Get(hmacDateHeader))
+		assert.Equal(t, expectedBodyHash, req.Header.Get(hmacContentHashHeader))
+		assert.Equal(t, fmt.Sprintf("HMAC-SHA256 Credential=keyID, SignedHeaders=content-type;host;x-content-sha256;x-date, Signature=%s", expectedSignature), req.Header.Get("X-Signature"))
+		assert.Empty(t, req.Header.Get(authorizationHeader))
+	})
+
+	t.Run("happy path -- the request is signed with the HMAC-SHA512 algorithm", func(t *testing.T) {
+		authenticator := newHMACAuthenticator(requestSigningCredentials{keyID: "keyID", secretKey: "secret"}, authorizationHeader, hmacSHA512Algorithm, "hmac_auth")
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/v1/resource", nil)
+
+		err := authenticator.signRequest(req, nil)
+
+		assert.NoError(t, err)
+		assert.Regexp(t, "^HMAC-SHA512 Credential=keyID, SignedHeaders=host;x-content-sha256;x-date, Signature=[0-9a-f]{128}$", req.Header.Get(authorizationHeader))
+		assert.Len(t, req.Header.Get(hmacContentHashHeader), 128)
+	})
+}
+
+func TestRequestSigningAuthenticatorsValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authenticator specAPIKeyAuthenticator
+		expectedError string
+	}{
+		{name: "HMAC credentials are populated", authenticator: newHMACAuthenticator(requestSigningCredentials{keyID: "keyID", secretKey: "secret"}, authorizationHeader, hmacSHA256Algorithm, "hmac_auth"), expectedError: ""},
+		{name: "HMAC secret key is NOT populated", authenticator: newHMACAuthenticator(requestSigningCredentials{keyID: "keyID"}, authorizationHeader, hmacSHA256Algorithm, "hmac_auth"), expectedError: "required security definition 'hmac_auth' is missing the signing credentials. Please make sure the properties 'hmac_auth_key_id' and 'hmac_auth_secret_key' are configured with a value in the provider's terraform configuration"},
+		{name: "AWS SigV4 credentials are populated", authenticator: newAWSSigV4Authenticator(requestSigningCredentials{keyID: "keyID", secretKey: "secret"}, "us-east-1", "execute-api", "sigv4_auth"), expectedError: ""},
+		{name: "AWS SigV4 key ID is NOT populated", authenticator: newAWSSigV4Authenticator(requestSigningCredentials{secretKey: "secret"}, "us-east-1", "execute-api", "sigv4_auth"), expectedError: "required security definition 'sigv4_auth' is missing the signing credentials. Please make sure the properties 'sigv4_auth_key_id' and 'sigv4_auth_secret_key' are configured with a value in the provider's terraform configuration"},
+	}
+	for _, tc := range testCases {
+		err := tc.authenticator.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
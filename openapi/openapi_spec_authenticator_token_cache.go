@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// accessTokenExpiryDelta is how long before the access token expiry a new token is requested, so API requests are never
+// made with a token that is about to expire
+const accessTokenExpiryDelta = 30 * time.Second
+
+type accessToken struct {
+	value string
+	// refreshAt is the time after which a new token must be requested, zero if the expiry of the token is unknown
+	refreshAt time.Time
+}
+
+// accessTokenCache holds the access tokens obtained by an authenticator. The cache is meant to be shared by all the copies
+// of the authenticator (hence the pointer) so the access tokens are requested once per provider instance. The lock is
+// held while a new token is requested so concurrent CRUD operations wait for the same token rather than requesting
+// one each.
+type accessTokenCache struct {
+	sync.Mutex
+	tokens map[string]accessToken
+	now    func() time.Time
+}
+
+func newAccessTokenCache() *accessTokenCache {
+	return &accessTokenCache{tokens: map[string]accessToken{}, now: time.Now}
+}
+
+// get returns the access token cached under the given key if it's still valid; otherwise the request function is called
+// to obtain a new one which is then cached. A nil cache does not cache anything.
+func (c *accessTokenCache) get(key string, request func(requestedAt time.Time) (*accessToken, error)) (string, error) {
+	if c == nil {
+		token, err := request(time.Now())
+		if err != nil {
+			return "", err
+		}
+		return token.value, nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	if token, exists := c.tokens[key]; exists && (token.refreshAt.IsZero() || c.now().Before(token.refreshAt)) {
+		return token.value, nil
+	}
+	token, err := request(c.now())
+	if err != nil {
+		return "", err
+	}
+	c.tokens[key] = *token
+	return token.value, nil
+}
+
+// invalidate removes the access token cached under the given key, as long as it is still the given value (a token
+// refreshed concurrently by another request is kept)
+func (c *accessTokenCache) invalidate(key, value string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if token, exists := c.tokens[key]; exists && token.value == value {
+		delete(c.tokens, key)
+	}
+}
+
+// newAccessTokenRefreshAt returns the time after which a token requested at requestedAt and expiring at expiresAt should be
+// refreshed. The token is refreshed accessTokenExpiryDelta before it expires, or half way through its lifetime for
+// short-lived tokens.
+func newAccessTokenRefreshAt(requestedAt, expiresAt time.Time) time.Time {
+	lifetime := expiresAt.Sub(requestedAt)
+	if lifetime <= 0 {
+		return requestedAt
+	}
+	expiryDelta := accessTokenExpiryDelta
+	if lifetime/2 < expiryDelta {
+		expiryDelta = lifetime / 2
+	}
+	return expiresAt.Add(-expiryDelta)
+}
+
+// getJWTExpiry returns the expiry time of the token from its 'exp' claim, as long as the token (optionally prefixed with
+// the Bearer scheme) is a JWT containing such claim. The signature of the token is not verified.
+func getJWTExpiry(token string) (time.Time, bool) {
+	token = strings.TrimSpace(token)
+	if len(token) > len(bearerScheme) && strings.EqualFold(token[:len(bearerScheme)+1], bearerScheme+" ") {
+		token = strings.TrimSpace(token[len(bearerScheme)+1:])
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	claims := struct {
+		Exp float64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Exp), 0), true
+}
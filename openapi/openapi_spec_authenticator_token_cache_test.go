@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJWT(claims string) string {
+	return fmt.Sprintf("eyJhbGciOiJIUzI1NiJ9.%s.c2lnbmF0dXJl", base64.RawURLEncoding.EncodeToString([]byte(claims)))
+}
+
+func TestGetJWTExpiry(t *testing.T) {
+	testCases := []struct {
+		name           string
+		token          string
+		expectedExpiry time.Time
+		expectedOK     bool
+	}{
+		{name: "JWT containing the exp claim", token: newTestJWT(`{"sub":"user","exp":1700000000}`), expectedExpiry: time.Unix(1700000000, 0), expectedOK: true},
+		{name: "JWT prefixed with the Bearer scheme", token: "Bearer " + newTestJWT(`{"exp":1700000000}`), expectedExpiry: time.Unix(1700000000, 0), expectedOK: true},
+		{name: "JWT prefixed with the Bearer scheme in lower case", token: "bearer " + newTestJWT(`{"exp":1700000000}`), expectedExpiry: time.Unix(1700000000, 0), expectedOK: true},
+		{name: "JWT missing the exp claim", token: newTestJWT(`{"sub":"user"}`), expectedOK: false},
+		{name: "JWT with a payload that is not JSON", token: newTestJWT(`not json`), expectedOK: false},
+		{name: "opaque token", token: "Bearer someOpaqueToken", expectedOK: false},
+		{name: "token with a payload that is not base64 encoded", token: "header.$$$.signature", expectedOK: false},
+	}
+	for _, tc := range testCases {
+		expiry, ok := getJWTExpiry(tc.token)
+		assert.Equal(t, tc.expectedOK, ok, tc.name)
+		assert.True(t, tc.expectedExpiry.Equal(expiry), tc.name)
+	}
+}
+
+func TestNewAccessTokenRefreshAt(t *testing.T) {
+	requestedAt := time.Now()
+	testCases := []struct {
+		name              string
+		expiresAt         time.Time
+		expectedRefreshAt time.Time
+	}{
+		{name: "long lived token is refreshed before it expires", expiresAt: requestedAt.Add(time.Hour), expectedRefreshAt: requestedAt.Add(time.Hour - accessTokenExpiryDelta)},
+		{name: "short lived token is refreshed half way through its lifetime", expiresAt: requestedAt.Add(20 * time.Second), expectedRefreshAt: requestedAt.Add(10 * time.Second)},
+		{name: "already expired token is refreshed right away", expiresAt: requestedAt.Add(-time.Second), expectedRefreshAt: requestedAt},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedRefreshAt, newAccessTokenRefreshAt(requestedAt, tc.expiresAt), tc.name)
+	}
+}
+
+func TestAccessTokenCache(t *testing.T) {
+	t.Run("happy path -- concurrent callers share the same access token", func(t *testing.T) {
+		cache := newAccessTokenCache()
+		requests := 0
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := cache.get("", func(requestedAt time.Time) (*accessToken, error) {
+					requests++
+					return &accessToken{value: "token"}, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, "token", token)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("happy path -- invalidate only discards the access token if it was not refreshed in the meantime", func(t *testing.T) {
+		cache := newAccessTokenCache()
+		requests := 0
+		request := func(requestedAt time.Time) (*accessToken, error) {
+			requests++
+			return &accessToken{value: fmt.Sprintf("token-%d", requests)}, nil
+		}
+		token, _ := cache.get("", request)
+		assert.Equal(t, "token-1", token)
+		cache.invalidate("", "token-1")
+		token, _ = cache.get("", request)
+		assert.Equal(t, "token-2", token)
+		cache.invalidate("", "token-1")
+		token, _ = cache.get("", request)
+		assert.Equal(t, "token-2", token)
+	})
+
+	t.Run("crappy path -- the access token is not cached when the request fails", func(t *testing.T) {
+		cache := newAccessTokenCache()
+		_, err := cache.get("", func(requestedAt time.Time) (*accessToken, error) {
+			return nil, fmt.Errorf("some error")
+		})
+		assert.EqualError(t, err, "some error")
+		assert.Empty(t, cache.tokens)
+	})
+}
@@ -6,6 +6,17 @@ type SpecBackendConfiguration interface {
 	getBasePath() string
 	getHTTPScheme() (string, error)
 	getHostByRegion(region string) (string, error)
+	// getRegionHost returns the host the API calls are made against for the given region, along with the region's base
+	// path and scheme if they override the global ones
+	getRegionHost(region string) (specRegionHost, error)
 	IsMultiRegion() (bool, string, []string, error)
 	GetDefaultRegion([]string) (string, error)
 }
+
+// specRegionHost defines the host the API calls are made against for a given region. The base path and scheme are
+// optional, the global ones are used if not specified.
+type specRegionHost struct {
+	Host     string `json:"host"`
+	BasePath string `json:"basePath,omitempty"`
+	Scheme   string `json:"scheme,omitempty"`
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// specCacheEntry defines the OpenAPI document cached for a given URL along with the information needed to revalidate it
+type specCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	// Document contains the document as returned by the server
+	Document []byte `json:"document"`
+	// ExpandedDocument contains the document with its references resolved, empty if the document could not be expanded yet
+	ExpandedDocument json.RawMessage `json:"expanded_document,omitempty"`
+	// ExpandedPatchChecksum contains the checksum of the swagger patch applied to the document before it was expanded
+	ExpandedPatchChecksum string `json:"expanded_patch_checksum,omitempty"`
+}
+
+// revalidationHeaders returns the conditional request headers used to revalidate the entry with the server, nil if the
+// entry is nil
+func (e *specCacheEntry) revalidationHeaders() map[string]string {
+	if e == nil {
+		return nil
+	}
+	headers := map[string]string{}
+	if e.ETag != "" {
+		headers["If-None-Match"] = e.ETag
+	}
+	if e.LastModified != "" {
+		headers["If-Modified-Since"] = e.LastModified
+	}
+	return headers
+}
+
+// specCache stores the OpenAPI documents on disk, one file per URL
+type specCache struct {
+	dir    string
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// newSpecCache returns the specCache for the given configuration, nil if the cache is not configured or not enabled
+func newSpecCache(swaggerCacheConfiguration *SwaggerCacheConfig) (*specCache, error) {
+	if swaggerCacheConfiguration == nil || !swaggerCacheConfiguration.Enabled {
+		return nil, nil
+	}
+	dir, err := swaggerCacheConfiguration.getDir()
+	if err != nil {
+		return nil, err
+	}
+	return &specCache{dir: dir, maxAge: time.Duration(swaggerCacheConfiguration.MaxAge) * time.Second, now: time.Now}, nil
+}
+
+// read returns the entry cached for the given URL, nil if there is none
+func (c *specCache) read(openAPIDocumentURL string) (*specCacheEntry, error) {
+	data, err := ioutil.ReadFile(c.path(openAPIDocumentURL))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &specCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	// the file names are hashes, so making sure the entry actually belongs to the URL
+	if entry.URL != openAPIDocumentURL {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// write stores the given entry. The entry is written to a temporary file first and then renamed so concurrent executions
+// of the plugin never read a partially written entry.
+func (c *specCache) write(entry *specCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), c.path(entry.URL))
+}
+
+// isFresh returns true if the given entry was fetched (or revalidated) within the configured max age
+func (c *specCache) isFresh(entry *specCacheEntry) bool {
+	return c.maxAge > 0 && c.now().Sub(entry.FetchedAt) < c.maxAge
+}
+
+func (c *specCache) path(openAPIDocumentURL string) string {
+	hash := sha256.Sum256([]byte(openAPIDocumentURL))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
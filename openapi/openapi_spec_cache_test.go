@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpecCache(t *testing.T) {
+	cache, err := newSpecCache(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cache)
+
+	cache, err = newSpecCache(&SwaggerCacheConfig{Enabled: false, Dir: "/tmp/openapi-cache"})
+	assert.NoError(t, err)
+	assert.Nil(t, cache)
+
+	cache, err = newSpecCache(&SwaggerCacheConfig{Enabled: true, MaxAge: 60, Dir: "/tmp/openapi-cache"})
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/openapi-cache", cache.dir)
+	assert.Equal(t, time.Minute, cache.maxAge)
+
+	terraformUtils, err := terraformutils.NewTerraformUtils()
+	require.NoError(t, err)
+	pluginsDir, err := terraformUtils.GetTerraformPluginsVendorDir()
+	require.NoError(t, err)
+	cache, err = newSpecCache(&SwaggerCacheConfig{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(pluginsDir, swaggerCacheDefaultDir), cache.dir)
+}
+
+func TestSpecCacheReadWrite(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "openapi-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+	cache := &specCache{dir: filepath.Join(cacheDir, "nested"), now: time.Now}
+
+	entry, err := cache.read("https://api.example.com/swagger.yaml")
+	assert.NoError(t, err)
+	assert.Nil(t, entry, "nothing cached yet")
+
+	expectedEntry := &specCacheEntry{
+		URL:              "https://api.example.com/swagger.yaml",
+		ETag:             `"v1"`,
+		LastModified:     "Wed, 21 Oct 2015 07:28:00 GMT",
+		FetchedAt:        time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		Document:         []byte("swagger: '2.0'"),
+		ExpandedDocument: []byte(`{"swagger":"2.0"}`),
+	}
+	require.NoError(t, cache.write(expectedEntry))
+	entry, err = cache.read("https://api.example.com/swagger.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntry, entry)
+
+	entry, err = cache.read("https://api.example.com/other.yaml")
+	assert.NoError(t, err)
+	assert.Nil(t, entry, "entries are keyed by URL")
+
+	require.NoError(t, ioutil.WriteFile(cache.path("https://api.example.com/corrupted.yaml"), []byte("{"), 0600))
+	_, err = cache.read("https://api.example.com/corrupted.yaml")
+	assert.Error(t, err)
+}
+
+func TestSpecCacheIsFresh(t *testing.T) {
+	now := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	entry := &specCacheEntry{FetchedAt: now.Add(-time.Minute)}
+	assert.True(t, (&specCache{maxAge: time.Hour, now: func() time.Time { return now }}).isFresh(entry))
+	assert.False(t, (&specCache{maxAge: time.Second, now: func() time.Time { return now }}).isFresh(entry))
+	assert.False(t, (&specCache{now: func() time.Time { return now }}).isFresh(entry), "without max age the entries are always revalidated")
+}
+
+func TestSpecCacheEntryRevalidationHeaders(t *testing.T) {
+	var entry *specCacheEntry
+	assert.Nil(t, entry.revalidationHeaders())
+	entry = &specCacheEntry{ETag: `"v1"`, LastModified: "Wed, 21 Oct 2015 07:28:00 GMT"}
+	assert.Equal(t, map[string]string{"If-None-Match": `"v1"`, "If-Modified-Since": "Wed, 21 Oct 2015 07:28:00 GMT"}, entry.revalidationHeaders())
+	assert.Empty(t, (&specCacheEntry{}).revalidationHeaders())
+}
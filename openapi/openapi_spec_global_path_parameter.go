@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+// SpecGlobalPathParameters groups a list of SpecGlobalPathParameter
+type SpecGlobalPathParameters []SpecGlobalPathParameter
+
+// SpecGlobalPathParameter defines a path parameter declared once in the OpenAPI document (the same idea as OpenAPI 3 server
+// variables) that is exposed as a provider property. The value configured in the provider is substituted wherever the
+// parameter shows up in the resources URLs (host, base path and resource paths), e,g: /v1/accounts/{account_id}/cdns
+type SpecGlobalPathParameter struct {
+	Name string
+	// ProviderPropertyAttributes contains the default value, description and validation of the provider property created
+	// for the path parameter
+	ProviderPropertyAttributes
+}
+
+// GetTerraformConfigurationName returns the terraform compliant name of the path parameter, which is the name of the
+// provider property as well as the name of the resource property that overrides the provider value
+func (p SpecGlobalPathParameter) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(p.Name)
+}
+
+// IsRequired returns true if the path parameter has no default value, in which case the provider property is required
+func (p SpecGlobalPathParameter) IsRequired() bool {
+	return p.Default == ""
+}
+
+// getNames returns the names of the path parameters
+func (s SpecGlobalPathParameters) getNames() []string {
+	var names []string
+	for _, globalPathParameter := range s {
+		names = append(names, globalPathParameter.Name)
+	}
+	return names
+}
+
+func (s SpecGlobalPathParameters) find(name string) *SpecGlobalPathParameter {
+	for _, globalPathParameter := range s {
+		if globalPathParameter.Name == name {
+			return &globalPathParameter
+		}
+	}
+	return nil
+}
+
+// resolveGlobalPathParameters replaces the given global path parameters in the given URL (or path) with their values. An
+// error is returned if any of the path parameters in the URL is missing the value.
+func resolveGlobalPathParameters(url string, values map[string]string) (string, error) {
+	names := []string{}
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := values[name]
+		pathParameter := fmt.Sprintf("{%s}", name)
+		if !strings.Contains(url, pathParameter) {
+			continue
+		}
+		if value == "" {
+			return "", fmt.Errorf("global path parameter '%s' is missing the value. Please make sure the property '%s' is configured with a value in the provider's terraform configuration", name, terraformutils.ConvertToTerraformCompliantName(name))
+		}
+		if strings.Contains(value, "/") {
+			return "", fmt.Errorf("global path parameter '%s' value (%s) contains not supported characters (forward slashes)", name, value)
+		}
+		url = strings.Replace(url, pathParameter, value, -1)
+	}
+	return url, nil
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecGlobalPathParameter(t *testing.T) {
+	globalPathParameter := SpecGlobalPathParameter{Name: "accountId"}
+	assert.Equal(t, "account_id", globalPathParameter.GetTerraformConfigurationName())
+	assert.True(t, globalPathParameter.IsRequired())
+
+	globalPathParameter.Default = "1234"
+	assert.False(t, globalPathParameter.IsRequired())
+}
+
+func TestSpecGlobalPathParametersFind(t *testing.T) {
+	globalPathParameters := SpecGlobalPathParameters{{Name: "account_id"}, {Name: "project_id"}}
+	assert.Equal(t, []string{"account_id", "project_id"}, globalPathParameters.getNames())
+	assert.Equal(t, &SpecGlobalPathParameter{Name: "project_id"}, globalPathParameters.find("project_id"))
+	assert.Nil(t, globalPathParameters.find("region"))
+}
+
+func TestResolveGlobalPathParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		values        map[string]string
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			name:        "path parameters in the path",
+			url:         "https://api.com/v1/accounts/{account_id}/projects/{project_id}/cdns",
+			values:      map[string]string{"account_id": "1234", "project_id": "web"},
+			expectedURL: "https://api.com/v1/accounts/1234/projects/web/cdns",
+		},
+		{
+			name:        "path parameter in the host",
+			url:         "https://{account_id}.api.com/v1/cdns",
+			values:      map[string]string{"account_id": "1234"},
+			expectedURL: "https://1234.api.com/v1/cdns",
+		},
+		{
+			name:        "path parameters not in the url",
+			url:         "https://api.com/v1/cdns",
+			values:      map[string]string{"account_id": ""},
+			expectedURL: "https://api.com/v1/cdns",
+		},
+		{
+			name:          "path parameter missing the value",
+			url:           "https://api.com/v1/accounts/{accountId}/cdns",
+			values:        map[string]string{"accountId": ""},
+			expectedError: "global path parameter 'accountId' is missing the value. Please make sure the property 'account_id' is configured with a value in the provider's terraform configuration",
+		},
+		{
+			name:          "path parameter value with forward slashes",
+			url:           "https://api.com/v1/accounts/{account_id}/cdns",
+			values:        map[string]string{"account_id": "12/34"},
+			expectedError: "global path parameter 'account_id' value (12/34) contains not supported characters (forward slashes)",
+		},
+	}
+	for _, tc := range testCases {
+		url, err := resolveGlobalPathParameters(tc.url, tc.values)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedURL, url, tc.name)
+	}
+}
@@ -10,6 +10,9 @@ type SpecHeaderParam struct {
 	Name          string
 	TerraformName string
 	IsRequired    bool
+	// ProviderPropertyAttributes contains the type, sensitivity, description and validation of the provider property
+	// created for the header
+	ProviderPropertyAttributes
 }
 
 // GetHeaderTerraformConfigurationName returns the terraform compliant name of the header. If the header TerraformName
@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// minisignAlgorithm is the minisign signature algorithm signing the document itself (legacy)
+	minisignAlgorithm = "Ed"
+	// minisignPrehashedAlgorithm is the minisign signature algorithm signing the BLAKE2b-512 hash of the document
+	minisignPrehashedAlgorithm = "ED"
+	minisignKeyIDLength        = 8
+	minisignTrustedComment     = "trusted comment: "
+)
+
+// signaturePublicKey defines an ed25519 public key, the key ID is only set for minisign public keys
+type signaturePublicKey struct {
+	keyID []byte
+	key   ed25519.PublicKey
+}
+
+// findExternalReference returns the first reference of the given document pointing at another document, empty if the
+// document is self-contained (e,g: it only references its own definitions)
+func findExternalReference(document []byte) (string, error) {
+	jsonDocument, err := yamlToJSON(document)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err := json.Unmarshal(jsonDocument, &value); err != nil {
+		return "", err
+	}
+	return findExternalReferenceValue(value), nil
+}
+
+func findExternalReferenceValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && !strings.HasPrefix(ref, "#") {
+			return ref
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if ref := findExternalReferenceValue(v[key]); ref != "" {
+				return ref
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if ref := findExternalReferenceValue(item); ref != "" {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+// verifyOpenAPIDocumentChecksum returns an error if the document does not match the given hex encoded SHA-256 checksum
+func verifyOpenAPIDocumentChecksum(document []byte, expectedChecksum string) error {
+	checksum := sha256.Sum256(document)
+	if actualChecksum := hex.EncodeToString(checksum[:]); !strings.EqualFold(actualChecksum, expectedChecksum) {
+		return fmt.Errorf("the SHA-256 checksum of the document (%s) does not match the configured one (%s)", actualChecksum, expectedChecksum)
+	}
+	return nil
+}
+
+// verifyOpenAPIDocumentSignature returns an error if the given detached signature of the document is not valid for the
+// given public key. The signature can be either the raw ed25519 signature (base64 encoded or not) or a minisign signature.
+func verifyOpenAPIDocumentSignature(document, signature []byte, publicKey string) error {
+	key, err := parseSignaturePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	// the raw signatures are binary, hence only the encoded ones are trimmed
+	if len(signature) != ed25519.SignatureSize {
+		signature = bytes.TrimSpace(signature)
+		if bytes.HasPrefix(signature, []byte("untrusted comment:")) {
+			return verifyMinisignSignature(document, string(signature), key)
+		}
+		if signature, err = base64.StdEncoding.DecodeString(string(signature)); err != nil {
+			return errors.New("the signature is neither a base64 encoded ed25519 signature nor a minisign signature")
+		}
+	}
+	if !ed25519.Verify(key.key, document, signature) {
+		return errors.New("the signature of the document is not valid")
+	}
+	return nil
+}
+
+// verifyMinisignSignature verifies the minisign signature (https://jedisct1.github.io/minisign/#signature-format) of the
+// document as well as the global signature of its trusted comment
+func verifyMinisignSignature(document []byte, signature string, key *signaturePublicKey) error {
+	lines := strings.Split(strings.ReplaceAll(signature, "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], minisignTrustedComment) {
+		return errors.New("the minisign signature is not properly formatted")
+	}
+	decodedSignature, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(decodedSignature) != 2+minisignKeyIDLength+ed25519.SignatureSize {
+		return errors.New("the minisign signature is not properly formatted")
+	}
+	algorithm, keyID, documentSignature := string(decodedSignature[:2]), decodedSignature[2:2+minisignKeyIDLength], decodedSignature[2+minisignKeyIDLength:]
+	if key.keyID != nil && !bytes.Equal(key.keyID, keyID) {
+		return fmt.Errorf("the minisign signature was created with a different key (%X) than the configured one (%X)", reverse(keyID), reverse(key.keyID))
+	}
+	message := document
+	switch algorithm {
+	case minisignAlgorithm:
+	case minisignPrehashedAlgorithm:
+		hash := blake2b.Sum512(document)
+		message = hash[:]
+	default:
+		return fmt.Errorf("the minisign signature algorithm '%s' is not supported", algorithm)
+	}
+	if !ed25519.Verify(key.key, message, documentSignature) {
+		return errors.New("the signature of the document is not valid")
+	}
+	globalSignature, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		return errors.New("the minisign signature is not properly formatted")
+	}
+	trustedComment := strings.TrimPrefix(lines[2], minisignTrustedComment)
+	signedTrustedComment := append(append([]byte{}, documentSignature...), trustedComment...)
+	if !ed25519.Verify(key.key, signedTrustedComment, globalSignature) {
+		return errors.New("the trusted comment of the minisign signature is not valid")
+	}
+	return nil
+}
+
+// parseSignaturePublicKey parses the given base64 encoded public key, which can be either a raw ed25519 public key or a
+// minisign public key (https://jedisct1.github.io/minisign/#public-key-format)
+func parseSignaturePublicKey(publicKey string) (*signaturePublicKey, error) {
+	decodedKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(decodedKey) == ed25519.PublicKeySize:
+		return &signaturePublicKey{key: decodedKey}, nil
+	case len(decodedKey) == 2+minisignKeyIDLength+ed25519.PublicKeySize && string(decodedKey[:2]) == minisignAlgorithm:
+		return &signaturePublicKey{keyID: decodedKey[2 : 2+minisignKeyIDLength], key: decodedKey[2+minisignKeyIDLength:]}, nil
+	}
+	return nil, errors.New("the public key is neither an ed25519 public key nor a minisign public key")
+}
+
+// reverse returns a reversed copy of the given bytes, used to display the minisign key IDs as minisign does (little endian)
+func reverse(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// newTestMinisignKey returns an ed25519 private key along with its minisign formatted public key
+func newTestMinisignKey(t *testing.T, keyID []byte) (ed25519.PrivateKey, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	minisignPublicKey := append(append([]byte(minisignAlgorithm), keyID...), publicKey...)
+	return privateKey, base64.StdEncoding.EncodeToString(minisignPublicKey)
+}
+
+// newTestMinisignSignature returns the minisign signature of the document created with the given algorithm and key
+func newTestMinisignSignature(privateKey ed25519.PrivateKey, keyID []byte, algorithm string, document []byte, trustedComment string) string {
+	message := document
+	if algorithm == minisignPrehashedAlgorithm {
+		hash := blake2b.Sum512(document)
+		message = hash[:]
+	}
+	signature := ed25519.Sign(privateKey, message)
+	globalSignature := ed25519.Sign(privateKey, append(append([]byte{}, signature...), trustedComment...))
+	return fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(algorithm), keyID...), signature...)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSignature))
+}
+
+func TestFindExternalReference(t *testing.T) {
+	testCases := []struct {
+		name              string
+		document          string
+		expectedReference string
+		expectedError     bool
+	}{
+		{name: "self-contained document", document: specLoaderTestSelfContainedDocument},
+		{name: "document referencing other documents", document: specLoaderTestRootDocument, expectedReference: "%s/definitions.yaml#/definitions/ContentDeliveryNetwork"},
+		{name: "document referencing other documents in a list", document: `{"allOf": [{"$ref": "#/definitions/Base"}, {"$ref": "other.json"}]}`, expectedReference: "other.json"},
+		{name: "document not valid", document: "{", expectedError: true},
+	}
+	for _, tc := range testCases {
+		reference, err := findExternalReference([]byte(tc.document))
+		if tc.expectedError {
+			assert.Error(t, err, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedReference, reference, tc.name)
+	}
+}
+
+func TestVerifyOpenAPIDocumentChecksum(t *testing.T) {
+	document := []byte(`swagger: "2.0"`)
+	checksum := sha256.Sum256(document)
+	expectedChecksum := hex.EncodeToString(checksum[:])
+	assert.NoError(t, verifyOpenAPIDocumentChecksum(document, expectedChecksum))
+	assert.NoError(t, verifyOpenAPIDocumentChecksum(document, fmt.Sprintf("%X", checksum[:])), "the checksum is case insensitive")
+
+	tamperedDocument := []byte(`swagger: "2.0" `)
+	tamperedChecksum := sha256.Sum256(tamperedDocument)
+	err := verifyOpenAPIDocumentChecksum(tamperedDocument, expectedChecksum)
+	assert.EqualError(t, err, fmt.Sprintf("the SHA-256 checksum of the document (%x) does not match the configured one (%s)", tamperedChecksum, expectedChecksum))
+}
+
+func TestVerifyOpenAPIDocumentSignature(t *testing.T) {
+	document := []byte(`swagger: "2.0"`)
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rawPublicKey := base64.StdEncoding.EncodeToString(publicKey)
+	signature := ed25519.Sign(privateKey, document)
+
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	minisignPrivateKey, minisignPublicKey := newTestMinisignKey(t, keyID)
+	_, otherMinisignPublicKey := newTestMinisignKey(t, []byte{8, 7, 6, 5, 4, 3, 2, 1})
+	minisignSignature := newTestMinisignSignature(minisignPrivateKey, keyID, minisignAlgorithm, document, "timestamp:1600000000")
+	tamperedMinisignSignature := strings.Replace(minisignSignature, "timestamp:1600000000", "timestamp:1700000000", 1)
+
+	testCases := []struct {
+		name          string
+		signature     string
+		publicKey     string
+		expectedError string
+	}{
+		{name: "raw signature", signature: string(signature), publicKey: rawPublicKey},
+		{name: "base64 encoded signature", signature: base64.StdEncoding.EncodeToString(signature) + "\n", publicKey: rawPublicKey},
+		{name: "minisign signature", signature: minisignSignature, publicKey: minisignPublicKey},
+		{name: "minisign prehashed signature", signature: newTestMinisignSignature(minisignPrivateKey, keyID, minisignPrehashedAlgorithm, document, "timestamp:1600000000"), publicKey: minisignPublicKey},
+		{name: "minisign signature verified with the raw public key", signature: newTestMinisignSignature(privateKey, keyID, minisignPrehashedAlgorithm, document, "timestamp:1600000000"), publicKey: rawPublicKey},
+		{name: "signature of another document", signature: base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte("other"))), publicKey: rawPublicKey, expectedError: "the signature of the document is not valid"},
+		{name: "signature not encoded", signature: "not a signature", publicKey: rawPublicKey, expectedError: "the signature is neither a base64 encoded ed25519 signature nor a minisign signature"},
+		{name: "minisign signature created with another key", signature: minisignSignature, publicKey: otherMinisignPublicKey, expectedError: "the minisign signature was created with a different key (0807060504030201) than the configured one (0102030405060708)"},
+		{name: "minisign signature of another document", signature: newTestMinisignSignature(minisignPrivateKey, keyID, minisignPrehashedAlgorithm, []byte("other"), "timestamp:1600000000"), publicKey: minisignPublicKey, expectedError: "the signature of the document is not valid"},
+		{name: "minisign signature with a tampered trusted comment", signature: tamperedMinisignSignature, publicKey: minisignPublicKey, expectedError: "the trusted comment of the minisign signature is not valid"},
+		{name: "minisign signature not properly formatted", signature: "untrusted comment: signature\nnot base64\n", publicKey: minisignPublicKey, expectedError: "the minisign signature is not properly formatted"},
+		{name: "public key not valid", signature: string(signature), publicKey: "bm90IGEga2V5", expectedError: "the public key is neither an ed25519 public key nor a minisign public key"},
+	}
+	for _, tc := range testCases {
+		err := verifyOpenAPIDocumentSignature(document, []byte(tc.signature), tc.publicKey)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SpecLintReport describes how the OpenAPI document is interpreted by the provider: which paths become resources, data
+// sources and data source instances (or why they are rejected), which extensions are applied and which extensions are
+// not recognised
+type SpecLintReport struct {
+	// Paths contains the lint result of each path in the OpenAPI document, sorted by path
+	Paths []SpecPathLintResult `json:"paths"`
+	// Warnings contains the unknown or possibly misspelled extensions found in the OpenAPI document
+	Warnings []SpecLintWarning `json:"warnings"`
+}
+
+// SpecPathLintResult describes how a path of the OpenAPI document is interpreted by the provider
+type SpecPathLintResult struct {
+	// Path is the path as documented in the OpenAPI document (e,g: /v1/cdns/{id})
+	Path string `json:"path"`
+	// Document is the OpenAPI document the path belongs to, only set when several documents are merged
+	Document string `json:"document,omitempty"`
+	// Resource is the name of the resource the path (as instance path) is exposed as
+	Resource string `json:"resource,omitempty"`
+	// ResourceRootPathOf is the name of the resource the path is the root path (e,g: /v1/cdns) of
+	ResourceRootPathOf string `json:"resource_root_path_of,omitempty"`
+	// DataSource is the name of the data source the path is exposed as
+	DataSource string `json:"data_source,omitempty"`
+	// DataSourceInstance is the name of the data source instance the path is exposed as
+	DataSourceInstance string `json:"data_source_instance,omitempty"`
+	// ResourceRejection is the reason why the path is not exposed as a resource
+	ResourceRejection string `json:"resource_rejection,omitempty"`
+	// DataSourceRejection is the reason why the path is not exposed as a data source
+	DataSourceRejection string `json:"data_source_rejection,omitempty"`
+	// Extensions contains the extensions applied to the resources or data sources the path is exposed as
+	Extensions []SpecExtensionUsage `json:"extensions,omitempty"`
+}
+
+// SpecExtensionUsage describes an extension found in the OpenAPI document
+type SpecExtensionUsage struct {
+	// Location is where the extension is defined within the path (e,g: post.responses.202, get.responses.200.schema.properties.id)
+	Location string `json:"location"`
+	// Name is the extension name (e,g: x-terraform-id)
+	Name string `json:"name"`
+	// Value is the extension value
+	Value interface{} `json:"value"`
+}
+
+// SpecLintWarning describes an issue found in the OpenAPI document that does not prevent the provider from being created
+type SpecLintWarning struct {
+	// Location is where the issue was found within the OpenAPI document (e,g: definitions.ContentDeliveryNetworkV1.properties.id)
+	Location string `json:"location"`
+	// Document is the OpenAPI document the warning belongs to, only set when several documents are merged
+	Document string `json:"document,omitempty"`
+	// Extension is the extension the warning refers to
+	Extension string `json:"extension"`
+	// Message describes the issue
+	Message string `json:"message"`
+}
+
+// IsClean returns true if the report does not contain any warning
+func (r SpecLintReport) IsClean() bool {
+	return len(r.Warnings) == 0
+}
+
+// specLinter defines the interface the SpecAnalyser implementations supporting linting must implement
+type specLinter interface {
+	lint() *SpecLintReport
+}
+
+// knownExtensions contains all the extensions supported by the provider
+var knownExtensions = []string{
+	extTfProviderMultiRegionFQDN,
+	extTfProviderRegions,
+	extTfProviderRegionHosts,
+	extTfProviderRegionsEndpoint,
+	extTfHeader,
+	extTfImmutable,
+	extTfForceNew,
+	extTfSensitive,
+	extTfFieldName,
+	extTfFieldStatus,
+	extTfID,
+	extTfComputed,
+	extTfIgnoreOrder,
+	extIgnoreOrder,
+	extTfLabels,
+	extTfGlobalPathParameters,
+	extTfResourceTimeout,
+	extTfResourcePollEnabled,
+	extTfResourcePollTargetStatuses,
+	extTfResourcePollPendingStatuses,
+	extTfExcludeResource,
+	extTfResourceName,
+	extTfResourceURL,
+	extTfAuthenticationSchemeBearer,
+	extTfAuthenticationRefreshToken,
+	extTfRequestSigning,
+	extTfRequestSigningAlgorithm,
+	extTfRequestSigningAWSRegion,
+	extTfRequestSigningAWSService,
+}
+
+// maxExtensionNameDistance is the maximum edit distance between an unknown extension and a known one for the unknown
+// extension to be considered a misspelling of the known one
+const maxExtensionNameDistance = 3
+
+// LintOpenAPIDocument loads the given OpenAPI document and returns the SpecLintReport explaining how the provider
+// interprets it. If providerName is provided, the resource and data source names in the report are prefixed with it
+// (e,g: openapi_cdns_v1) as they would be exposed by the provider
+func LintOpenAPIDocument(providerName, openAPIDocumentURL string) (*SpecLintReport, error) {
+	specAnalyser, err := CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("lint OpenAPI spec analyser error: %s", err)
+	}
+	return lintSpecAnalyser(providerName, specAnalyser, nil, nil)
+}
+
+// Lint returns the SpecLintReport for the OpenAPI document configured for the provider. The resources and data sources
+// are reported with the names they are exposed with according to the resources and data sources configuration of the
+// service (if any), the ones not exposed are reported as rejected.
+func (p *ProviderOpenAPI) Lint() (*SpecLintReport, error) {
+	serviceConfiguration, err := getServiceConfiguration(p.ProviderName, p.EmbeddedFS)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	specAnalyser, err := createServiceSpecAnalyser(serviceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("lint %s", err)
+	}
+	return lintSpecAnalyser(p.ProviderName, specAnalyser, serviceConfiguration.GetResourcesConfiguration(), serviceConfiguration.GetDataSourcesConfiguration())
+}
+
+func lintSpecAnalyser(providerName string, specAnalyser SpecAnalyser, resourcesConfiguration *ResourcesConfig, dataSourcesConfiguration *DataSourcesConfig) (*SpecLintReport, error) {
+	linter, ok := specAnalyser.(specLinter)
+	if !ok {
+		return nil, fmt.Errorf("spec analyser %T does not support linting", specAnalyser)
+	}
+	report := linter.lint()
+	configureSpecLintReport(report, resourcesConfiguration, dataSourcesConfiguration)
+	if providerName != "" {
+		for i := range report.Paths {
+			pathResult := &report.Paths[i]
+			for _, name := range []*string{&pathResult.Resource, &pathResult.ResourceRootPathOf, &pathResult.DataSource, &pathResult.DataSourceInstance} {
+				if *name != "" {
+					*name = fmt.Sprintf("%s_%s", providerName, *name)
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// configureSpecLintReport updates the resource and data source names of the given report following the same logic as
+// configureResources and configureDataSources: the names are replaced with the ones they are exposed with, and the
+// resources and data sources that are not exposed are reported as rejected
+func configureSpecLintReport(report *SpecLintReport, resourcesConfiguration *ResourcesConfig, dataSourcesConfiguration *DataSourcesConfig) {
+	for i := range report.Paths {
+		pathResult := &report.Paths[i]
+		if resourcesConfiguration != nil {
+			if pathResult.Resource != "" {
+				name, exposed := getConfiguredResourceName(pathResult.Resource, &resourcesConfiguration.DataSourcesConfig)
+				if !exposed {
+					pathResult.ResourceRejection = fmt.Sprintf("resource '%s' is not exposed according to the plugin configuration", pathResult.Resource)
+					pathResult.Resource, pathResult.DataSourceInstance = "", ""
+				} else {
+					pathResult.Resource = name
+					pathResult.DataSourceInstance = newDataSourceInstanceFactory(configuredSpecResource{name: name}).getDataSourceInstanceName()
+				}
+			}
+			if pathResult.ResourceRootPathOf != "" {
+				name, exposed := getConfiguredResourceName(pathResult.ResourceRootPathOf, &resourcesConfiguration.DataSourcesConfig)
+				if !exposed {
+					name = ""
+				}
+				pathResult.ResourceRootPathOf = name
+			}
+		}
+		if dataSourcesConfiguration != nil && pathResult.DataSource != "" {
+			name, exposed := getConfiguredResourceName(pathResult.DataSource, dataSourcesConfiguration)
+			if !exposed {
+				pathResult.DataSourceRejection = fmt.Sprintf("data source '%s' is not exposed according to the plugin configuration", pathResult.DataSource)
+				name = ""
+			}
+			pathResult.DataSource = name
+		}
+	}
+}
+
+// checkExtension returns a SpecLintWarning if the given extension is not known by the provider: either it has the
+// 'x-terraform-' prefix but it is not supported, or it looks like a misspelling of a supported extension. Nil is returned
+// otherwise.
+func checkExtension(location, extension string) *SpecLintWarning {
+	name := strings.ToLower(extension)
+	if isKnownExtension(name) {
+		return nil
+	}
+	suggestion, distance := "", maxExtensionNameDistance+1
+	for _, knownExtension := range knownExtensions {
+		if d := levenshteinDistance(name, knownExtension); d < distance {
+			suggestion, distance = knownExtension, d
+		}
+	}
+	if distance <= maxExtensionNameDistance {
+		return &SpecLintWarning{Location: location, Extension: extension, Message: fmt.Sprintf("unknown extension, did you mean '%s'?", suggestion)}
+	}
+	if strings.HasPrefix(name, "x-terraform-") {
+		return &SpecLintWarning{Location: location, Extension: extension, Message: "unknown extension"}
+	}
+	return nil
+}
+
+func isKnownExtension(extension string) bool {
+	for _, knownExtension := range knownExtensions {
+		if strings.ToLower(extension) == knownExtension {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// FormatSpecLintReport returns a human readable representation of the given report
+func FormatSpecLintReport(report *SpecLintReport) string {
+	builder := &strings.Builder{}
+	for _, pathResult := range report.Paths {
+		if pathResult.Document != "" {
+			fmt.Fprintf(builder, "%s (%s)\n", pathResult.Path, pathResult.Document)
+		} else {
+			fmt.Fprintf(builder, "%s\n", pathResult.Path)
+		}
+		if pathResult.Resource != "" {
+			fmt.Fprintf(builder, "  resource: %s\n", pathResult.Resource)
+		}
+		if pathResult.ResourceRootPathOf != "" {
+			fmt.Fprintf(builder, "  resource root path of: %s\n", pathResult.ResourceRootPathOf)
+		}
+		if pathResult.DataSourceInstance != "" {
+			fmt.Fprintf(builder, "  data source instance: %s\n", pathResult.DataSourceInstance)
+		}
+		if pathResult.DataSource != "" {
+			fmt.Fprintf(builder, "  data source: %s\n", pathResult.DataSource)
+		}
+		if pathResult.ResourceRejection != "" {
+			fmt.Fprintf(builder, "  not a resource: %s\n", pathResult.ResourceRejection)
+		}
+		if pathResult.DataSourceRejection != "" {
+			fmt.Fprintf(builder, "  not a data source: %s\n", pathResult.DataSourceRejection)
+		}
+		for _, extension := range pathResult.Extensions {
+			fmt.Fprintf(builder, "  extension %s: %s = %v\n", extension.Location, extension.Name, extension.Value)
+		}
+	}
+	if len(report.Warnings) > 0 {
+		builder.WriteString("warnings:\n")
+		for _, warning := range report.Warnings {
+			if warning.Document != "" {
+				fmt.Fprintf(builder, "  %s: %s: %s: %s\n", warning.Document, warning.Location, warning.Extension, warning.Message)
+				continue
+			}
+			fmt.Fprintf(builder, "  %s: %s: %s\n", warning.Location, warning.Extension, warning.Message)
+		}
+	}
+	return builder.String()
+}
+
+func sortSpecExtensionUsages(extensions []SpecExtensionUsage) {
+	sort.SliceStable(extensions, func(i, j int) bool {
+		if extensions[i].Location != extensions[j].Location {
+			return extensions[i].Location < extensions[j].Location
+		}
+		return extensions[i].Name < extensions[j].Name
+	})
+}
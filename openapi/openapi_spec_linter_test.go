@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const specLinterTestSwagger = `swagger: "2.0"
+host: "api.example.com"
+x-terraform-provider-regions: "rst1"
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkV1"
+    post:
+      x-terraform-resource-timeout: "30s"
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/lbs:
+    post:
+      x-terraform-exclude-resource: true
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/LBV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/LBV1"
+  /v1/lbs/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/LBV1"
+  /v1/monitors/{id}:
+    get:
+      x-terraform-resource-nmae: "monitor"
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/LBV1"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+        x-terraform-force-new: true
+      secret:
+        type: "string"
+        x-terrafrom-sensitive: true
+  LBV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      name:
+        type: "string"`
+
+func TestLintOpenAPIDocument(t *testing.T) {
+	Convey("Given an OpenAPI document containing a resource, a data source, an excluded resource, a non compliant path and misspelled extensions", t, func() {
+		file, err := ioutil.TempFile("", "spec_linter*.yaml")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		_, err = file.Write([]byte(specLinterTestSwagger))
+		So(err, ShouldBeNil)
+		Convey("When LintOpenAPIDocument is called", func() {
+			report, err := LintOpenAPIDocument("openapi", file.Name())
+			So(err, ShouldBeNil)
+			Convey("Then the report should explain how each path is interpreted", func() {
+				So(len(report.Paths), ShouldEqual, 5)
+				So(report.Paths[0], ShouldResemble, SpecPathLintResult{
+					Path:               "/v1/cdns",
+					ResourceRootPathOf: "openapi_cdns_v1",
+					DataSource:         "openapi_cdns_v1",
+					Extensions: []SpecExtensionUsage{
+						{Location: "get.responses.200.schema.items.properties.label", Name: "x-terraform-force-new", Value: true},
+						{Location: "post", Name: "x-terraform-resource-timeout", Value: "30s"},
+						{Location: "post.parameters.body.schema.properties.label", Name: "x-terraform-force-new", Value: true},
+						{Location: "post.responses.201.schema.properties.label", Name: "x-terraform-force-new", Value: true},
+					},
+				})
+				So(report.Paths[1].Path, ShouldEqual, "/v1/cdns/{id}")
+				So(report.Paths[1].Resource, ShouldEqual, "openapi_cdns_v1")
+				So(report.Paths[1].DataSourceInstance, ShouldEqual, "openapi_cdns_v1_instance")
+				So(report.Paths[1].DataSourceRejection, ShouldEqual, "response does not return an array of items")
+				So(report.Paths[1].Extensions, ShouldResemble, []SpecExtensionUsage{{Location: "get.responses.200.schema.properties.label", Name: "x-terraform-force-new", Value: true}})
+				So(report.Paths[3].Path, ShouldEqual, "/v1/lbs/{id}")
+				So(report.Paths[3].Resource, ShouldEqual, "")
+				So(report.Paths[3].ResourceRejection, ShouldEqual, "resource 'lbs_v1' is excluded with the 'x-terraform-exclude-resource' extension")
+				So(report.Paths[4].Path, ShouldEqual, "/v1/monitors/{id}")
+				So(report.Paths[4].ResourceRejection, ShouldEqual, "resource instance path '/v1/monitors/{id}' missing resource root path")
+			})
+			Convey("And the report should contain warnings for the misspelled extensions", func() {
+				So(report.IsClean(), ShouldBeFalse)
+				So(report.Warnings, ShouldResemble, []SpecLintWarning{
+					{Location: "definitions.ContentDeliveryNetworkV1.properties.secret", Extension: "x-terrafrom-sensitive", Message: "unknown extension, did you mean 'x-terraform-sensitive'?"},
+					{Location: "paths./v1/monitors/{id}.get", Extension: "x-terraform-resource-nmae", Message: "unknown extension, did you mean 'x-terraform-resource-name'?"},
+				})
+			})
+		})
+	})
+}
+
+func TestProviderOpenAPILint(t *testing.T) {
+	Convey("Given a provider configured with an OpenAPI document and a resources and data sources configuration", t, func() {
+		embeddedFS := fstest.MapFS{
+			OpenAPIPluginConfigurationFileName: {Data: []byte(`version: '1'
+services:
+  openapi:
+    swagger-url: specs/swagger.yaml
+    resources:
+      renames:
+        cdns_v1: cdn
+    data_sources:
+      exclude:
+      - cdns_v1`)},
+			"specs/swagger.yaml": {Data: []byte(specLinterTestSwagger)},
+		}
+		Convey("When Lint is called", func() {
+			p := ProviderOpenAPI{ProviderName: "openapi", EmbeddedFS: embeddedFS}
+			report, err := p.Lint()
+			So(err, ShouldBeNil)
+			Convey("Then the resources should be reported with the names they are exposed with", func() {
+				So(report.Paths[0].ResourceRootPathOf, ShouldEqual, "openapi_cdn")
+				So(report.Paths[1].Resource, ShouldEqual, "openapi_cdn")
+				So(report.Paths[1].DataSourceInstance, ShouldEqual, "openapi_cdn_instance")
+			})
+			Convey("And the data sources not exposed should be reported as rejected", func() {
+				So(report.Paths[0].DataSource, ShouldEqual, "")
+				So(report.Paths[0].DataSourceRejection, ShouldEqual, "data source 'cdns_v1' is not exposed according to the plugin configuration")
+			})
+		})
+	})
+}
+
+func TestCheckExtension(t *testing.T) {
+	testCases := []struct {
+		name            string
+		extension       string
+		expectedWarning *SpecLintWarning
+	}{
+		{name: "known extension", extension: "x-terraform-id", expectedWarning: nil},
+		{name: "known extension with different case", extension: "X-Terraform-Id", expectedWarning: nil},
+		{name: "misspelled extension", extension: "x-terraform-sensitve", expectedWarning: &SpecLintWarning{Location: "loc", Extension: "x-terraform-sensitve", Message: "unknown extension, did you mean 'x-terraform-sensitive'?"}},
+		{name: "misspelled extension prefix", extension: "x-terrafrom-id", expectedWarning: &SpecLintWarning{Location: "loc", Extension: "x-terrafrom-id", Message: "unknown extension, did you mean 'x-terraform-id'?"}},
+		{name: "unknown terraform extension", extension: "x-terraform-something-else", expectedWarning: &SpecLintWarning{Location: "loc", Extension: "x-terraform-something-else", Message: "unknown extension"}},
+		{name: "unrelated vendor extension", extension: "x-go-name", expectedWarning: nil},
+	}
+	for _, tc := range testCases {
+		warning := checkExtension("loc", tc.extension)
+		if tc.expectedWarning == nil && warning != nil {
+			t.Errorf("%s: expected no warning but got %+v", tc.name, warning)
+		}
+		if tc.expectedWarning != nil && (warning == nil || *warning != *tc.expectedWarning) {
+			t.Errorf("%s: expected warning %+v but got %+v", tc.name, tc.expectedWarning, warning)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/swag"
+)
+
+// specExpansionLock serialises the expansion of the OpenAPI documents, since the go-openapi library resolves the remote
+// references with the global spec.PathLoader which is replaced while expanding the documents retrieved by a specLoader
+var specExpansionLock sync.Mutex
+
+// embeddedDocumentsBaseURL is the base URL the references of the OpenAPI documents embedded in the provider binary are
+// resolved against, so the go-openapi library does not mistake them for files stored on disk. The go-openapi library only
+// resolves the references relative to URLs using the http scheme, hence the reserved .invalid host that never resolves.
+const embeddedDocumentsBaseURL = "http://embedded.invalid/"
+
+// specLoader retrieves the OpenAPI documents hosted remotely with a dedicated HTTP client (e,g: configured with the
+// provider's TLS configuration), sending the configured headers (e,g: credentials) along with the requests. The headers
+// are also sent when resolving references to other documents hosted on the same host, but never to other hosts.
+// If a cache is configured, the documents (and their expanded version) are stored on disk and revalidated with the server
+// when they are loaded again, falling back to the cached copy if the server can not be reached.
+type specLoader struct {
+	httpClient *http.Client
+	headers    map[string]string
+	cache      *specCache
+	// embeddedFS contains the OpenAPI documents compiled into the provider binary, nil if there are none
+	embeddedFS fs.FS
+	// integrity defines how the OpenAPI documents are verified, nil if they are not
+	integrity *SwaggerIntegrityConfig
+	// patch defines the JSON Patch operations applied to the OpenAPI documents after they are verified, empty if there are none
+	patch []SwaggerPatchOperation
+	// regions defines the regions configured in the plugin configuration, nil if there are none
+	regions *RegionsConfig
+	// cacheEntry is the cache entry of the last document loaded, nil if the document was not cached
+	cacheEntry *specCacheEntry
+}
+
+// newSpecLoader returns a specLoader configured with the TLS, swagger auth, swagger cache, swagger integrity, swagger
+// patch and regions configuration of the given service configuration, as well as the OpenAPI documents embedded in the
+// provider binary
+func newSpecLoader(serviceConfiguration ServiceConfiguration, httpClient *http.Client) (*specLoader, error) {
+	headers := map[string]string{}
+	if swaggerAuthConfiguration := serviceConfiguration.GetSwaggerAuthConfiguration(); swaggerAuthConfiguration != nil {
+		var err error
+		headers, err = swaggerAuthConfiguration.getHeaders()
+		if err != nil {
+			return nil, err
+		}
+	}
+	cache, err := newSpecCache(serviceConfiguration.GetSwaggerCacheConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the swagger cache: %s", err)
+	}
+	return &specLoader{
+		httpClient: httpClient,
+		headers:    headers,
+		cache:      cache,
+		embeddedFS: serviceConfiguration.GetEmbeddedFS(),
+		integrity:  serviceConfiguration.GetSwaggerIntegrityConfiguration(),
+		patch:      serviceConfiguration.GetSwaggerPatchConfiguration(),
+		regions:    serviceConfiguration.GetRegionsConfiguration(),
+	}, nil
+}
+
+// load returns the OpenAPI document located at the given path, which can be either a URL, a path to a file embedded in
+// the provider binary or a path to a file stored on disk. A nil loader (or a document stored on disk) uses the default
+// go-openapi loader. If an integrity configuration is set, the document is verified before it's returned. If a patch is
+// configured, it's applied to the document once verified.
+func (l *specLoader) load(openAPIDocumentURL string) (*loads.Document, error) {
+	var data []byte
+	var err error
+	switch {
+	case l.isEmbedded(openAPIDocumentURL):
+		data, err = fs.ReadFile(l.embeddedFS, path.Clean(openAPIDocumentURL))
+	case l.isRemote(openAPIDocumentURL) && l.cache != nil:
+		data, err = l.loadCached(openAPIDocumentURL)
+	case l.isRemote(openAPIDocumentURL):
+		data, err = l.get(openAPIDocumentURL)
+	case l != nil && (l.integrity != nil || len(l.patch) > 0):
+		data, err = ioutil.ReadFile(openAPIDocumentURL) // #nosec G304
+	default:
+		return loads.JSONSpec(openAPIDocumentURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := l.verify(openAPIDocumentURL, data); err != nil {
+		return nil, err
+	}
+	if len(l.patch) > 0 {
+		if data, err = applyOpenAPIDocumentPatch(data, l.patch); err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] the swagger patch has been applied to the OpenAPI document '%s'", openAPIDocumentURL)
+	}
+	if err := l.verifySelfContained(data); err != nil {
+		return nil, err
+	}
+	return loads.Analyzed(data, "")
+}
+
+// verify checks the integrity of the given document with the configured checksum and/or detached signature, the signature
+// is retrieved the same way as the document
+func (l *specLoader) verify(openAPIDocumentURL string, document []byte) error {
+	if l.integrity == nil {
+		return nil
+	}
+	if l.integrity.SHA256 != "" {
+		if err := verifyOpenAPIDocumentChecksum(document, l.integrity.SHA256); err != nil {
+			return fmt.Errorf("integrity verification failed: %s", err)
+		}
+	}
+	if l.integrity.Signature != nil {
+		signatureURL := l.integrity.Signature.getURL(openAPIDocumentURL)
+		signature, err := l.read(signatureURL)
+		if err != nil {
+			return fmt.Errorf("integrity verification failed: could not retrieve the signature from '%s': %s", signatureURL, err)
+		}
+		if err := verifyOpenAPIDocumentSignature(document, signature, l.integrity.Signature.PublicKey); err != nil {
+			return fmt.Errorf("integrity verification failed: %s", err)
+		}
+	}
+	log.Printf("[INFO] the integrity of the OpenAPI document '%s' has been verified", openAPIDocumentURL)
+	return nil
+}
+
+// verifySelfContained makes sure the given document does not reference other documents if an integrity configuration is
+// set, since the referenced documents are not covered by the checksum nor the signature
+func (l *specLoader) verifySelfContained(document []byte) error {
+	if l.integrity == nil {
+		return nil
+	}
+	ref, err := findExternalReference(document)
+	if err != nil {
+		return fmt.Errorf("integrity verification failed: %s", err)
+	}
+	if ref != "" {
+		return fmt.Errorf("integrity verification failed: the document references '%s', which can not be verified - the document must be self-contained", ref)
+	}
+	return nil
+}
+
+// read returns the content of the file located at the given path without caching it, which can be either a URL, a path
+// to a file embedded in the provider binary or a path to a file stored on disk
+func (l *specLoader) read(location string) ([]byte, error) {
+	switch {
+	case l.isEmbedded(location):
+		return fs.ReadFile(l.embeddedFS, path.Clean(location))
+	case l.isRemote(location):
+		return l.get(location)
+	}
+	return ioutil.ReadFile(location) // #nosec G304
+}
+
+// loadCached returns the cached document if it's within the max age. Otherwise, the document is revalidated with the
+// server and the cached copy is used if the server reports it has not been modified or the server can not be reached.
+func (l *specLoader) loadCached(openAPIDocumentURL string) ([]byte, error) {
+	entry, err := l.fetchCached(openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	l.cacheEntry = entry
+	return entry.Document, nil
+}
+
+// fetchCached returns the cache entry of the given URL, which is revalidated with the server if it's not within the max
+// age. The entry is updated if the server returns a new version of the document.
+func (l *specLoader) fetchCached(openAPIDocumentURL string) (*specCacheEntry, error) {
+	entry, err := l.cache.read(openAPIDocumentURL)
+	if err != nil {
+		log.Printf("[WARN] ignoring the OpenAPI document cached for '%s' since it could not be read: %s", openAPIDocumentURL, err)
+		entry = nil
+	}
+	if entry != nil && l.cache.isFresh(entry) {
+		log.Printf("[DEBUG] using the OpenAPI document cached for '%s' at %s", openAPIDocumentURL, entry.FetchedAt)
+		return entry, nil
+	}
+	resp, err := l.do(openAPIDocumentURL, entry.revalidationHeaders())
+	if err == nil {
+		defer resp.Body.Close()
+	}
+	switch {
+	case err == nil && resp.StatusCode == http.StatusNotModified && entry != nil:
+		log.Printf("[DEBUG] the OpenAPI document cached for '%s' has not been modified", openAPIDocumentURL)
+		entry.FetchedAt = l.cache.now()
+		l.writeCacheEntry(entry)
+	case err == nil && resp.StatusCode == http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		entry = &specCacheEntry{
+			URL:          openAPIDocumentURL,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    l.cache.now(),
+			Document:     data,
+		}
+	default:
+		if err == nil {
+			err = fmt.Errorf("could not access document at %q [%s] ", openAPIDocumentURL, resp.Status)
+		}
+		if entry == nil {
+			return nil, err
+		}
+		log.Printf("[WARN] failed to retrieve the OpenAPI document from '%s', using the copy cached at %s instead: %s", openAPIDocumentURL, entry.FetchedAt, err)
+	}
+	return entry, nil
+}
+
+// expand resolves the references of the given OpenAPI document. The references of remote documents are resolved relative
+// to the document URL, and the ones hosted on the same host as the document are retrieved by the loader.
+// If the document was loaded from the cache and it had been expanded already (with the same patch), the cached expanded
+// document is returned. The expanded documents are not cached if an integrity configuration is set, since only the
+// document itself is verified.
+func (l *specLoader) expand(apiSpec *loads.Document, openAPIDocumentURL string) (*loads.Document, error) {
+	if l.isEmbedded(openAPIDocumentURL) {
+		return l.expandEmbedded(apiSpec, openAPIDocumentURL)
+	}
+	if !l.isRemote(openAPIDocumentURL) {
+		specExpansionLock.Lock()
+		defer specExpansionLock.Unlock()
+		return apiSpec.Expanded()
+	}
+	entry := l.cacheEntry
+	if entry == nil || entry.URL != openAPIDocumentURL {
+		return l.expandRemote(apiSpec, openAPIDocumentURL)
+	}
+	patchChecksum := openAPIDocumentPatchChecksum(l.patch)
+	if l.integrity == nil && len(entry.ExpandedDocument) > 0 && entry.ExpandedPatchChecksum == patchChecksum {
+		return loads.Analyzed(entry.ExpandedDocument, "")
+	}
+	expandedSpec, err := l.expandRemote(apiSpec, openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	if l.integrity != nil {
+		entry.ExpandedDocument, entry.ExpandedPatchChecksum = nil, ""
+		l.writeCacheEntry(entry)
+		return expandedSpec, nil
+	}
+	entry.ExpandedDocument, err = json.Marshal(expandedSpec.Spec())
+	if err != nil {
+		log.Printf("[WARN] failed to cache the expanded OpenAPI document for '%s': %s", openAPIDocumentURL, err)
+		return expandedSpec, nil
+	}
+	entry.ExpandedPatchChecksum = patchChecksum
+	l.writeCacheEntry(entry)
+	return expandedSpec, nil
+}
+
+func (l *specLoader) expandRemote(apiSpec *loads.Document, openAPIDocumentURL string) (*loads.Document, error) {
+	specExpansionLock.Lock()
+	defer specExpansionLock.Unlock()
+	documentURL, err := url.Parse(openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	pathLoader := spec.PathLoader
+	defer func() { spec.PathLoader = pathLoader }()
+	spec.PathLoader = func(path string) (json.RawMessage, error) {
+		if !isSameOrigin(documentURL, path) {
+			return pathLoader(path)
+		}
+		log.Printf("[DEBUG] retrieving the OpenAPI document '%s' referenced from '%s'", path, openAPIDocumentURL)
+		data, err := l.get(path)
+		if err != nil {
+			return nil, err
+		}
+		return yamlToJSON(data)
+	}
+	return apiSpec.Expanded(&spec.ExpandOptions{RelativeBase: openAPIDocumentURL})
+}
+
+// isSameOrigin returns true if the given URL has the same scheme and host as the OpenAPI document URL. The loader's headers
+// are only sent to the same origin so the credentials are never sent to other hosts nor downgraded to cleartext.
+func isSameOrigin(documentURL *url.URL, otherURL string) bool {
+	parsedURL, err := url.Parse(otherURL)
+	return err == nil && parsedURL.Scheme == documentURL.Scheme && strings.EqualFold(parsedURL.Host, documentURL.Host)
+}
+
+// expandEmbedded resolves the references of the given embedded OpenAPI document, the relative references are resolved
+// from the files embedded in the provider binary
+func (l *specLoader) expandEmbedded(apiSpec *loads.Document, openAPIDocumentPath string) (*loads.Document, error) {
+	specExpansionLock.Lock()
+	defer specExpansionLock.Unlock()
+	pathLoader := spec.PathLoader
+	defer func() { spec.PathLoader = pathLoader }()
+	spec.PathLoader = func(documentPath string) (json.RawMessage, error) {
+		if !strings.HasPrefix(documentPath, embeddedDocumentsBaseURL) {
+			return pathLoader(documentPath)
+		}
+		data, err := fs.ReadFile(l.embeddedFS, strings.TrimPrefix(documentPath, embeddedDocumentsBaseURL))
+		if err != nil {
+			return nil, err
+		}
+		return yamlToJSON(data)
+	}
+	return apiSpec.Expanded(&spec.ExpandOptions{RelativeBase: embeddedDocumentsBaseURL + path.Clean(openAPIDocumentPath)})
+}
+
+func (l *specLoader) isEmbedded(openAPIDocumentURL string) bool {
+	return l != nil && l.embeddedFS != nil && !isURL(openAPIDocumentURL)
+}
+
+func (l *specLoader) isRemote(openAPIDocumentURL string) bool {
+	return l != nil && strings.HasPrefix(openAPIDocumentURL, "http")
+}
+
+// writeCacheEntry stores the given entry, the failures are only logged since the document can still be used
+func (l *specLoader) writeCacheEntry(entry *specCacheEntry) {
+	if err := l.cache.write(entry); err != nil {
+		log.Printf("[WARN] failed to cache the OpenAPI document for '%s': %s", entry.URL, err)
+	}
+}
+
+func (l *specLoader) get(documentURL string) ([]byte, error) {
+	resp, err := l.do(documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not access document at %q [%s] ", documentURL, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// do sends a GET request to the given URL including the loader's headers as well as the given ones
+func (l *specLoader) do(documentURL string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range l.headers {
+		req.Header.Set(name, value)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return l.httpClient.Do(req)
+}
+
+// yamlToJSON converts the given document to JSON if it's YAML formatted
+func yamlToJSON(data []byte) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '{' || trimmed[0] == '[' {
+		return data, nil
+	}
+	yamlDoc, err := swag.BytesToYAMLDoc(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return swag.YAMLToJSON(yamlDoc)
+}
@@ -0,0 +1,466 @@
+package openapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-openapi/loads"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specLoaderTestRootDocument = `swagger: "2.0"
+host: "localhost"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "%s/definitions.yaml#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "definitions.yaml#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "definitions.yaml#/definitions/ContentDeliveryNetwork"`
+
+const specLoaderTestDefinitionsDocument = `definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+
+const specLoaderTestSelfContainedDocument = `swagger: "2.0"
+host: "localhost"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+
+func newSpecLoaderTestServer(t *testing.T, expectedAPIKey string) (*httptest.Server, *[]string) {
+	var requestedPaths []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		if r.Header.Get("X-Api-Key") != expectedAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/swagger.yaml":
+			fmt.Fprintf(w, specLoaderTestRootDocument, server.URL)
+		case "/definitions.yaml":
+			w.Write([]byte(specLoaderTestDefinitionsDocument))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &requestedPaths
+}
+
+func TestSpecLoader(t *testing.T) {
+	server, requestedPaths := newSpecLoaderTestServer(t, "secret")
+	defer server.Close()
+
+	serviceConfiguration := &ServiceConfigStub{SwaggerAuth: &SwaggerAuthConfig{Headers: map[string]string{"X-Api-Key": "secret"}}}
+	loader, err := newSpecLoader(serviceConfiguration, &http.Client{})
+	require.NoError(t, err)
+
+	openAPIDocumentURL := server.URL + "/swagger.yaml"
+	apiSpec, err := loader.load(openAPIDocumentURL)
+	require.NoError(t, err)
+	expandedSpec, err := loader.expand(apiSpec, openAPIDocumentURL)
+	require.NoError(t, err)
+
+	postBody := expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Parameters[0].Schema
+	assert.Contains(t, postBody.Properties, "label")
+	getResponse := expandedSpec.Spec().Paths.Paths["/v1/cdns/{id}"].Get.Responses.StatusCodeResponses[200].Schema
+	assert.Contains(t, getResponse.Properties, "label")
+	assert.Contains(t, *requestedPaths, "/definitions.yaml")
+}
+
+func TestSpecLoaderMissingCredentials(t *testing.T) {
+	server, _ := newSpecLoaderTestServer(t, "secret")
+	defer server.Close()
+
+	loader, err := newSpecLoader(&ServiceConfigStub{}, &http.Client{})
+	require.NoError(t, err)
+	_, err = loader.load(server.URL + "/swagger.yaml")
+	assert.EqualError(t, err, fmt.Sprintf("could not access document at %q [401 Unauthorized] ", server.URL+"/swagger.yaml"))
+}
+
+func TestSpecLoaderHeadersNotSentToOtherHosts(t *testing.T) {
+	var otherHostRequested bool
+	var otherHostAPIKey string
+	otherHost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherHostRequested = true
+		otherHostAPIKey = r.Header.Get("X-Api-Key")
+		w.Write([]byte(specLoaderTestDefinitionsDocument))
+	}))
+	defer otherHost.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/swagger.yaml" {
+			fmt.Fprintf(w, specLoaderTestRootDocument, otherHost.URL)
+			return
+		}
+		w.Write([]byte(specLoaderTestDefinitionsDocument))
+	}))
+	defer server.Close()
+
+	loader, err := newSpecLoader(&ServiceConfigStub{SwaggerAuth: &SwaggerAuthConfig{Headers: map[string]string{"X-Api-Key": "secret"}}}, &http.Client{})
+	require.NoError(t, err)
+	apiSpec, err := loader.load(server.URL + "/swagger.yaml")
+	require.NoError(t, err)
+	_, err = loader.expand(apiSpec, server.URL+"/swagger.yaml")
+	require.NoError(t, err)
+	assert.True(t, otherHostRequested)
+	assert.Empty(t, otherHostAPIKey)
+}
+
+func TestIsSameOrigin(t *testing.T) {
+	documentURL, err := url.Parse("https://api.domain.com/swagger.yaml")
+	require.NoError(t, err)
+	testCases := []struct {
+		name     string
+		url      string
+		expected bool
+	}{
+		{name: "same scheme and host", url: "https://api.domain.com/definitions.yaml", expected: true},
+		{name: "same scheme and host with different case", url: "https://API.domain.com/definitions.yaml", expected: true},
+		{name: "same host over cleartext", url: "http://api.domain.com/definitions.yaml", expected: false},
+		{name: "same host with different port", url: "https://api.domain.com:8443/definitions.yaml", expected: false},
+		{name: "other host", url: "https://other.domain.com/definitions.yaml", expected: false},
+		{name: "relative path", url: "definitions.yaml", expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isSameOrigin(documentURL, tc.url), tc.name)
+	}
+}
+
+func TestNewSpecLoaderError(t *testing.T) {
+	_, err := newSpecLoader(&ServiceConfigStub{SwaggerAuth: &SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Env: "SPEC_LOADER_TEST_NON_EXISTING"}}}, &http.Client{})
+	assert.EqualError(t, err, "the environment variable 'SPEC_LOADER_TEST_NON_EXISTING' containing the swagger bearer token is not set")
+}
+
+func TestSpecLoaderCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "openapi-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	var requestedPaths []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		switch r.URL.Path {
+		case "/swagger.yaml":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprintf(w, specLoaderTestRootDocument, server.URL)
+		case "/definitions.yaml":
+			w.Write([]byte(specLoaderTestDefinitionsDocument))
+		}
+	}))
+	openAPIDocumentURL := server.URL + "/swagger.yaml"
+
+	loadDocument := func(maxAge int) (*loads.Document, error) {
+		loader, err := newSpecLoader(&ServiceConfigStub{SwaggerCache: &SwaggerCacheConfig{Enabled: true, MaxAge: maxAge, Dir: cacheDir}}, &http.Client{})
+		require.NoError(t, err)
+		apiSpec, err := loader.load(openAPIDocumentURL)
+		if err != nil {
+			return nil, err
+		}
+		return loader.expand(apiSpec, openAPIDocumentURL)
+	}
+	assertDocumentExpanded := func(expandedSpec *loads.Document) {
+		postBody := expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Parameters[0].Schema
+		So(postBody.Properties, ShouldContainKey, "label")
+	}
+
+	Convey("Given an empty cache", t, func() {
+		Convey("When the document is loaded", func() {
+			requestedPaths = nil
+			expandedSpec, err := loadDocument(0)
+			Convey("Then the document and its references should be retrieved from the server and the expanded document should be cached", func() {
+				So(err, ShouldBeNil)
+				assertDocumentExpanded(expandedSpec)
+				So(requestedPaths, ShouldResemble, []string{"/swagger.yaml", "/definitions.yaml"})
+				entry, err := (&specCache{dir: cacheDir}).read(openAPIDocumentURL)
+				So(err, ShouldBeNil)
+				So(entry.ETag, ShouldEqual, `"v1"`)
+				So(entry.ExpandedDocument, ShouldNotBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a cached document", t, func() {
+		Convey("When the document is loaded without max age and the server reports it has not been modified", func() {
+			requestedPaths = nil
+			expandedSpec, err := loadDocument(0)
+			Convey("Then the document should be revalidated and the cached expanded document should be used", func() {
+				So(err, ShouldBeNil)
+				assertDocumentExpanded(expandedSpec)
+				So(requestedPaths, ShouldResemble, []string{"/swagger.yaml"})
+			})
+		})
+		Convey("When the document is loaded within the max age", func() {
+			requestedPaths = nil
+			expandedSpec, err := loadDocument(3600)
+			Convey("Then the cached expanded document should be used without sending any request", func() {
+				So(err, ShouldBeNil)
+				assertDocumentExpanded(expandedSpec)
+				So(requestedPaths, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a cached document and a server that is not reachable", t, func() {
+		server.Close()
+		Convey("When the document is loaded", func() {
+			expandedSpec, err := loadDocument(0)
+			Convey("Then the last good copy should be used", func() {
+				So(err, ShouldBeNil)
+				assertDocumentExpanded(expandedSpec)
+			})
+		})
+		Convey("When the cache is empty and the document is loaded", func() {
+			So(os.RemoveAll(cacheDir), ShouldBeNil)
+			_, err := loadDocument(0)
+			Convey("Then the error should be returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecLoaderEmbedded(t *testing.T) {
+	embeddedFS := fstest.MapFS{
+		"specs/swagger.yaml":      {Data: []byte(fmt.Sprintf(specLoaderTestRootDocument, "../common"))},
+		"specs/definitions.yaml":  {Data: []byte(specLoaderTestDefinitionsDocument)},
+		"common/definitions.yaml": {Data: []byte(specLoaderTestDefinitionsDocument)},
+	}
+	loader, err := newSpecLoader(&ServiceConfigStub{EmbeddedFS: embeddedFS}, &http.Client{})
+	require.NoError(t, err)
+
+	apiSpec, err := loader.load("specs/swagger.yaml")
+	require.NoError(t, err)
+	expandedSpec, err := loader.expand(apiSpec, "specs/swagger.yaml")
+	require.NoError(t, err)
+	postBody := expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Parameters[0].Schema
+	assert.Contains(t, postBody.Properties, "label")
+	getResponse := expandedSpec.Spec().Paths.Paths["/v1/cdns/{id}"].Get.Responses.StatusCodeResponses[200].Schema
+	assert.Contains(t, getResponse.Properties, "label")
+
+	_, err = loader.load("specs/non_existing.yaml")
+	assert.EqualError(t, err, "open specs/non_existing.yaml: file does not exist")
+}
+
+func TestSpecLoaderIntegrity(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	document := []byte(specLoaderTestSelfContainedDocument)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/swagger.yaml":
+			w.Write(document)
+		case "/swagger.yaml.sig":
+			w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, document))))
+		case "/external.yaml":
+			fmt.Fprintf(w, specLoaderTestRootDocument, server.URL)
+		case "/external.yaml.sig":
+			w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(fmt.Sprintf(specLoaderTestRootDocument, server.URL))))))
+		case "/definitions.yaml":
+			w.Write([]byte(specLoaderTestDefinitionsDocument))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	checksum := sha256.Sum256(document)
+
+	testCases := []struct {
+		name          string
+		path          string
+		integrity     *SwaggerIntegrityConfig
+		expectedError string
+	}{
+		{name: "checksum matching", path: "/swagger.yaml", integrity: &SwaggerIntegrityConfig{SHA256: hex.EncodeToString(checksum[:])}},
+		{name: "signature valid", path: "/swagger.yaml", integrity: &SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: base64.StdEncoding.EncodeToString(publicKey)}}},
+		{name: "checksum not matching", path: "/swagger.yaml", integrity: &SwaggerIntegrityConfig{SHA256: strings.Repeat("0", 64)}, expectedError: fmt.Sprintf("integrity verification failed: the SHA-256 checksum of the document (%x) does not match the configured one (%s)", checksum, strings.Repeat("0", 64))},
+		{name: "signature not found", path: "/swagger.yaml", integrity: &SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: base64.StdEncoding.EncodeToString(publicKey), URL: server.URL + "/swagger.yaml.minisig"}}, expectedError: fmt.Sprintf(`integrity verification failed: could not retrieve the signature from '%[1]s/swagger.yaml.minisig': could not access document at "%[1]s/swagger.yaml.minisig" [404 Not Found] `, server.URL)},
+		{name: "signature valid but the document references other documents", path: "/external.yaml", integrity: &SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: base64.StdEncoding.EncodeToString(publicKey)}}, expectedError: fmt.Sprintf("integrity verification failed: the document references '%s/definitions.yaml#/definitions/ContentDeliveryNetwork', which can not be verified - the document must be self-contained", server.URL)},
+	}
+	for _, tc := range testCases {
+		openAPIDocumentURL := server.URL + tc.path
+		loader, err := newSpecLoader(&ServiceConfigStub{SwaggerIntegrity: tc.integrity}, &http.Client{})
+		require.NoError(t, err, tc.name)
+		_, err = newSpecAnalyserV2WithLoader(openAPIDocumentURL, loader)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, fmt.Sprintf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, tc.expectedError), tc.name)
+		}
+	}
+}
+
+func TestSpecLoaderIntegrityCachedExpandedDocument(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "openapi-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(specLoaderTestSelfContainedDocument))
+	}))
+	defer server.Close()
+	openAPIDocumentURL := server.URL + "/swagger.yaml"
+	checksum := sha256.Sum256([]byte(specLoaderTestSelfContainedDocument))
+	serviceConfiguration := &ServiceConfigStub{
+		SwaggerCache:     &SwaggerCacheConfig{Enabled: true, MaxAge: 3600, Dir: cacheDir},
+		SwaggerIntegrity: &SwaggerIntegrityConfig{SHA256: hex.EncodeToString(checksum[:])},
+	}
+	loadDocument := func() *loads.Document {
+		loader, err := newSpecLoader(serviceConfiguration, &http.Client{})
+		require.NoError(t, err)
+		apiSpec, err := loader.load(openAPIDocumentURL)
+		require.NoError(t, err)
+		expandedSpec, err := loader.expand(apiSpec, openAPIDocumentURL)
+		require.NoError(t, err)
+		return expandedSpec
+	}
+
+	loadDocument()
+	cache := &specCache{dir: cacheDir}
+	entry, err := cache.read(openAPIDocumentURL)
+	require.NoError(t, err)
+	assert.Empty(t, entry.ExpandedDocument, "the expanded document should not be cached when swagger integrity is configured")
+
+	entry.ExpandedDocument = []byte(`{"swagger": "2.0", "host": "attacker.com", "paths": {"/v1/tampered": {}}}`)
+	entry.ExpandedPatchChecksum = ""
+	require.NoError(t, cache.write(entry))
+	expandedSpec := loadDocument()
+	assert.Equal(t, "localhost", expandedSpec.Spec().Host)
+	assert.NotContains(t, expandedSpec.Spec().Paths.Paths, "/v1/tampered")
+	assert.Contains(t, expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Parameters[0].Schema.Properties, "label")
+}
+
+func TestSpecLoaderIntegrityLocalFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "swagger.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`swagger: "2.0"`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	loader, err := newSpecLoader(&ServiceConfigStub{SwaggerIntegrity: &SwaggerIntegrityConfig{SHA256: strings.Repeat("0", 64)}}, &http.Client{})
+	require.NoError(t, err)
+	_, err = loader.load(file.Name())
+	assert.Error(t, err)
+
+	checksum := sha256.Sum256([]byte(`swagger: "2.0"`))
+	loader, err = newSpecLoader(&ServiceConfigStub{SwaggerIntegrity: &SwaggerIntegrityConfig{SHA256: hex.EncodeToString(checksum[:])}}, &http.Client{})
+	require.NoError(t, err)
+	_, err = loader.load(file.Name())
+	assert.NoError(t, err)
+}
+
+func TestSpecLoaderPatch(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "openapi-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+	server, _ := newSpecLoaderTestServer(t, "")
+	defer server.Close()
+	openAPIDocumentURL := server.URL + "/swagger.yaml"
+
+	loadDocument := func(patch []SwaggerPatchOperation) (*loads.Document, error) {
+		loader, err := newSpecLoader(&ServiceConfigStub{SwaggerPatch: patch, SwaggerCache: &SwaggerCacheConfig{Enabled: true, Dir: cacheDir}}, &http.Client{})
+		require.NoError(t, err)
+		apiSpec, err := loader.load(openAPIDocumentURL)
+		if err != nil {
+			return nil, err
+		}
+		return loader.expand(apiSpec, openAPIDocumentURL)
+	}
+	resourceNamePatch := func(resourceName string) []SwaggerPatchOperation {
+		return []SwaggerPatchOperation{{Op: "add", Path: "/paths/~1v1~1cdns/post/x-terraform-resource-name", Value: resourceName}}
+	}
+
+	expandedSpec, err := loadDocument(resourceNamePatch("cdn"))
+	require.NoError(t, err)
+	assert.Equal(t, "cdn", expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Extensions["x-terraform-resource-name"])
+	assert.Contains(t, expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Parameters[0].Schema.Properties, "label")
+
+	// the expanded document cached with a different patch must not be used
+	expandedSpec, err = loadDocument(resourceNamePatch("content_delivery_network"))
+	require.NoError(t, err)
+	assert.Equal(t, "content_delivery_network", expandedSpec.Spec().Paths.Paths["/v1/cdns"].Post.Extensions["x-terraform-resource-name"])
+
+	_, err = loadDocument([]SwaggerPatchOperation{{Op: "remove", Path: "/paths/~1v1~1non_existing"}})
+	assert.EqualError(t, err, "swagger patch operation #0 (remove /paths/~1v1~1non_existing) failed: path '/paths/~1v1~1non_existing' not found: member '/v1/non_existing' does not exist")
+}
+
+func TestSpecLoaderPatchLocalFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "swagger.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`swagger: "2.0"
+host: "localhost"`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	loader, err := newSpecLoader(&ServiceConfigStub{SwaggerPatch: []SwaggerPatchOperation{{Op: "replace", Path: "/host", Value: "api.domain.com"}}}, &http.Client{})
+	require.NoError(t, err)
+	apiSpec, err := loader.load(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "api.domain.com", apiSpec.Spec().Host)
+}
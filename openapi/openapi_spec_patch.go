@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// applyOpenAPIDocumentPatch applies the given JSON Patch (RFC 6902) operations in order to the given document (JSON or
+// YAML), returning the patched document in JSON. The operations are atomic, if any of them fails an error is returned.
+func applyOpenAPIDocumentPatch(document []byte, patch []SwaggerPatchOperation) ([]byte, error) {
+	jsonDocument, err := yamlToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonDocument))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, err
+	}
+	for i, operation := range patch {
+		if root, err = applyJSONPatchOperation(root, operation); err != nil {
+			return nil, fmt.Errorf("swagger patch operation #%d (%s %s) failed: %s", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+// openAPIDocumentPatchChecksum returns the checksum of the given patch, so the documents patched with a different patch
+// can be told apart (e,g: the expanded documents stored in the swagger cache). An empty checksum is returned if the patch is empty.
+func openAPIDocumentPatchChecksum(patch []SwaggerPatchOperation) string {
+	if len(patch) == 0 {
+		return ""
+	}
+	operations := make([]interface{}, len(patch))
+	for i, operation := range patch {
+		operations[i] = []interface{}{operation.Op, operation.Path, operation.From, normalizeYAMLValue(operation.Value)}
+	}
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return ""
+	}
+	checksum := sha256.Sum256(data)
+	return hex.EncodeToString(checksum[:])
+}
+
+func applyJSONPatchOperation(root interface{}, operation SwaggerPatchOperation) (interface{}, error) {
+	switch operation.Op {
+	case "add":
+		return addJSONPointer(root, operation.Path, normalizeYAMLValue(operation.Value))
+	case "remove":
+		root, _, err := removeJSONPointer(root, operation.Path)
+		return root, err
+	case "replace":
+		if operation.Path == "" {
+			return normalizeYAMLValue(operation.Value), nil
+		}
+		root, _, err := removeJSONPointer(root, operation.Path)
+		if err != nil {
+			return nil, err
+		}
+		return addJSONPointer(root, operation.Path, normalizeYAMLValue(operation.Value))
+	case "move":
+		if operation.From == operation.Path {
+			_, err := getJSONPointer(root, operation.From)
+			return root, err
+		}
+		root, value, err := removeJSONPointer(root, operation.From)
+		if err != nil {
+			return nil, err
+		}
+		return addJSONPointer(root, operation.Path, value)
+	case "copy":
+		value, err := getJSONPointer(root, operation.From)
+		if err != nil {
+			return nil, err
+		}
+		return addJSONPointer(root, operation.Path, deepCopyJSONValue(value))
+	case "test":
+		value, err := getJSONPointer(root, operation.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonValuesEqual(value, normalizeYAMLValue(operation.Value)) {
+			return nil, fmt.Errorf("the value at '%s' is not the expected one", operation.Path)
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("operation '%s' not supported", operation.Op)
+}
+
+// parseJSONPointer returns the reference tokens of the given JSON pointer (RFC 6901), empty for the whole document
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer '%s' must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getJSONPointer(root interface{}, pointer string) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value := root
+	for _, token := range tokens {
+		if value, err = getJSONChild(value, token); err != nil {
+			return nil, fmt.Errorf("path '%s' not found: %s", pointer, err)
+		}
+	}
+	return value, nil
+}
+
+// addJSONPointer adds the value at the location of the given pointer, replacing the object member if it exists already
+// or inserting the value in the array at the given index ('-' appends the value at the end of the array)
+func addJSONPointer(root interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return updateJSONParent(root, pointer, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index := len(container)
+			if token != "-" {
+				if index, err = parseJSONArrayIndex(token, len(container)+1); err != nil {
+					return nil, err
+				}
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("'%s' can not be added to a value that is neither an object nor an array", token)
+	})
+}
+
+// removeJSONPointer removes the value at the location of the given pointer, returning the removed value too
+func removeJSONPointer(root interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("the whole document can not be removed")
+	}
+	var removed interface{}
+	root, err = updateJSONParent(root, pointer, tokens, func(parent interface{}, token string) (interface{}, error) {
+		if removed, err = getJSONChild(parent, token); err != nil {
+			return nil, fmt.Errorf("path '%s' not found: %s", pointer, err)
+		}
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, _ := parseJSONArrayIndex(token, len(container))
+			return append(container[:index], container[index+1:]...), nil
+		}
+		return parent, nil
+	})
+	return root, removed, err
+}
+
+// updateJSONParent replaces the parent of the location referenced by the given tokens with the one returned by the update
+// function, since updating an array might require a new slice
+func updateJSONParent(node interface{}, pointer string, tokens []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return update(node, tokens[0])
+	}
+	child, err := getJSONChild(node, tokens[0])
+	if err != nil {
+		return nil, fmt.Errorf("path '%s' not found: %s", pointer, err)
+	}
+	child, err = updateJSONParent(child, pointer, tokens[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	switch container := node.(type) {
+	case map[string]interface{}:
+		container[tokens[0]] = child
+	case []interface{}:
+		index, _ := parseJSONArrayIndex(tokens[0], len(container))
+		container[index] = child
+	}
+	return node, nil
+}
+
+func getJSONChild(node interface{}, token string) (interface{}, error) {
+	switch container := node.(type) {
+	case map[string]interface{}:
+		child, exists := container[token]
+		if !exists {
+			return nil, fmt.Errorf("member '%s' does not exist", token)
+		}
+		return child, nil
+	case []interface{}:
+		index, err := parseJSONArrayIndex(token, len(container))
+		if err != nil {
+			return nil, err
+		}
+		return container[index], nil
+	}
+	return nil, fmt.Errorf("'%s' can not be referenced in a value that is neither an object nor an array", token)
+}
+
+// parseJSONArrayIndex returns the array index of the given token, which must be lower than the given limit
+func parseJSONArrayIndex(token string, limit int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("'%s' is not a valid array index", token)
+	}
+	if index >= limit {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+// normalizeYAMLValue converts the maps unmarshalled from YAML (keyed by interface{}) into maps keyed by string so the
+// value can be encoded in JSON
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprintf("%v", key)] = normalizeYAMLValue(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeYAMLValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeYAMLValue(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+// deepCopyJSONValue returns a copy of the given value, the normalization copies the objects and arrays
+func deepCopyJSONValue(value interface{}) interface{} {
+	return normalizeYAMLValue(value)
+}
+
+// jsonValuesEqual compares the given values as JSON values, hence numbers are equal regardless of how they were decoded
+func jsonValuesEqual(a, b interface{}) bool {
+	var normalizedA, normalizedB interface{}
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	if errA != nil || errB != nil || json.Unmarshal(dataA, &normalizedA) != nil || json.Unmarshal(dataB, &normalizedB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizedA, normalizedB)
+}
@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOpenAPIDocumentPatch(t *testing.T) {
+	document := `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":["label"]}}}`
+	testCases := []struct {
+		name             string
+		patch            []SwaggerPatchOperation
+		expectedDocument string
+		expectedError    string
+	}{
+		{
+			name:             "add object member",
+			patch:            []SwaggerPatchOperation{{Op: "add", Path: "/paths/~1v1~1cdns/x-terraform-exclude-resource", Value: true}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]},"x-terraform-exclude-resource":true}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":["label"]}}}`,
+		},
+		{
+			name:             "add object member with a value unmarshalled from YAML",
+			patch:            []SwaggerPatchOperation{{Op: "add", Path: "/definitions/ContentDeliveryNetwork/properties/label/x-terraform-sensitive", Value: map[interface{}]interface{}{"enabled": true}}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string","x-terraform-sensitive":{"enabled":true}}},"required":["label"]}}}`,
+		},
+		{
+			name:             "add array item",
+			patch:            []SwaggerPatchOperation{{Op: "add", Path: "/paths/~1v1~1cdns/post/tags/0", Value: "network"}, {Op: "add", Path: "/paths/~1v1~1cdns/post/tags/-", Value: "edge"}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["network","cdn","edge"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":["label"]}}}`,
+		},
+		{
+			name:             "remove",
+			patch:            []SwaggerPatchOperation{{Op: "remove", Path: "/definitions/ContentDeliveryNetwork/required/0"}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":[]}}}`,
+		},
+		{
+			name:             "replace",
+			patch:            []SwaggerPatchOperation{{Op: "replace", Path: "/definitions/ContentDeliveryNetwork/properties/label/type", Value: "integer"}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"integer"}},"required":["label"]}}}`,
+		},
+		{
+			name:             "move",
+			patch:            []SwaggerPatchOperation{{Op: "move", From: "/paths/~1v1~1cdns", Path: "/paths/~1v2~1cdns"}},
+			expectedDocument: `{"paths":{"/v2/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":["label"]}}}`,
+		},
+		{
+			name:             "copy and test",
+			patch:            []SwaggerPatchOperation{{Op: "copy", From: "/paths/~1v1~1cdns/post/tags", Path: "/tags"}, {Op: "test", Path: "/tags", Value: []interface{}{"cdn"}}},
+			expectedDocument: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"definitions":{"ContentDeliveryNetwork":{"properties":{"label":{"type":"string"}},"required":["label"]}},"tags":["cdn"]}`,
+		},
+		{
+			name:          "test failure",
+			patch:         []SwaggerPatchOperation{{Op: "test", Path: "/paths/~1v1~1cdns/post/tags/0", Value: "network"}},
+			expectedError: "swagger patch operation #0 (test /paths/~1v1~1cdns/post/tags/0) failed: the value at '/paths/~1v1~1cdns/post/tags/0' is not the expected one",
+		},
+		{
+			name:          "replace non existing member",
+			patch:         []SwaggerPatchOperation{{Op: "add", Path: "/host", Value: "api.domain.com"}, {Op: "replace", Path: "/basePath", Value: "/api"}},
+			expectedError: "swagger patch operation #1 (replace /basePath) failed: path '/basePath' not found: member 'basePath' does not exist",
+		},
+		{
+			name:          "add member to non existing parent",
+			patch:         []SwaggerPatchOperation{{Op: "add", Path: "/paths/~1v2~1cdns/x-terraform-exclude-resource", Value: true}},
+			expectedError: "swagger patch operation #0 (add /paths/~1v2~1cdns/x-terraform-exclude-resource) failed: path '/paths/~1v2~1cdns/x-terraform-exclude-resource' not found: member '/v2/cdns' does not exist",
+		},
+		{
+			name:          "add array item out of bounds",
+			patch:         []SwaggerPatchOperation{{Op: "add", Path: "/paths/~1v1~1cdns/post/tags/2", Value: "edge"}},
+			expectedError: "swagger patch operation #0 (add /paths/~1v1~1cdns/post/tags/2) failed: array index 2 out of bounds",
+		},
+	}
+	for _, tc := range testCases {
+		patchedDocument, err := applyOpenAPIDocumentPatch([]byte(document), tc.patch)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.JSONEq(t, tc.expectedDocument, string(patchedDocument), tc.name)
+	}
+}
+
+func TestApplyOpenAPIDocumentPatchYAML(t *testing.T) {
+	patchedDocument, err := applyOpenAPIDocumentPatch([]byte(`swagger: "2.0"
+host: "localhost"
+x-terraform-provider-regions: "rst1"`), []SwaggerPatchOperation{{Op: "remove", Path: "/x-terraform-provider-regions"}, {Op: "replace", Path: "/host", Value: "api.domain.com"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"swagger":"2.0","host":"api.domain.com"}`, string(patchedDocument))
+}
+
+func TestOpenAPIDocumentPatchChecksum(t *testing.T) {
+	assert.Empty(t, openAPIDocumentPatchChecksum(nil))
+	checksum := openAPIDocumentPatchChecksum([]SwaggerPatchOperation{{Op: "add", Path: "/host", Value: map[interface{}]interface{}{"name": "api.domain.com"}}})
+	assert.Len(t, checksum, 64)
+	assert.Equal(t, checksum, openAPIDocumentPatchChecksum([]SwaggerPatchOperation{{Op: "add", Path: "/host", Value: map[string]interface{}{"name": "api.domain.com"}}}))
+	assert.NotEqual(t, checksum, openAPIDocumentPatchChecksum([]SwaggerPatchOperation{{Op: "add", Path: "/host", Value: map[string]interface{}{"name": "localhost"}}}))
+}
@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderPropertyAttributes describes the type, sensitivity, description and validation of a provider property created
+// from the OpenAPI document (e,g: headers), which can be overridden in the plugin configuration
+type ProviderPropertyAttributes struct {
+	// Type is the type of the property (string, integer or boolean), string if empty
+	Type schemaDefinitionPropertyType
+	// Sensitive is true if the property holds a secret so its value is not displayed in the plan output nor the logs
+	Sensitive bool
+	// Default is the default value of the property, the default value configured in the plugin configuration takes precedence
+	Default string
+	// Description describes the property
+	Description string
+	// AllowedValues defines the only values the property can be configured with, any value if empty
+	AllowedValues []string
+	// Pattern defines the regular expression the values of the property must match, only applies to string properties
+	Pattern string
+	// Minimum and Maximum define the range the values of the property must be within, only apply to integer properties
+	Minimum *float64
+	Maximum *float64
+}
+
+// GetTypeName returns the name of the type of the property (string, integer or boolean)
+func (a ProviderPropertyAttributes) GetTypeName() string {
+	return string(a.getType())
+}
+
+func (a ProviderPropertyAttributes) getType() schemaDefinitionPropertyType {
+	switch a.Type {
+	case TypeInt, TypeBool:
+		return a.Type
+	default:
+		return TypeString
+	}
+}
+
+// getTerraformType returns the terraform schema type of the property
+func (a ProviderPropertyAttributes) getTerraformType() schema.ValueType {
+	switch a.getType() {
+	case TypeInt:
+		return schema.TypeInt
+	case TypeBool:
+		return schema.TypeBool
+	default:
+		return schema.TypeString
+	}
+}
+
+// validateFunc returns the function that validates the values of the property, nil if the property has no validation
+func (a ProviderPropertyAttributes) validateFunc() schema.SchemaValidateFunc {
+	if len(a.AllowedValues) == 0 && a.Pattern == "" && a.Minimum == nil && a.Maximum == nil {
+		return nil
+	}
+	return func(value interface{}, key string) ([]string, []error) {
+		if err := a.validate(value); err != nil {
+			return nil, []error{fmt.Errorf("property %s %s", key, err)}
+		}
+		return nil, nil
+	}
+}
+
+func (a ProviderPropertyAttributes) validate(value interface{}) error {
+	stringValue := fmt.Sprintf("%v", value)
+	if len(a.AllowedValues) > 0 && !isAllowedValue(a.AllowedValues, stringValue) {
+		return fmt.Errorf("value %s is not valid, please make sure the value is one of %+v", stringValue, a.AllowedValues)
+	}
+	switch a.getType() {
+	case TypeString:
+		if a.Pattern == "" {
+			return nil
+		}
+		matched, err := regexp.MatchString(a.Pattern, stringValue)
+		if err != nil {
+			return fmt.Errorf("pattern '%s' is not a valid regular expression: %s", a.Pattern, err)
+		}
+		if !matched {
+			return fmt.Errorf("value %s is not valid, please make sure the value matches the pattern '%s'", stringValue, a.Pattern)
+		}
+	case TypeInt:
+		intValue, err := strconv.Atoi(stringValue)
+		if err != nil {
+			return fmt.Errorf("value %s is not a valid integer", stringValue)
+		}
+		if a.Minimum != nil && float64(intValue) < *a.Minimum {
+			return fmt.Errorf("value %d is not valid, please make sure the value is greater than or equal to %v", intValue, *a.Minimum)
+		}
+		if a.Maximum != nil && float64(intValue) > *a.Maximum {
+			return fmt.Errorf("value %d is not valid, please make sure the value is less than or equal to %v", intValue, *a.Maximum)
+		}
+	}
+	return nil
+}
+
+// configureSchema sets up the given provider property schema with the sensitivity, description and validation of the property
+func (a ProviderPropertyAttributes) configureSchema(s *schema.Schema) {
+	s.Sensitive = a.Sensitive
+	s.Description = a.Description
+	s.ValidateFunc = a.validateFunc()
+}
+
+func isAllowedValue(allowedValues []string, value string) bool {
+	for _, allowedValue := range allowedValues {
+		if value == allowedValue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderPropertyAttributesGetTerraformType(t *testing.T) {
+	testCases := []struct {
+		name         string
+		attributes   ProviderPropertyAttributes
+		expectedType schema.ValueType
+		expectedName string
+	}{
+		{name: "no type", attributes: ProviderPropertyAttributes{}, expectedType: schema.TypeString, expectedName: "string"},
+		{name: "integer type", attributes: ProviderPropertyAttributes{Type: TypeInt}, expectedType: schema.TypeInt, expectedName: "integer"},
+		{name: "boolean type", attributes: ProviderPropertyAttributes{Type: TypeBool}, expectedType: schema.TypeBool, expectedName: "boolean"},
+		{name: "unsupported type", attributes: ProviderPropertyAttributes{Type: TypeFloat}, expectedType: schema.TypeString, expectedName: "string"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedType, tc.attributes.getTerraformType(), tc.name)
+		assert.Equal(t, tc.expectedName, tc.attributes.GetTypeName(), tc.name)
+	}
+}
+
+func TestProviderPropertyAttributesValidateFunc(t *testing.T) {
+	minimum := 1.0
+	maximum := 5.0
+	testCases := []struct {
+		name          string
+		attributes    ProviderPropertyAttributes
+		value         interface{}
+		expectedError string
+	}{
+		{name: "allowed value", attributes: ProviderPropertyAttributes{AllowedValues: []string{"v1", "v2"}}, value: "v2"},
+		{name: "not allowed value", attributes: ProviderPropertyAttributes{AllowedValues: []string{"v1", "v2"}}, value: "v3", expectedError: "property some_property value v3 is not valid, please make sure the value is one of [v1 v2]"},
+		{name: "allowed integer value", attributes: ProviderPropertyAttributes{Type: TypeInt, AllowedValues: []string{"1", "2"}}, value: 2},
+		{name: "value matching the pattern", attributes: ProviderPropertyAttributes{Pattern: "^[a-z]+$"}, value: "abc"},
+		{name: "value not matching the pattern", attributes: ProviderPropertyAttributes{Pattern: "^[a-z]+$"}, value: "ABC", expectedError: "property some_property value ABC is not valid, please make sure the value matches the pattern '^[a-z]+$'"},
+		{name: "value within the range", attributes: ProviderPropertyAttributes{Type: TypeInt, Minimum: &minimum, Maximum: &maximum}, value: 5},
+		{name: "value below the minimum", attributes: ProviderPropertyAttributes{Type: TypeInt, Minimum: &minimum}, value: 0, expectedError: "property some_property value 0 is not valid, please make sure the value is greater than or equal to 1"},
+		{name: "value above the maximum", attributes: ProviderPropertyAttributes{Type: TypeInt, Maximum: &maximum}, value: 6, expectedError: "property some_property value 6 is not valid, please make sure the value is less than or equal to 5"},
+	}
+	for _, tc := range testCases {
+		validateFunc := tc.attributes.validateFunc()
+		if !assert.NotNil(t, validateFunc, tc.name) {
+			continue
+		}
+		_, errs := validateFunc(tc.value, "some_property")
+		if tc.expectedError == "" {
+			assert.Empty(t, errs, tc.name)
+		} else if assert.Len(t, errs, 1, tc.name) {
+			assert.EqualError(t, errs[0], tc.expectedError, tc.name)
+		}
+	}
+	assert.Nil(t, ProviderPropertyAttributes{Type: TypeBool}.validateFunc())
+}
@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/swag"
+	"gopkg.in/yaml.v2"
+)
+
+// specDiscoveredRegion defines a region returned by the regions endpoint, the host is optional if the document defines
+// the 'x-terraform-provider-multiregion-fqdn' extension
+type specDiscoveredRegion struct {
+	Name     string `json:"name"`
+	Host     string `json:"host,omitempty"`
+	BasePath string `json:"basePath,omitempty"`
+	Scheme   string `json:"scheme,omitempty"`
+}
+
+// configureRegions updates the multi-region extensions of the given OpenAPI document with the regions discovered from
+// the regions endpoint (configured in the plugin configuration or the 'x-terraform-provider-regions-endpoint' extension)
+// and the regions configured in the plugin configuration, which take precedence over the ones defined in the document.
+// The regions endpoint is only called once when the provider is created, and the response is stored in the swagger cache
+// if configured so the regions are still available if the endpoint can not be reached.
+func (l *specLoader) configureRegions(apiSpec *spec.Swagger, openAPIDocumentURL string) error {
+	var regionsConfig *RegionsConfig
+	if l != nil {
+		regionsConfig = l.regions
+	}
+	discoveryURL, _ := apiSpec.Extensions.GetString(extTfProviderRegionsEndpoint)
+	if regionsConfig != nil && regionsConfig.DiscoveryURL != "" {
+		discoveryURL = regionsConfig.DiscoveryURL
+	}
+	if discoveryURL == "" && regionsConfig == nil {
+		return nil
+	}
+	regions := getSpecRegions(apiSpec)
+	regionHosts, err := specV2BackendConfiguration{spec: apiSpec}.getRegionHosts()
+	if err != nil {
+		return err
+	}
+	if discoveryURL != "" {
+		discoveredRegions, err := l.discoverRegions(discoveryURL, openAPIDocumentURL)
+		switch {
+		case err != nil && len(regions) == 0 && len(regionHosts) == 0 && (regionsConfig == nil || len(regionsConfig.Hosts) == 0):
+			return fmt.Errorf("failed to discover the regions: %s", err)
+		case err != nil:
+			log.Printf("[WARN] failed to discover the regions, using the regions defined in the OpenAPI document and the plugin configuration instead: %s", err)
+		default:
+			regions = []string{}
+			for _, discoveredRegion := range discoveredRegions {
+				regions = append(regions, discoveredRegion.Name)
+				if discoveredRegion.Host != "" {
+					regionHosts[discoveredRegion.Name] = specRegionHost{Host: discoveredRegion.Host, BasePath: discoveredRegion.BasePath, Scheme: discoveredRegion.Scheme}
+				}
+			}
+			log.Printf("[INFO] discovered the regions %v from '%s'", regions, discoveryURL)
+		}
+	}
+	if regionsConfig != nil {
+		for _, region := range regionsConfig.getRegionNames() {
+			if !isAllowedValue(regions, region) {
+				regions = append(regions, region)
+			}
+		}
+		for region, regionHost := range regionsConfig.getRegionHosts() {
+			regionHosts[region] = regionHost
+		}
+	}
+	if len(regions) == 0 {
+		for region := range regionHosts {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+	}
+	if regionsConfig != nil && regionsConfig.Default != "" {
+		if !isAllowedValue(regions, regionsConfig.Default) {
+			return fmt.Errorf("default region '%s' is not one of the regions supported %v", regionsConfig.Default, regions)
+		}
+		defaultFirst := []string{regionsConfig.Default}
+		for _, region := range regions {
+			if region != regionsConfig.Default {
+				defaultFirst = append(defaultFirst, region)
+			}
+		}
+		regions = defaultFirst
+	}
+	if len(regions) > 0 {
+		apiSpec.AddExtension(extTfProviderRegions, strings.Join(regions, ","))
+	}
+	if len(regionHosts) > 0 {
+		apiSpec.AddExtension(extTfProviderRegionHosts, regionHosts)
+	}
+	return nil
+}
+
+// discoverRegions retrieves the regions from the given endpoint, which is resolved relative to the OpenAPI document URL.
+// The endpoint must return (in JSON or YAML format) either a list of region names or a list of regions including their
+// name and optionally their host, basePath and scheme. The loader's headers are only sent if the endpoint is configured
+// in the plugin configuration or it has the same scheme and host as the OpenAPI document, since otherwise the document
+// would decide where the credentials are sent.
+func (l *specLoader) discoverRegions(discoveryURL, openAPIDocumentURL string) ([]specDiscoveredRegion, error) {
+	if l == nil {
+		l = &specLoader{httpClient: &http.Client{Timeout: openAPIDocumentRequestTimeout}}
+	}
+	configured := l.regions != nil && l.regions.DiscoveryURL == discoveryURL
+	documentURL, err := url.Parse(openAPIDocumentURL)
+	if err != nil && isURL(openAPIDocumentURL) {
+		return nil, err
+	}
+	if !isURL(discoveryURL) && isURL(openAPIDocumentURL) {
+		endpointURL, err := url.Parse(discoveryURL)
+		if err != nil {
+			return nil, err
+		}
+		discoveryURL = documentURL.ResolveReference(endpointURL).String()
+	}
+	if len(l.headers) > 0 && !configured && (documentURL == nil || !isSameOrigin(documentURL, discoveryURL)) {
+		log.Printf("[DEBUG] not sending the swagger auth headers to the regions endpoint '%s' since it is not hosted along with the OpenAPI document", discoveryURL)
+		loader := *l
+		loader.headers = nil
+		l = &loader
+	}
+	var data []byte
+	if l.isRemote(discoveryURL) && l.cache != nil {
+		entry, err := l.fetchCached(discoveryURL)
+		if err != nil {
+			return nil, err
+		}
+		l.writeCacheEntry(entry)
+		data = entry.Document
+	} else {
+		var err error
+		if data, err = l.read(discoveryURL); err != nil {
+			return nil, err
+		}
+	}
+	return parseDiscoveredRegions(data)
+}
+
+// parseDiscoveredRegions parses the response of the regions endpoint, which can be either JSON or YAML formatted
+func parseDiscoveredRegions(data []byte) ([]specDiscoveredRegion, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	jsonData, err := swag.YAMLToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	discoveredRegions := []specDiscoveredRegion{}
+	var regionNames []string
+	if err := json.Unmarshal(jsonData, &regionNames); err == nil {
+		for _, regionName := range regionNames {
+			discoveredRegions = append(discoveredRegions, specDiscoveredRegion{Name: regionName})
+		}
+	} else if err := json.Unmarshal(jsonData, &discoveredRegions); err != nil {
+		return nil, fmt.Errorf("the regions must be a list of region names or a list of regions with their name, host, basePath and scheme: %s", err)
+	}
+	if len(discoveredRegions) == 0 {
+		return nil, fmt.Errorf("no regions found")
+	}
+	for _, discoveredRegion := range discoveredRegions {
+		if discoveredRegion.Name == "" {
+			return nil, fmt.Errorf("region name must not be empty")
+		}
+		if discoveredRegion.Scheme != "" && discoveredRegion.Scheme != "http" && discoveredRegion.Scheme != "https" {
+			return nil, fmt.Errorf("region '%s' scheme '%s' not supported - must use http or https", discoveredRegion.Name, discoveredRegion.Scheme)
+		}
+	}
+	return discoveredRegions, nil
+}
+
+// getSpecRegions returns the regions defined in the 'x-terraform-provider-regions' extension, empty if there are none
+func getSpecRegions(apiSpec *spec.Swagger) []string {
+	regions := []string{}
+	value, exists := apiSpec.Extensions.GetString(extTfProviderRegions)
+	if !exists {
+		return regions
+	}
+	for _, region := range strings.Split(value, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegionsTestServer(t *testing.T, regions string) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1/regions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(regions))
+	}))
+	return server, &requests
+}
+
+func newRegionsTestSpec(extensions spec.Extensions) *spec.Swagger {
+	return &spec.Swagger{
+		SwaggerProps:     spec.SwaggerProps{Swagger: "2.0"},
+		VendorExtensible: spec.VendorExtensible{Extensions: extensions},
+	}
+}
+
+func TestConfigureRegions(t *testing.T) {
+	server, _ := newRegionsTestServer(t, `[{"name": "rst1", "host": "rst.some-backend.com"}, {"name": "dub1", "host": "dub.some-backend.com", "basePath": "/v2", "scheme": "http"}]`)
+	defer server.Close()
+
+	testCases := []struct {
+		name                string
+		regionsConfig       *RegionsConfig
+		extensions          spec.Extensions
+		expectedRegions     string
+		expectedRegionHosts map[string]specRegionHost
+		expectedErr         string
+	}{
+		{
+			name:            "regions discovered from the endpoint defined in the document",
+			extensions:      spec.Extensions{extTfProviderRegionsEndpoint: "/v1/regions"},
+			expectedRegions: "rst1,dub1",
+			expectedRegionHosts: map[string]specRegionHost{
+				"rst1": {Host: "rst.some-backend.com"},
+				"dub1": {Host: "dub.some-backend.com", BasePath: "/v2", Scheme: "http"},
+			},
+		},
+		{
+			name:            "regions discovered from the endpoint configured in the plugin configuration",
+			regionsConfig:   &RegionsConfig{DiscoveryURL: server.URL + "/v1/regions", Default: "dub1"},
+			extensions:      spec.Extensions{extTfProviderRegionsEndpoint: "/v1/unknown"},
+			expectedRegions: "dub1,rst1",
+			expectedRegionHosts: map[string]specRegionHost{
+				"rst1": {Host: "rst.some-backend.com"},
+				"dub1": {Host: "dub.some-backend.com", BasePath: "/v2", Scheme: "http"},
+			},
+		},
+		{
+			name: "regions configured in the plugin configuration take precedence over the document",
+			regionsConfig: &RegionsConfig{Hosts: map[string]RegionHostConfig{
+				"dub1": {Host: "dub.other-backend.io", BasePath: "/api"},
+				"sfo1": {Host: "sfo.other-backend.io"},
+			}},
+			extensions: spec.Extensions{
+				extTfProviderMultiRegionFQDN: "www.${region}.some-backend.com",
+				extTfProviderRegions:         "rst1,dub1",
+			},
+			expectedRegions: "rst1,dub1,sfo1",
+			expectedRegionHosts: map[string]specRegionHost{
+				"dub1": {Host: "dub.other-backend.io", BasePath: "/api"},
+				"sfo1": {Host: "sfo.other-backend.io"},
+			},
+		},
+		{
+			name:            "regions defined in the document are used if the regions can not be discovered",
+			extensions:      spec.Extensions{extTfProviderRegionsEndpoint: "/v1/unknown", extTfProviderRegions: "rst1"},
+			expectedRegions: "rst1",
+		},
+		{
+			name:        "regions can not be discovered",
+			extensions:  spec.Extensions{extTfProviderRegionsEndpoint: "/v1/unknown"},
+			expectedErr: "failed to discover the regions: could not access document at \"" + server.URL + "/v1/unknown\" [404 Not Found] ",
+		},
+		{
+			name:          "default region not supported",
+			regionsConfig: &RegionsConfig{Default: "sfo1"},
+			extensions:    spec.Extensions{extTfProviderRegions: "rst1,dub1"},
+			expectedErr:   "default region 'sfo1' is not one of the regions supported [rst1 dub1]",
+		},
+	}
+	for _, tc := range testCases {
+		apiSpec := newRegionsTestSpec(tc.extensions)
+		loader := &specLoader{httpClient: &http.Client{}, regions: tc.regionsConfig}
+		err := loader.configureRegions(apiSpec, server.URL+"/swagger.yaml")
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		regions, _ := apiSpec.Extensions.GetString(extTfProviderRegions)
+		assert.Equal(t, tc.expectedRegions, regions, tc.name)
+		if tc.expectedRegionHosts != nil {
+			regionHosts, err := specV2BackendConfiguration{spec: apiSpec}.getRegionHosts()
+			require.NoError(t, err, tc.name)
+			assert.Equal(t, tc.expectedRegionHosts, regionHosts, tc.name)
+		}
+	}
+}
+
+func TestConfigureRegionsNotConfigured(t *testing.T) {
+	apiSpec := newRegionsTestSpec(spec.Extensions{extTfProviderMultiRegionFQDN: "www.${region}.some-backend.com", extTfProviderRegions: "rst1"})
+	var loader *specLoader
+	err := loader.configureRegions(apiSpec, "https://www.domain.com/swagger.yaml")
+	assert.NoError(t, err)
+	assert.NotContains(t, apiSpec.Extensions, extTfProviderRegionHosts)
+}
+
+func TestConfigureRegionsCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "openapi-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	server, requests := newRegionsTestServer(t, `["rst1", "dub1"]`)
+	openAPIDocumentURL := server.URL + "/swagger.yaml"
+	configureRegions := func(maxAge int) (string, error) {
+		loader, err := newSpecLoader(&ServiceConfigStub{SwaggerCache: &SwaggerCacheConfig{Enabled: true, MaxAge: maxAge, Dir: cacheDir}}, &http.Client{})
+		require.NoError(t, err)
+		apiSpec := newRegionsTestSpec(spec.Extensions{extTfProviderMultiRegionFQDN: "www.${region}.some-backend.com", extTfProviderRegionsEndpoint: "/v1/regions"})
+		if err := loader.configureRegions(apiSpec, openAPIDocumentURL); err != nil {
+			return "", err
+		}
+		regions, _ := apiSpec.Extensions.GetString(extTfProviderRegions)
+		return regions, nil
+	}
+
+	regions, err := configureRegions(3600)
+	assert.NoError(t, err)
+	assert.Equal(t, "rst1,dub1", regions)
+	assert.Equal(t, 1, *requests)
+
+	regions, err = configureRegions(3600)
+	assert.NoError(t, err, "the cached regions should be used within the max age")
+	assert.Equal(t, "rst1,dub1", regions)
+	assert.Equal(t, 1, *requests)
+
+	server.Close()
+	regions, err = configureRegions(0)
+	assert.NoError(t, err, "the cached regions should be used if the endpoint can not be reached")
+	assert.Equal(t, "rst1,dub1", regions)
+}
+
+func TestDiscoverRegionsAuthHeaders(t *testing.T) {
+	var authorization string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`["rst1"]`))
+	})
+	documentServer := httptest.NewServer(handler)
+	defer documentServer.Close()
+	otherServer := httptest.NewServer(handler)
+	defer otherServer.Close()
+
+	testCases := []struct {
+		name                  string
+		regionsConfig         *RegionsConfig
+		discoveryURL          string
+		expectedAuthorization string
+	}{
+		{
+			name:                  "endpoint defined in the document hosted along with the document",
+			discoveryURL:          "/v1/regions",
+			expectedAuthorization: "Bearer secret",
+		},
+		{
+			name:                  "endpoint defined in the document hosted on another host",
+			discoveryURL:          otherServer.URL + "/v1/regions",
+			expectedAuthorization: "",
+		},
+		{
+			name:                  "endpoint configured in the plugin configuration hosted on another host",
+			regionsConfig:         &RegionsConfig{DiscoveryURL: otherServer.URL + "/v1/regions"},
+			discoveryURL:          otherServer.URL + "/v1/regions",
+			expectedAuthorization: "Bearer secret",
+		},
+	}
+	for _, tc := range testCases {
+		authorization = ""
+		loader := &specLoader{httpClient: &http.Client{}, headers: map[string]string{"Authorization": "Bearer secret"}, regions: tc.regionsConfig}
+		discoveredRegions, err := loader.discoverRegions(tc.discoveryURL, documentServer.URL+"/swagger.yaml")
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, []specDiscoveredRegion{{Name: "rst1"}}, discoveredRegions, tc.name)
+		assert.Equal(t, tc.expectedAuthorization, authorization, tc.name)
+	}
+}
+
+func TestParseDiscoveredRegions(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		data                      string
+		expectedDiscoveredRegions []specDiscoveredRegion
+		expectedErr               string
+	}{
+		{
+			name:                      "list of region names",
+			data:                      `["rst1", "dub1"]`,
+			expectedDiscoveredRegions: []specDiscoveredRegion{{Name: "rst1"}, {Name: "dub1"}},
+		},
+		{
+			name:                      "list of regions in YAML format",
+			data:                      "- name: rst1\n  host: rst.some-backend.com\n",
+			expectedDiscoveredRegions: []specDiscoveredRegion{{Name: "rst1", Host: "rst.some-backend.com"}},
+		},
+		{
+			name:        "empty list of regions",
+			data:        `[]`,
+			expectedErr: "no regions found",
+		},
+		{
+			name:        "region without name",
+			data:        `[{"host": "rst.some-backend.com"}]`,
+			expectedErr: "region name must not be empty",
+		},
+		{
+			name:        "region with a scheme not supported",
+			data:        `[{"name": "rst1", "host": "rst.some-backend.com", "scheme": "ftp"}]`,
+			expectedErr: "region 'rst1' scheme 'ftp' not supported - must use http or https",
+		},
+		{
+			name:        "regions not in the expected format",
+			data:        `{"regions": ["rst1"]}`,
+			expectedErr: "the regions must be a list of region names or a list of regions with their name, host, basePath and scheme: json: cannot unmarshal object into Go value of type []openapi.specDiscoveredRegion",
+		},
+	}
+	for _, tc := range testCases {
+		discoveredRegions, err := parseDiscoveredRegions([]byte(tc.data))
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedDiscoveredRegions, discoveredRegions, tc.name)
+	}
+}
@@ -16,6 +16,15 @@ type SpecResource interface {
 	// GetParentResourceInfo returns a struct populated with relevant ParentResourceInfo if the resource is considered
 	// a sub-resource; nil otherwise.
 	GetParentResourceInfo() *ParentResourceInfo
+	// getRegion returns the region the resource is managed in if it overrides the region configured in the provider;
+	// empty otherwise.
+	getRegion() string
+	// getBasePath returns the base path the API calls of the resource are made against if it overrides the base path of
+	// the backend configuration; empty otherwise.
+	getBasePath() string
+	// getScheme returns the scheme the API calls of the resource are made with if it overrides the scheme of the backend
+	// configuration; empty otherwise.
+	getScheme() string
 }
 
 type specTimeouts struct {
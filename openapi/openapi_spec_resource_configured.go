@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// configuredSpecResource is a resource (or data source) exposed with the name and timeouts defined in the plugin configuration
+type configuredSpecResource struct {
+	SpecResource
+	name string
+	// timeouts contains the configured timeouts, the ones not configured are nil
+	timeouts *specTimeouts
+	// resourcesConfiguration is used to figure out the name the parent resource is exposed with
+	resourcesConfiguration *ResourcesConfig
+}
+
+// configureResources returns the resources exposed according to the given resources configuration, renamed and configured
+// with the timeouts defined in the configuration. An error is returned if two resources end up exposed with the same name
+// (e,g: a resource renamed to the name of another resource that is still exposed).
+func configureResources(resources []SpecResource, resourcesConfiguration *ResourcesConfig) ([]SpecResource, error) {
+	if resourcesConfiguration == nil {
+		return resources, nil
+	}
+	warnUnknownResourceNames(resources, resourcesConfiguration.getRenamedNames(), "resources renames")
+	warnUnknownResourceNames(resources, resourcesConfiguration.getTimeoutsNames(), "resources timeouts")
+	configuredResources := []SpecResource{}
+	exposedNames := map[string]string{}
+	for _, resource := range resources {
+		name := resource.GetResourceName()
+		configuredName, exposed := getConfiguredResourceName(name, &resourcesConfiguration.DataSourcesConfig)
+		if !exposed {
+			log.Printf("[INFO] '%s' is not exposed according to the plugin configuration and therefore skipping resource registration into the provider", name)
+			continue
+		}
+		if otherName, exists := exposedNames[configuredName]; exists {
+			return nil, fmt.Errorf("resources configuration not valid, both '%s' and '%s' are exposed as '%s'", otherName, name, configuredName)
+		}
+		exposedNames[configuredName] = name
+		configuredResource := configuredSpecResource{SpecResource: resource, name: configuredName, resourcesConfiguration: resourcesConfiguration}
+		if timeoutsConfiguration, exists := resourcesConfiguration.Timeouts[name]; exists {
+			timeouts, err := timeoutsConfiguration.getTimeouts()
+			if err != nil {
+				return nil, err
+			}
+			configuredResource.timeouts = timeouts
+		}
+		configuredResources = append(configuredResources, configuredResource)
+	}
+	return configuredResources, nil
+}
+
+// configureDataSources returns the data sources exposed according to the given data sources configuration, renamed as
+// defined in the configuration. An error is returned if two data sources end up exposed with the same name.
+func configureDataSources(dataSources []SpecResource, dataSourcesConfiguration *DataSourcesConfig, resourcesConfiguration *ResourcesConfig) ([]SpecResource, error) {
+	if dataSourcesConfiguration == nil {
+		return dataSources, nil
+	}
+	warnUnknownResourceNames(dataSources, dataSourcesConfiguration.getRenamedNames(), "data sources renames")
+	configuredDataSources := []SpecResource{}
+	exposedNames := map[string]string{}
+	for _, dataSource := range dataSources {
+		name := dataSource.GetResourceName()
+		configuredName, exposed := getConfiguredResourceName(name, dataSourcesConfiguration)
+		if !exposed {
+			log.Printf("[INFO] '%s' is not exposed according to the plugin configuration and therefore skipping data source registration into the provider", name)
+			continue
+		}
+		if otherName, exists := exposedNames[configuredName]; exists {
+			return nil, fmt.Errorf("data sources configuration not valid, both '%s' and '%s' are exposed as '%s'", otherName, name, configuredName)
+		}
+		exposedNames[configuredName] = name
+		configuredDataSources = append(configuredDataSources, configuredSpecResource{SpecResource: dataSource, name: configuredName, resourcesConfiguration: resourcesConfiguration})
+	}
+	return configuredDataSources, nil
+}
+
+// warnUnknownResourceNames logs a warning for each of the configured names that does not match the name of any of the
+// resources, since the configuration would be silently ignored otherwise (e,g: a typo in the name)
+func warnUnknownResourceNames(resources []SpecResource, configuredNames []string, setting string) {
+	names := map[string]bool{}
+	for _, resource := range resources {
+		names[resource.GetResourceName()] = true
+	}
+	sort.Strings(configuredNames)
+	for _, name := range configuredNames {
+		if names[name] {
+			continue
+		}
+		log.Printf("[WARN] ignoring the %s configured for '%s', the OpenAPI document does not define a resource with that name", setting, name)
+	}
+}
+
+// getConfiguredResourceName returns the name the resource (or data source) with the given name is exposed with according
+// to the given configuration, and whether it is exposed at all
+func getConfiguredResourceName(name string, configuration *DataSourcesConfig) (string, bool) {
+	if !configuration.isExposed(name) {
+		return name, false
+	}
+	return configuration.getName(name), true
+}
+
+func (r configuredSpecResource) GetResourceName() string {
+	return r.name
+}
+
+// getTimeouts returns the resource's timeouts overridden by the configured ones
+func (r configuredSpecResource) getTimeouts() (*specTimeouts, error) {
+	timeouts, err := r.SpecResource.getTimeouts()
+	if err != nil || r.timeouts == nil {
+		return timeouts, err
+	}
+	configuredTimeouts := specTimeouts{}
+	if timeouts != nil {
+		configuredTimeouts = *timeouts
+	}
+	if r.timeouts.Post != nil {
+		configuredTimeouts.Post = r.timeouts.Post
+	}
+	if r.timeouts.Get != nil {
+		configuredTimeouts.Get = r.timeouts.Get
+	}
+	if r.timeouts.Put != nil {
+		configuredTimeouts.Put = r.timeouts.Put
+	}
+	if r.timeouts.Delete != nil {
+		configuredTimeouts.Delete = r.timeouts.Delete
+	}
+	return &configuredTimeouts, nil
+}
+
+// GetParentResourceInfo returns the parent resource info with the full parent resource name the parent is exposed with
+func (r configuredSpecResource) GetParentResourceInfo() *ParentResourceInfo {
+	parentResourceInfo := r.SpecResource.GetParentResourceInfo()
+	if parentResourceInfo == nil || r.resourcesConfiguration == nil {
+		return parentResourceInfo
+	}
+	configuredParentResourceInfo := *parentResourceInfo
+	configuredParentResourceInfo.fullParentResourceName = r.resourcesConfiguration.getName(parentResourceInfo.fullParentResourceName)
+	return &configuredParentResourceInfo
+}
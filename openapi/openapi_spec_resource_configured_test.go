@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureResources(t *testing.T) {
+	cdns := newSpecStubResource("cdns_v1", "/v1/cdns", false, nil)
+	readTimeout := 5 * time.Minute
+	cdns.timeouts = &specTimeouts{Get: &readTimeout}
+	firewalls := newSpecStubResource("cdns_v1_firewalls", "/v1/cdns/{id}/firewalls", false, nil)
+	firewalls.parentResourceNames = []string{"cdns_v1"}
+	firewalls.fullParentResourceName = "cdns_v1"
+	lbs := newSpecStubResource("lbs_v1", "/v1/lbs", false, nil)
+	resources := []SpecResource{cdns, firewalls, lbs}
+
+	configuredResources, err := configureResources(resources, nil)
+	require.NoError(t, err)
+	assert.Equal(t, resources, configuredResources)
+
+	configuredResources, err = configureResources(resources, &ResourcesConfig{
+		DataSourcesConfig: DataSourcesConfig{Include: []string{"cdns_*"}, Renames: map[string]string{"cdns_v1": "cdn", "cdns_v1_firewalls": "cdn_firewall"}},
+		Timeouts:          map[string]ResourceTimeoutsConfig{"cdns_v1": {Create: "10m"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, configuredResources, 2)
+	assert.Equal(t, "cdn", configuredResources[0].GetResourceName())
+	assert.Equal(t, "cdn_firewall", configuredResources[1].GetResourceName())
+
+	// the configured timeouts override the ones defined in the OpenAPI document
+	timeouts, err := configuredResources[0].getTimeouts()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, *timeouts.Post)
+	assert.Equal(t, 5*time.Minute, *timeouts.Get)
+	assert.Nil(t, cdns.timeouts.Post)
+
+	// the parent resource is referred to with the name it's exposed with
+	assert.Nil(t, configuredResources[0].GetParentResourceInfo())
+	parentResourceInfo := configuredResources[1].GetParentResourceInfo()
+	require.NotNil(t, parentResourceInfo)
+	assert.Equal(t, "cdn", parentResourceInfo.fullParentResourceName)
+	assert.Equal(t, []string{"cdns_v1"}, parentResourceInfo.parentResourceNames)
+	assert.Equal(t, "cdns_v1", firewalls.GetParentResourceInfo().fullParentResourceName)
+
+	// a resource can not be renamed to the name of another resource that is still exposed
+	_, err = configureResources(resources, &ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Renames: map[string]string{"cdns_v1": "lbs_v1"}}})
+	assert.EqualError(t, err, "resources configuration not valid, both 'cdns_v1' and 'lbs_v1' are exposed as 'lbs_v1'")
+	configuredResources, err = configureResources(resources, &ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Exclude: []string{"lbs_v1"}, Renames: map[string]string{"cdns_v1": "lbs_v1"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "lbs_v1", configuredResources[0].GetResourceName())
+}
+
+func TestConfigureDataSources(t *testing.T) {
+	cdns := newSpecStubResource("cdns_v1", "/v1/cdns", false, nil)
+	lbs := newSpecStubResource("lbs_v1", "/v1/lbs", false, nil)
+	dataSources := []SpecResource{cdns, lbs}
+
+	configuredDataSources, err := configureDataSources(dataSources, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, dataSources, configuredDataSources)
+
+	configuredDataSources, err = configureDataSources(dataSources, &DataSourcesConfig{Exclude: []string{"lbs_*"}, Renames: map[string]string{"cdns_v1": "cdn"}}, nil)
+	require.NoError(t, err)
+	require.Len(t, configuredDataSources, 1)
+	assert.Equal(t, "cdn", configuredDataSources[0].GetResourceName())
+
+	_, err = configureDataSources(dataSources, &DataSourcesConfig{Renames: map[string]string{"lbs_v1": "cdns_v1"}}, nil)
+	assert.EqualError(t, err, "data sources configuration not valid, both 'cdns_v1' and 'lbs_v1' are exposed as 'cdns_v1'")
+}
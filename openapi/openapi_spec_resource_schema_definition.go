@@ -169,6 +169,16 @@ func (s *SpecSchemaDefinition) getStatusIdentifierFor(schemaDefinition *SpecSche
 	return statusHierarchy, nil
 }
 
+// getLabelsProperty returns the property that holds the resource's labels ('x-terraform-labels'), nil if there is none
+func (s *SpecSchemaDefinition) getLabelsProperty() *SpecSchemaDefinitionProperty {
+	for _, property := range s.Properties {
+		if property.IsLabels {
+			return property
+		}
+	}
+	return nil
+}
+
 func (s *SpecSchemaDefinition) getProperty(name string) (*SpecSchemaDefinitionProperty, error) {
 	for _, property := range s.Properties {
 		if property.Name == name {
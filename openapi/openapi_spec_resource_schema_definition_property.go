@@ -26,11 +26,17 @@ const (
 	TypeList schemaDefinitionPropertyType = "list"
 	// TypeObject defines a schema definition property of type object
 	TypeObject schemaDefinitionPropertyType = "object"
+	// TypeMap defines a schema definition property of type map (object with arbitrary keys and string values)
+	TypeMap schemaDefinitionPropertyType = "map"
 )
 
 const idDefaultPropertyName = "id"
 const statusDefaultPropertyName = "status"
 
+// labelsKeyPropertyName and labelsValuePropertyName define the properties of the objects of labels properties that are lists
+const labelsKeyPropertyName = "key"
+const labelsValuePropertyName = "value"
+
 // SpecSchemaDefinitionProperty defines the attributes for a schema property
 type SpecSchemaDefinitionProperty struct {
 	Name           string
@@ -55,6 +61,14 @@ type SpecSchemaDefinitionProperty struct {
 	Immutable          bool
 	IsIdentifier       bool
 	IsStatusIdentifier bool
+	// IsGlobalPathParameter defines whether the property overrides the value configured in the provider of a global path
+	// parameter. These properties are also parent properties so they are not posted to the API either.
+	IsGlobalPathParameter bool
+	// IsLabels defines whether the property holds the resource's labels, in which case the provider's default labels are
+	// merged into it
+	IsLabels bool
+	// AllowedValues contains the values supported by the property, any value is supported if empty
+	AllowedValues []string
 	// Default field is only for informative purposes to know what the openapi spec for the property stated the default value is
 	// As per the openapi spec default attributes, the value is expected to be computed by the API
 	Default interface{}
@@ -105,6 +119,10 @@ func (s *SpecSchemaDefinitionProperty) isArrayProperty() bool {
 	return s.Type == TypeList
 }
 
+func (s *SpecSchemaDefinitionProperty) isMapProperty() bool {
+	return s.Type == TypeMap
+}
+
 func (s *SpecSchemaDefinitionProperty) shouldIgnoreOrder() bool {
 	return s.Type == TypeList && s.IgnoreItemsOrder
 }
@@ -113,6 +131,24 @@ func (s *SpecSchemaDefinitionProperty) isArrayOfObjectsProperty() bool {
 	return s.Type == TypeList && s.ArrayItemsType == TypeObject
 }
 
+// isLabelsCompatible returns true if the property can hold labels, that is, the property is a map or a list of objects with
+// 'key' and 'value' string properties
+func (s *SpecSchemaDefinitionProperty) isLabelsCompatible() bool {
+	if s.isMapProperty() {
+		return true
+	}
+	if !s.isArrayOfObjectsProperty() || s.SpecSchemaDefinition == nil {
+		return false
+	}
+	for _, propertyName := range []string{labelsKeyPropertyName, labelsValuePropertyName} {
+		property, err := s.SpecSchemaDefinition.getProperty(propertyName)
+		if err != nil || property.Type != TypeString {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *SpecSchemaDefinitionProperty) isReadOnly() bool {
 	return s.ReadOnly
 }
@@ -168,6 +204,8 @@ func (s *SpecSchemaDefinitionProperty) terraformType() (schema.ValueType, error)
 		return schema.TypeBool, nil
 	case TypeObject, TypeList:
 		return schema.TypeList, nil
+	case TypeMap:
+		return schema.TypeMap, nil
 	}
 	return schema.TypeInvalid, fmt.Errorf("non supported type %s", s.Type)
 }
@@ -258,6 +296,9 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 			}
 			terraformSchema.Elem = objectSchema
 		}
+
+	case TypeMap:
+		terraformSchema.Elem = &schema.Schema{Type: schema.TypeString}
 	}
 
 	// A computed property could be one of:
@@ -281,7 +322,7 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	}
 
 	// ValidateFunc is not yet supported on lists or sets
-	if !s.isArrayProperty() && !s.isObjectProperty() {
+	if !s.isArrayProperty() && !s.isObjectProperty() && !s.isMapProperty() {
 		terraformSchema.ValidateDiagFunc = s.validateDiagFunc()
 	}
 
@@ -290,7 +331,7 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	// thrown at runtime: Default must be nil if computed
 	if !s.isComputed() {
 		// Terraform does not allow defaults to be set on type list properties, an error (Default is not valid for lists) would be thrown otherwise (https://www.terraform.io/docs/extend/schemas/schema-behaviors.html#default)
-		if !s.isArrayProperty() {
+		if !s.isArrayProperty() && !s.isMapProperty() {
 			terraformSchema.Default = s.Default
 		}
 	}
@@ -319,10 +360,22 @@ func (s *SpecSchemaDefinitionProperty) validateFunc() schema.SchemaValidateFunc
 		if s.Required && s.ReadOnly {
 			errors = append(errors, fmt.Errorf("property '%s' is configured as required and can not be configured as computed too", s.Name))
 		}
+		if value, ok := v.(string); ok && len(s.AllowedValues) > 0 && !s.isAllowedValue(value) {
+			errors = append(errors, fmt.Errorf("property '%s' value '%s' is not valid, please make sure the value is one of %+v", s.Name, value, s.AllowedValues))
+		}
 		return
 	}
 }
 
+func (s *SpecSchemaDefinitionProperty) isAllowedValue(value string) bool {
+	for _, allowedValue := range s.AllowedValues {
+		if value == allowedValue {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SpecSchemaDefinitionProperty) equal(item1, item2 interface{}) bool {
 	return s.equalItems(s.Type, item1, item2)
 }
@@ -386,6 +439,11 @@ func (s *SpecSchemaDefinitionProperty) equalItems(itemsType schemaDefinitionProp
 			}
 		}
 		return true
+	case TypeMap:
+		if !s.validateValueType(item1, reflect.Map) || !s.validateValueType(item2, reflect.Map) {
+			return false
+		}
+		return reflect.DeepEqual(item1, item2)
 	default:
 		return false
 	}
@@ -1660,6 +1660,23 @@ func TestValidateFunc(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a schemaDefinitionProperty that has allowed values", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "region", Type: TypeString, AllowedValues: []string{"rst", "dub"}}
+		Convey("When validateFunc is called with an allowed value", func() {
+			_, err := s.validateFunc()("dub", "")
+			Convey("Then no errors should be returned", func() {
+				So(err, ShouldBeEmpty)
+			})
+		})
+		Convey("When validateFunc is called with a value that is not allowed", func() {
+			_, err := s.validateFunc()("sfo", "")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeEmpty)
+				So(err[0].Error(), ShouldEqual, "property 'region' value 'sfo' is not valid, please make sure the value is one of [rst dub]")
+			})
+		})
+	})
 }
 
 func TestEqualItems(t *testing.T) {
@@ -2075,3 +2092,21 @@ func Test_shouldUseLegacyTerraformSDKBlockApproachForComplexObjects(t *testing.T
 		})
 	})
 }
+
+func TestTerraformSchemaMap(t *testing.T) {
+	s := &SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap, Default: map[string]interface{}{"team": "network"}}
+	terraformSchema, err := s.terraformSchema()
+	assert.NoError(t, err)
+	assert.Equal(t, schema.TypeMap, terraformSchema.Type)
+	assert.Equal(t, &schema.Schema{Type: schema.TypeString}, terraformSchema.Elem)
+	assert.True(t, terraformSchema.Optional)
+	assert.Nil(t, terraformSchema.Default)
+	assert.Nil(t, terraformSchema.ValidateDiagFunc)
+}
+
+func TestEqualItemsMap(t *testing.T) {
+	s := &SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap}
+	assert.True(t, s.equal(map[string]interface{}{"team": "network"}, map[string]interface{}{"team": "network"}))
+	assert.False(t, s.equal(map[string]interface{}{"team": "network"}, map[string]interface{}{"team": "storage"}))
+	assert.False(t, s.equal(map[string]interface{}{"team": "network"}, "team"))
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+const basicUsernamePropertySuffix = "username"
+const basicPasswordPropertySuffix = "password" // #nosec G101
+
+type specBasicSecurityDefinition struct {
+	name string
+}
+
+// newBasicSecurityDefinition constructs a SpecSecurityDefinition for the HTTP Basic authentication. The secDefName value
+// is the identifier of the security definition.
+func newBasicSecurityDefinition(secDefName string) specBasicSecurityDefinition {
+	return specBasicSecurityDefinition{secDefName}
+}
+
+func (s specBasicSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specBasicSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionBasic
+}
+
+func (s specBasicSecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specBasicSecurityDefinition) getAPIKey() specAPIKey {
+	return newAPIKeyHeader(authorizationHeader)
+}
+
+func (s specBasicSecurityDefinition) buildValue(value string) string {
+	return value
+}
+
+func (s specBasicSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specBasicSecurityDefinition missing mandatory security definition name")
+	}
+	return nil
+}
+
+// getCredentialProperties returns the username and password properties, named after the security definition
+// (e,g: basic_auth_username and basic_auth_password)
+func (s specBasicSecurityDefinition) getCredentialProperties() []SpecSecurityDefinitionProperty {
+	return []SpecSecurityDefinitionProperty{
+		{Name: s.getUsernamePropertyName()},
+		{Name: s.getPasswordPropertyName(), Sensitive: true},
+	}
+}
+
+func (s specBasicSecurityDefinition) createAuthenticator(values map[string]string) specAPIKeyAuthenticator {
+	return newBasicAuthenticator(values[s.getUsernamePropertyName()], values[s.getPasswordPropertyName()], s.GetTerraformConfigurationName())
+}
+
+func (s specBasicSecurityDefinition) getUsernamePropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), basicUsernamePropertySuffix)
+}
+
+func (s specBasicSecurityDefinition) getPasswordPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), basicPasswordPropertySuffix)
+}
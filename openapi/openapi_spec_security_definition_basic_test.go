@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewBasicSecurityDefinition(t *testing.T) {
+	Convey("Given a security definition name", t, func() {
+		name := "basic_auth"
+		Convey("When newBasicSecurityDefinition method is called", func() {
+			basicSecurityDefinition := newBasicSecurityDefinition(name)
+			Convey("Then the security definition should comply with SpecSecurityDefinition and specCredentialsSecurityDefinition interfaces", func() {
+				var _ SpecSecurityDefinition = basicSecurityDefinition
+				var _ specCredentialsSecurityDefinition = basicSecurityDefinition
+			})
+			Convey("And the type should be securityDefinitionBasic", func() {
+				So(basicSecurityDefinition.getType(), ShouldEqual, securityDefinitionBasic)
+			})
+			Convey("And the API key should be the Authorization header", func() {
+				So(basicSecurityDefinition.getAPIKey(), ShouldResemble, newAPIKeyHeader(authorizationHeader))
+			})
+			Convey("And the validation should pass", func() {
+				So(basicSecurityDefinition.validate(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given an empty security definition name", t, func() {
+		Convey("When validate method is called", func() {
+			err := newBasicSecurityDefinition("").validate()
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "specBasicSecurityDefinition missing mandatory security definition name")
+			})
+		})
+	})
+}
+
+func TestBasicSecurityDefinitionGetCredentialProperties(t *testing.T) {
+	Convey("Given a BasicSecurityDefinition with a NON compliant name", t, func() {
+		basicSecurityDefinition := newBasicSecurityDefinition("basicAuth")
+		Convey("When GetSecurityDefinitionProperties is called", func() {
+			properties := GetSecurityDefinitionProperties(basicSecurityDefinition)
+			Convey("Then the properties returned should be the username and the sensitive password named after the terraform compliant name", func() {
+				So(properties, ShouldResemble, []SpecSecurityDefinitionProperty{
+					{Name: "basic_auth_username"},
+					{Name: "basic_auth_password", Sensitive: true},
+				})
+			})
+		})
+		Convey("When createAuthenticator is called with the values of the credential properties", func() {
+			authenticator := basicSecurityDefinition.createAuthenticator(map[string]string{"basic_auth_username": "user", "basic_auth_password": "secret"})
+			Convey("Then the authenticator returned should be configured with the username and password", func() {
+				So(authenticator, ShouldHaveSameTypeAs, basicAuthenticator{})
+				So(authenticator.getContext(), ShouldResemble, basicCredentials{username: "user", password: "secret"})
+				So(authenticator.validate(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+const oauth2ClientIDPropertySuffix = "client_id"
+const oauth2ClientSecretPropertySuffix = "client_secret" // #nosec G101
+
+type specOAuth2ClientCredentialsSecurityDefinition struct {
+	name     string
+	tokenURL string
+}
+
+// newOAuth2ClientCredentialsSecurityDefinition constructs a SpecSecurityDefinition for the OAuth2 client credentials flow
+// (named 'application' in the OpenAPI 2.0 specification). The secDefName value is the identifier of the security definition,
+// and the tokenURL is the URL where the access tokens are requested from.
+func newOAuth2ClientCredentialsSecurityDefinition(secDefName, tokenURL string) specOAuth2ClientCredentialsSecurityDefinition {
+	return specOAuth2ClientCredentialsSecurityDefinition{secDefName, tokenURL}
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionOAuth2ClientCredentials
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getAPIKey() specAPIKey {
+	return newAPIKeyHeader(authorizationHeader)
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) buildValue(value string) string {
+	return value
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name")
+	}
+	if s.tokenURL == "" {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition '%s' missing mandatory token URL", s.name)
+	}
+	if !isURL(s.tokenURL) {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition '%s' token URL must be a valid URL", s.name)
+	}
+	return nil
+}
+
+// getCredentialProperties returns the client ID and client secret properties, named after the security definition
+// (e,g: oauth2_auth_client_id and oauth2_auth_client_secret)
+func (s specOAuth2ClientCredentialsSecurityDefinition) getCredentialProperties() []SpecSecurityDefinitionProperty {
+	return []SpecSecurityDefinitionProperty{
+		{Name: s.getClientIDPropertyName()},
+		{Name: s.getClientSecretPropertyName(), Sensitive: true},
+	}
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) createAuthenticator(values map[string]string) specAPIKeyAuthenticator {
+	return newOAuth2ClientCredentialsAuthenticator(values[s.getClientIDPropertyName()], values[s.getClientSecretPropertyName()], s.tokenURL, s.GetTerraformConfigurationName())
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientIDPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), oauth2ClientIDPropertySuffix)
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientSecretPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), oauth2ClientSecretPropertySuffix)
+}
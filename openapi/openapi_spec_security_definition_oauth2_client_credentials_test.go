@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewOAuth2ClientCredentialsSecurityDefinition(t *testing.T) {
+	Convey("Given a name and a token URL", t, func() {
+		name := "oauth2_auth"
+		tokenURL := "https://api.iam.com/oauth2/token"
+		Convey("When newOAuth2ClientCredentialsSecurityDefinition method is called", func() {
+			oauth2SecurityDefinition := newOAuth2ClientCredentialsSecurityDefinition(name, tokenURL)
+			Convey("Then the security definition should comply with SpecSecurityDefinition and specCredentialsSecurityDefinition interfaces", func() {
+				var _ SpecSecurityDefinition = oauth2SecurityDefinition
+				var _ specCredentialsSecurityDefinition = oauth2SecurityDefinition
+			})
+			Convey("And the type should be securityDefinitionOAuth2ClientCredentials", func() {
+				So(oauth2SecurityDefinition.getType(), ShouldEqual, securityDefinitionOAuth2ClientCredentials)
+			})
+			Convey("And the API key should be the Authorization header", func() {
+				So(oauth2SecurityDefinition.getAPIKey(), ShouldResemble, newAPIKeyHeader(authorizationHeader))
+			})
+		})
+	})
+}
+
+func TestOAuth2ClientCredentialsSecurityDefinitionGetCredentialProperties(t *testing.T) {
+	Convey("Given an OAuth2ClientCredentialsSecurityDefinition with a NON compliant name", t, func() {
+		oauth2SecurityDefinition := newOAuth2ClientCredentialsSecurityDefinition("oauth2Auth", "https://api.iam.com/oauth2/token")
+		Convey("When GetSecurityDefinitionProperties is called", func() {
+			properties := GetSecurityDefinitionProperties(oauth2SecurityDefinition)
+			Convey("Then the properties returned should be the client ID and the sensitive client secret named after the terraform compliant name", func() {
+				So(properties, ShouldResemble, []SpecSecurityDefinitionProperty{
+					{Name: "oauth2_auth_client_id"},
+					{Name: "oauth2_auth_client_secret", Sensitive: true},
+				})
+			})
+		})
+		Convey("When createAuthenticator is called with the values of the credential properties", func() {
+			authenticator := oauth2SecurityDefinition.createAuthenticator(map[string]string{"oauth2_auth_client_id": "clientID", "oauth2_auth_client_secret": "clientSecret"})
+			Convey("Then the authenticator returned should be configured with the client credentials and the token URL", func() {
+				So(authenticator, ShouldHaveSameTypeAs, oauth2ClientCredentialsAuthenticator{})
+				So(authenticator.getContext(), ShouldResemble, oauth2ClientCredentials{clientID: "clientID", clientSecret: "clientSecret"})
+				So(authenticator.(oauth2ClientCredentialsAuthenticator).tokenURL, ShouldEqual, "https://api.iam.com/oauth2/token")
+				So(authenticator.validate(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestOAuth2ClientCredentialsSecurityDefinitionValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		secDefName    string
+		tokenURL      string
+		expectedError string
+	}{
+		{name: "valid security definition", secDefName: "oauth2_auth", tokenURL: "https://api.iam.com/oauth2/token", expectedError: ""},
+		{name: "missing name", secDefName: "", tokenURL: "https://api.iam.com/oauth2/token", expectedError: "specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name"},
+		{name: "missing token URL", secDefName: "oauth2_auth", tokenURL: "", expectedError: "specOAuth2ClientCredentialsSecurityDefinition 'oauth2_auth' missing mandatory token URL"},
+		{name: "invalid token URL", secDefName: "oauth2_auth", tokenURL: "not a url", expectedError: "specOAuth2ClientCredentialsSecurityDefinition 'oauth2_auth' token URL must be a valid URL"},
+	}
+	for _, tc := range testCases {
+		err := newOAuth2ClientCredentialsSecurityDefinition(tc.secDefName, tc.tokenURL).validate()
+		if tc.expectedError == "" && err != nil {
+			t.Errorf("%s: unexpected error %s", tc.name, err)
+		}
+		if tc.expectedError != "" && (err == nil || err.Error() != tc.expectedError) {
+			t.Errorf("%s: expected error '%s' but got '%v'", tc.name, tc.expectedError, err)
+		}
+	}
+}
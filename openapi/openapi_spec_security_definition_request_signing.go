@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+const requestSigningKeyIDPropertySuffix = "key_id"
+const requestSigningSecretKeyPropertySuffix = "secret_key"       // #nosec G101
+const requestSigningSessionTokenPropertySuffix = "session_token" // #nosec G101
+
+type requestSigningMethod string
+
+const (
+	requestSigningHMAC     requestSigningMethod = "hmac"
+	requestSigningAWSSigV4 requestSigningMethod = "aws-sigv4"
+)
+
+const (
+	hmacSHA256Algorithm = "hmac-sha256"
+	hmacSHA512Algorithm = "hmac-sha512"
+)
+
+type specRequestSigningSecurityDefinition struct {
+	name          string
+	signingMethod requestSigningMethod
+	// headerName is the header where the HMAC signature is sent
+	headerName string
+	// algorithm is the HMAC algorithm used to sign the requests
+	algorithm string
+	// region and service are part of the AWS SigV4 credential scope
+	region  string
+	service string
+}
+
+// newHMACRequestSigningSecurityDefinition constructs a SpecSecurityDefinition that signs the requests with an HMAC computed
+// over the canonical request. The secDefName value is the identifier of the security definition, the headerName is the
+// header where the signature will be sent (Authorization if empty) and the algorithm is the HMAC algorithm (hmac-sha256 if
+// empty).
+func newHMACRequestSigningSecurityDefinition(secDefName, headerName, algorithm string) specRequestSigningSecurityDefinition {
+	if headerName == "" {
+		headerName = authorizationHeader
+	}
+	if algorithm == "" {
+		algorithm = hmacSHA256Algorithm
+	}
+	return specRequestSigningSecurityDefinition{name: secDefName, signingMethod: requestSigningHMAC, headerName: headerName, algorithm: algorithm}
+}
+
+// newAWSSigV4RequestSigningSecurityDefinition constructs a SpecSecurityDefinition that signs the requests following the
+// AWS Signature Version 4 process for the given region and service
+func newAWSSigV4RequestSigningSecurityDefinition(secDefName, region, service string) specRequestSigningSecurityDefinition {
+	return specRequestSigningSecurityDefinition{name: secDefName, signingMethod: requestSigningAWSSigV4, headerName: authorizationHeader, region: region, service: service}
+}
+
+func (s specRequestSigningSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specRequestSigningSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionRequestSigning
+}
+
+func (s specRequestSigningSecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specRequestSigningSecurityDefinition) getAPIKey() specAPIKey {
+	return newAPIKeyHeader(s.headerName)
+}
+
+func (s specRequestSigningSecurityDefinition) buildValue(value string) string {
+	return value
+}
+
+func (s specRequestSigningSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specRequestSigningSecurityDefinition missing mandatory security definition name")
+	}
+	switch s.signingMethod {
+	case requestSigningHMAC:
+		if s.algorithm != hmacSHA256Algorithm && s.algorithm != hmacSHA512Algorithm {
+			return fmt.Errorf("specRequestSigningSecurityDefinition '%s' HMAC algorithm '%s' not supported, only '%s' and '%s' values are valid", s.name, s.algorithm, hmacSHA256Algorithm, hmacSHA512Algorithm)
+		}
+	case requestSigningAWSSigV4:
+		if s.region == "" || s.service == "" {
+			return fmt.Errorf("specRequestSigningSecurityDefinition '%s' missing mandatory AWS SigV4 region and service, please make sure the '%s' and '%s' extensions are configured", s.name, extTfRequestSigningAWSRegion, extTfRequestSigningAWSService)
+		}
+	default:
+		return fmt.Errorf("specRequestSigningSecurityDefinition '%s' request signing method '%s' not supported, only '%s' and '%s' values are valid", s.name, s.signingMethod, requestSigningHMAC, requestSigningAWSSigV4)
+	}
+	return nil
+}
+
+// getCredentialProperties returns the key ID and secret key properties, named after the security definition
+// (e,g: hmac_auth_key_id and hmac_auth_secret_key). AWS SigV4 security definitions also have an optional session token
+// property for temporary credentials (e,g: sigv4_auth_session_token).
+func (s specRequestSigningSecurityDefinition) getCredentialProperties() []SpecSecurityDefinitionProperty {
+	properties := []SpecSecurityDefinitionProperty{
+		{Name: s.getKeyIDPropertyName()},
+		{Name: s.getSecretKeyPropertyName(), Sensitive: true},
+	}
+	if s.signingMethod == requestSigningAWSSigV4 {
+		properties = append(properties, SpecSecurityDefinitionProperty{Name: s.getSessionTokenPropertyName(), Sensitive: true, Optional: true})
+	}
+	return properties
+}
+
+func (s specRequestSigningSecurityDefinition) createAuthenticator(values map[string]string) specAPIKeyAuthenticator {
+	credentials := requestSigningCredentials{keyID: values[s.getKeyIDPropertyName()], secretKey: values[s.getSecretKeyPropertyName()], sessionToken: values[s.getSessionTokenPropertyName()]}
+	if s.signingMethod == requestSigningAWSSigV4 {
+		return newAWSSigV4Authenticator(credentials, s.region, s.service, s.GetTerraformConfigurationName())
+	}
+	return newHMACAuthenticator(credentials, s.headerName, s.algorithm, s.GetTerraformConfigurationName())
+}
+
+func (s specRequestSigningSecurityDefinition) getKeyIDPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), requestSigningKeyIDPropertySuffix)
+}
+
+func (s specRequestSigningSecurityDefinition) getSecretKeyPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), requestSigningSecretKeyPropertySuffix)
+}
+
+func (s specRequestSigningSecurityDefinition) getSessionTokenPropertyName() string {
+	return fmt.Sprintf("%s_%s", s.GetTerraformConfigurationName(), requestSigningSessionTokenPropertySuffix)
+}
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewHMACRequestSigningSecurityDefinition(t *testing.T) {
+	Convey("Given a name and no header name nor algorithm", t, func() {
+		name := "hmac_auth"
+		Convey("When newHMACRequestSigningSecurityDefinition method is called", func() {
+			securityDefinition := newHMACRequestSigningSecurityDefinition(name, "", "")
+			Convey("Then the security definition should comply with SpecSecurityDefinition and specCredentialsSecurityDefinition interfaces", func() {
+				var _ SpecSecurityDefinition = securityDefinition
+				var _ specCredentialsSecurityDefinition = securityDefinition
+			})
+			Convey("And the type should be securityDefinitionRequestSigning", func() {
+				So(securityDefinition.getType(), ShouldEqual, securityDefinitionRequestSigning)
+			})
+			Convey("And the defaults should be the Authorization header and the hmac-sha256 algorithm", func() {
+				So(securityDefinition.getAPIKey(), ShouldResemble, newAPIKeyHeader(authorizationHeader))
+				So(securityDefinition.algorithm, ShouldEqual, hmacSHA256Algorithm)
+			})
+		})
+	})
+}
+
+func TestRequestSigningSecurityDefinitionGetCredentialProperties(t *testing.T) {
+	Convey("Given a HMAC RequestSigningSecurityDefinition with a NON compliant name", t, func() {
+		securityDefinition := newHMACRequestSigningSecurityDefinition("hmacAuth", "X-Signature", hmacSHA512Algorithm)
+		Convey("When GetSecurityDefinitionProperties is called", func() {
+			properties := GetSecurityDefinitionProperties(securityDefinition)
+			Convey("Then the properties returned should be the key ID and the sensitive secret key named after the terraform compliant name", func() {
+				So(properties, ShouldResemble, []SpecSecurityDefinitionProperty{
+					{Name: "hmac_auth_key_id"},
+					{Name: "hmac_auth_secret_key", Sensitive: true},
+				})
+			})
+		})
+		Convey("When createAuthenticator is called with the values of the credential properties", func() {
+			authenticator := securityDefinition.createAuthenticator(map[string]string{"hmac_auth_key_id": "keyID", "hmac_auth_secret_key": "secret"})
+			Convey("Then the authenticator returned should be a HMAC request signer configured with the credentials, header and algorithm", func() {
+				So(authenticator, ShouldHaveSameTypeAs, hmacAuthenticator{})
+				So(authenticator, ShouldImplement, (*specRequestSignerAuthenticator)(nil))
+				So(authenticator.getContext(), ShouldResemble, requestSigningCredentials{keyID: "keyID", secretKey: "secret"})
+				So(authenticator.(hmacAuthenticator).headerName, ShouldEqual, "X-Signature")
+				So(authenticator.(hmacAuthenticator).algorithm, ShouldEqual, hmacSHA512Algorithm)
+			})
+		})
+	})
+	Convey("Given an AWS SigV4 RequestSigningSecurityDefinition", t, func() {
+		securityDefinition := newAWSSigV4RequestSigningSecurityDefinition("sigv4_auth", "us-east-1", "execute-api")
+		Convey("When GetSecurityDefinitionProperties is called", func() {
+			properties := GetSecurityDefinitionProperties(securityDefinition)
+			Convey("Then the properties returned should include the optional sensitive session token", func() {
+				So(properties, ShouldResemble, []SpecSecurityDefinitionProperty{
+					{Name: "sigv4_auth_key_id"},
+					{Name: "sigv4_auth_secret_key", Sensitive: true},
+					{Name: "sigv4_auth_session_token", Sensitive: true, Optional: true},
+				})
+			})
+		})
+		Convey("When createAuthenticator is called with the values of the credential properties", func() {
+			authenticator := securityDefinition.createAuthenticator(map[string]string{"sigv4_auth_key_id": "keyID", "sigv4_auth_secret_key": "secret", "sigv4_auth_session_token": "token"})
+			Convey("Then the authenticator returned should be an AWS SigV4 request signer configured with the credentials, region and service", func() {
+				So(authenticator, ShouldHaveSameTypeAs, awsSigV4Authenticator{})
+				So(authenticator, ShouldImplement, (*specRequestSignerAuthenticator)(nil))
+				So(authenticator.getContext(), ShouldResemble, requestSigningCredentials{keyID: "keyID", secretKey: "secret", sessionToken: "token"})
+				So(authenticator.(awsSigV4Authenticator).region, ShouldEqual, "us-east-1")
+				So(authenticator.(awsSigV4Authenticator).service, ShouldEqual, "execute-api")
+			})
+		})
+	})
+}
+
+func TestRequestSigningSecurityDefinitionValidate(t *testing.T) {
+	unsupportedMethod := newHMACRequestSigningSecurityDefinition("signing_auth", "", "")
+	unsupportedMethod.signingMethod = "rsa"
+	testCases := []struct {
+		name               string
+		securityDefinition specRequestSigningSecurityDefinition
+		expectedError      string
+	}{
+		{name: "valid HMAC security definition", securityDefinition: newHMACRequestSigningSecurityDefinition("hmac_auth", "", hmacSHA512Algorithm), expectedError: ""},
+		{name: "valid AWS SigV4 security definition", securityDefinition: newAWSSigV4RequestSigningSecurityDefinition("sigv4_auth", "us-east-1", "execute-api"), expectedError: ""},
+		{name: "missing name", securityDefinition: newHMACRequestSigningSecurityDefinition("", "", ""), expectedError: "specRequestSigningSecurityDefinition missing mandatory security definition name"},
+		{name: "unsupported HMAC algorithm", securityDefinition: newHMACRequestSigningSecurityDefinition("hmac_auth", "", "hmac-md5"), expectedError: "specRequestSigningSecurityDefinition 'hmac_auth' HMAC algorithm 'hmac-md5' not supported, only 'hmac-sha256' and 'hmac-sha512' values are valid"},
+		{name: "AWS SigV4 missing service", securityDefinition: newAWSSigV4RequestSigningSecurityDefinition("sigv4_auth", "us-east-1", ""), expectedError: "specRequestSigningSecurityDefinition 'sigv4_auth' missing mandatory AWS SigV4 region and service, please make sure the 'x-terraform-request-signing-aws-region' and 'x-terraform-request-signing-aws-service' extensions are configured"},
+		{name: "unsupported signing method", securityDefinition: unsupportedMethod, expectedError: "specRequestSigningSecurityDefinition 'signing_auth' request signing method 'rsa' not supported, only 'hmac' and 'aws-sigv4' values are valid"},
+	}
+	for _, tc := range testCases {
+		err := tc.securityDefinition.validate()
+		if tc.expectedError == "" && err != nil {
+			t.Errorf("%s: unexpected error %s", tc.name, err)
+		}
+		if tc.expectedError != "" && (err == nil || err.Error() != tc.expectedError) {
+			t.Errorf("%s: expected error '%s' but got '%v'", tc.name, tc.expectedError, err)
+		}
+	}
+}
@@ -15,8 +15,11 @@ func (s SpecSecurityDefinitions) findSecurityDefinitionFor(securitySchemeName st
 type securityDefinitionType string
 
 const (
-	securityDefinitionAPIKey             securityDefinitionType = "apiKey"
-	securityDefinitionAPIKeyRefreshToken securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionAPIKey                  securityDefinitionType = "apiKey"
+	securityDefinitionAPIKeyRefreshToken      securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionOAuth2ClientCredentials securityDefinitionType = "oauth2ClientCredentials"
+	securityDefinitionBasic                   securityDefinitionType = "basic"
+	securityDefinitionRequestSigning          securityDefinitionType = "requestSigning"
 )
 
 // SpecSecurityDefinition defines the behaviour expected for security definition implementations. This interface creates
@@ -40,3 +43,33 @@ type SpecSecurityDefinition interface {
 	// including security definition name and any extra validation on the specAPIKey
 	validate() error
 }
+
+// specCredentialsSecurityDefinition defines the behaviour for security definitions that are configured with multiple
+// provider properties (e,g: OAuth2 client ID and secret) rather than a single property named after the security definition
+type specCredentialsSecurityDefinition interface {
+	SpecSecurityDefinition
+	// getCredentialProperties returns the provider properties used to configure the security definition
+	getCredentialProperties() []SpecSecurityDefinitionProperty
+	// createAuthenticator returns the authenticator configured with the given values keyed by provider property name
+	createAuthenticator(values map[string]string) specAPIKeyAuthenticator
+}
+
+// SpecSecurityDefinitionProperty describes a provider property used to configure a security definition
+type SpecSecurityDefinitionProperty struct {
+	// Name is the terraform compliant name of the provider property
+	Name string
+	// Sensitive is true if the property holds a secret (e,g: client secret)
+	Sensitive bool
+	// Optional is true if the property is not required even if the security definition is (e,g: AWS session token)
+	Optional bool
+}
+
+// GetSecurityDefinitionProperties returns the provider properties used to configure the given security definition. Most
+// security definitions are configured with a single sensitive property (e,g: API key) named after the security definition,
+// whereas others (e,g: OAuth2 client credentials) are configured with multiple properties
+func GetSecurityDefinitionProperties(secDef SpecSecurityDefinition) []SpecSecurityDefinitionProperty {
+	if credentialsSecDef, ok := secDef.(specCredentialsSecurityDefinition); ok {
+		return credentialsSecDef.getCredentialProperties()
+	}
+	return []SpecSecurityDefinitionProperty{{Name: secDef.GetTerraformConfigurationName(), Sensitive: true}}
+}
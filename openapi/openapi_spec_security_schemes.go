@@ -8,8 +8,14 @@ type SpecSecuritySchemes []SpecSecurityScheme
 func createSecuritySchemes(securitySchemes []map[string][]string) SpecSecuritySchemes {
 	schemes := SpecSecuritySchemes{}
 	for _, securityScheme := range securitySchemes {
-		for securitySchemeName := range securityScheme {
-			schemes = append(schemes, SpecSecurityScheme{Name: securitySchemeName})
+		for securitySchemeName, scopes := range securityScheme {
+			scheme := SpecSecurityScheme{Name: securitySchemeName}
+			for _, scope := range scopes {
+				if scope != "" {
+					scheme.Scopes = append(scheme.Scopes, scope)
+				}
+			}
+			schemes = append(schemes, scheme)
 		}
 		// Choosing the first set of security schemes as defined by the service provider. The order defines the priority
 		// by which security schemes are selected, in this case the first set. Hence, disregarding the rest of security
@@ -32,6 +38,8 @@ func (s SpecSecuritySchemes) securitySchemeExists(secDef SpecSecurityDefinition)
 // and the scheme that will be used by the OpenAPI Terraform provider when making API calls to the backend
 type SpecSecurityScheme struct {
 	Name string
+	// Scopes contains the scopes required by the security requirement (only applicable to OAuth2 security definitions)
+	Scopes []string
 }
 
 // GetTerraformConfigurationName returns the scheme name converted to a terraform compliant name if needed following the snake_case naming convention
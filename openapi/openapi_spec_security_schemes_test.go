@@ -102,4 +102,19 @@ func TestCreateSecuritySchemes(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a map of securitySchemes with scopes", t, func() {
+		securitySchemes := []map[string][]string{
+			{
+				"oauth2_auth": {"read", "write"},
+				"apikey_auth": {""},
+			},
+		}
+		Convey("When createSecuritySchemes method is called with the securitySchemes", func() {
+			specSecuritySchemes := createSecuritySchemes(securitySchemes)
+			Convey("Then the specSecuritySchemes should contain the non empty scopes", func() {
+				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "oauth2_auth", Scopes: []string{"read", "write"}})
+				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "apikey_auth"})
+			})
+		})
+	})
 }
@@ -15,6 +15,8 @@ type specStubBackendConfiguration struct {
 	hostErr          error
 	defaultRegionErr error
 	hostByRegionErr  error
+	// regionHosts contains the hosts returned for the regions, the host is built from the host format otherwise
+	regionHosts map[string]specRegionHost
 
 	getHTTPSchemeBehavior func() (string, error)
 }
@@ -62,6 +64,17 @@ func (s *specStubBackendConfiguration) getHostByRegion(region string) (string, e
 	return fmt.Sprintf(s.host, region), nil
 }
 
+func (s *specStubBackendConfiguration) getRegionHost(region string) (specRegionHost, error) {
+	if regionHost, exists := s.regionHosts[region]; exists {
+		return regionHost, nil
+	}
+	host, err := s.getHostByRegion(region)
+	if err != nil {
+		return specRegionHost{}, err
+	}
+	return specRegionHost{Host: host}, nil
+}
+
 func (s *specStubBackendConfiguration) GetDefaultRegion(regions []string) (string, error) {
 	if s.defaultRegionErr != nil {
 		return "", s.defaultRegionErr
@@ -4,6 +4,9 @@ package openapi
 type specStubResource struct {
 	name                    string
 	host                    string
+	region                  string
+	basePath                string
+	scheme                  string
 	path                    string
 	shouldIgnore            bool
 	schemaDefinition        *SpecSchemaDefinition
@@ -92,3 +95,15 @@ func (s *specStubResource) GetParentResourceInfo() *ParentResourceInfo {
 	}
 	return nil
 }
+
+func (s *specStubResource) getRegion() string {
+	return s.region
+}
+
+func (s *specStubResource) getBasePath() string {
+	return s.basePath
+}
+
+func (s *specStubResource) getScheme() string {
+	return s.scheme
+}
@@ -1,9 +1,11 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapiutils"
@@ -12,6 +14,8 @@ import (
 
 const extTfProviderMultiRegionFQDN = "x-terraform-provider-multiregion-fqdn"
 const extTfProviderRegions = "x-terraform-provider-regions"
+const extTfProviderRegionHosts = "x-terraform-provider-region-hosts"
+const extTfProviderRegionsEndpoint = "x-terraform-provider-regions-endpoint"
 
 type specV2BackendConfiguration struct {
 	openAPIDocumentURL string
@@ -41,24 +45,41 @@ func (o specV2BackendConfiguration) getHost() (string, error) {
 }
 
 func (o specV2BackendConfiguration) getHostByRegion(region string) (string, error) {
+	regionHost, err := o.getRegionHost(region)
+	if err != nil {
+		return "", err
+	}
+	return regionHost.Host, nil
+}
+
+// getRegionHost returns the host of the given region defined in the 'x-terraform-provider-region-hosts' extension. If
+// the region is not defined there, the host is built from the 'x-terraform-provider-multiregion-fqdn' extension value.
+func (o specV2BackendConfiguration) getRegionHost(region string) (specRegionHost, error) {
 	if region == "" {
-		return "", fmt.Errorf("can't get host by region, missing region value")
+		return specRegionHost{}, fmt.Errorf("can't get host by region, missing region value")
 	}
 	isMultiRegion, host, allowedRegions, err := o.IsMultiRegion()
 	if err != nil {
-		return "", err
+		return specRegionHost{}, err
 	}
 	if !isMultiRegion {
-		return "", fmt.Errorf("missing '%s' extension or value provided not matching multiregion host format", extTfProviderMultiRegionFQDN)
+		return specRegionHost{}, fmt.Errorf("missing '%s' extension or value provided not matching multiregion host format", extTfProviderMultiRegionFQDN)
 	}
 	if err := o.validateRegion(region, allowedRegions); err != nil {
-		return "", err
+		return specRegionHost{}, err
+	}
+	regionHosts, err := o.getRegionHosts()
+	if err != nil {
+		return specRegionHost{}, err
+	}
+	if regionHost, exists := regionHosts[region]; exists {
+		return regionHost, nil
 	}
 	overrideHost, err := openapiutils.GetMultiRegionHost(host, region)
 	if err != nil {
-		return "", err
+		return specRegionHost{}, err
 	}
-	return overrideHost, nil
+	return specRegionHost{Host: overrideHost}, nil
 }
 
 func (o specV2BackendConfiguration) validateRegion(region string, allowedRegions []string) error {
@@ -77,19 +98,33 @@ func (o specV2BackendConfiguration) GetDefaultRegion(regions []string) (string,
 	return regions[0], nil
 }
 
+// IsMultiRegion returns true if the document defines the 'x-terraform-provider-multiregion-fqdn' and/or the
+// 'x-terraform-provider-region-hosts' extensions, along with the multi-region host (empty if only the region hosts are
+// defined) and the regions supported
 func (o specV2BackendConfiguration) IsMultiRegion() (bool, string, []string, error) {
 	isHostMultiRegion, host, err := o.isHostMultiRegion()
 	if err != nil {
 		return false, "", nil, err
 	}
-	if isHostMultiRegion {
-		regions, err := o.getProviderRegions()
-		if err != nil {
-			return false, "", nil, err
+	regionHosts, err := o.getRegionHosts()
+	if err != nil {
+		return false, "", nil, err
+	}
+	if !isHostMultiRegion && len(regionHosts) == 0 {
+		return false, "", nil, nil
+	}
+	regions, err := o.getProviderRegions(regionHosts)
+	if err != nil {
+		return false, "", nil, err
+	}
+	if !isHostMultiRegion {
+		for _, region := range regions {
+			if _, exists := regionHosts[region]; !exists {
+				return false, "", nil, fmt.Errorf("region '%s' is missing the host in the '%s' extension", region, extTfProviderRegionHosts)
+			}
 		}
-		return true, host, regions, nil
 	}
-	return false, "", nil, nil
+	return true, host, regions, nil
 }
 
 func (o specV2BackendConfiguration) isHostMultiRegion() (bool, string, error) {
@@ -103,8 +138,52 @@ func (o specV2BackendConfiguration) isHostMultiRegion() (bool, string, error) {
 	return false, "", nil
 }
 
-func (o specV2BackendConfiguration) getProviderRegions() ([]string, error) {
+// getRegionHosts returns the hosts defined per region in the 'x-terraform-provider-region-hosts' extension, keyed by the
+// region name. For instance:
+//
+//	x-terraform-provider-region-hosts:
+//	  rst:
+//	    host: "api.rst.hostname.com"
+//	  dub:
+//	    host: "dub-api.hostname.io"
+//	    basePath: "/v2"
+//	    scheme: "https"
+func (o specV2BackendConfiguration) getRegionHosts() (map[string]specRegionHost, error) {
+	regionHosts := map[string]specRegionHost{}
+	value, exists := o.spec.Extensions[extTfProviderRegionHosts]
+	if !exists || value == nil {
+		return regionHosts, nil
+	}
+	extensionJSON, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the '%s' extension: %s", extTfProviderRegionHosts, err)
+	}
+	if err := json.Unmarshal(extensionJSON, &regionHosts); err != nil {
+		return nil, fmt.Errorf("'%s' extension not valid, it must be a map of region names to their host, basePath and scheme: %s", extTfProviderRegionHosts, err)
+	}
+	for region, regionHost := range regionHosts {
+		if regionHost.Host == "" {
+			return nil, fmt.Errorf("'%s' extension not valid, region '%s' is missing the host", extTfProviderRegionHosts, region)
+		}
+		if regionHost.Scheme != "" && regionHost.Scheme != "http" && regionHost.Scheme != "https" {
+			return nil, fmt.Errorf("'%s' extension not valid, region '%s' scheme '%s' not supported - must use http or https", extTfProviderRegionHosts, region, regionHost.Scheme)
+		}
+	}
+	return regionHosts, nil
+}
+
+// getProviderRegions returns the regions defined in the 'x-terraform-provider-regions' extension. If the extension is
+// not present, the regions defined in the given region hosts are returned sorted by name.
+func (o specV2BackendConfiguration) getProviderRegions(regionHosts map[string]specRegionHost) ([]string, error) {
 	regionsExtensionValue, regionsExtensionExists := o.spec.Extensions.GetString(extTfProviderRegions)
+	if !regionsExtensionExists && len(regionHosts) > 0 {
+		regions := []string{}
+		for region := range regionHosts {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+		return regions, nil
+	}
 	if !regionsExtensionExists {
 		return nil, fmt.Errorf("mandatory multiregion '%s' extension missing", extTfProviderRegions)
 	}
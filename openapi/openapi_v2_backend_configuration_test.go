@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -340,7 +342,7 @@ func TestGetProviderRegions(t *testing.T) {
 		openAPIDocumentURL := "www.domain.com"
 		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, openAPIDocumentURL)
 		Convey("When getProviderRegions() method is called", func() {
-			regions, err := specV2BackendConfiguration.getProviderRegions()
+			regions, err := specV2BackendConfiguration.getProviderRegions(nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 				So(regions, ShouldContain, "rst1")
@@ -363,7 +365,7 @@ func TestGetProviderRegions(t *testing.T) {
 		openAPIDocumentURL := "www.domain.com"
 		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, openAPIDocumentURL)
 		Convey("When getProviderRegions() method is called", func() {
-			_, err := specV2BackendConfiguration.getProviderRegions()
+			_, err := specV2BackendConfiguration.getProviderRegions(nil)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, "mandatory multiregion 'x-terraform-provider-regions' extension empty value provided")
@@ -385,7 +387,7 @@ func TestGetProviderRegions(t *testing.T) {
 		openAPIDocumentURL := "www.domain.com"
 		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, openAPIDocumentURL)
 		Convey("When getProviderRegions() method is called", func() {
-			_, err := specV2BackendConfiguration.getProviderRegions()
+			_, err := specV2BackendConfiguration.getProviderRegions(nil)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, "mandatory multiregion 'x-terraform-provider-regions' extension missing")
@@ -536,3 +538,89 @@ func TestGetHTTPSchemes(t *testing.T) {
 
 	}
 }
+
+func TestGetRegionHost(t *testing.T) {
+	testCases := []struct {
+		name               string
+		extensions         spec.Extensions
+		region             string
+		expectedRegionHost specRegionHost
+		expectedErr        string
+	}{
+		{
+			name: "region defined in the region hosts",
+			extensions: spec.Extensions{
+				extTfProviderMultiRegionFQDN: "www.${region}.some-backend.com",
+				extTfProviderRegions:         "rst1,dub1",
+				extTfProviderRegionHosts:     map[string]interface{}{"dub1": map[string]interface{}{"host": "dub.other-backend.io", "basePath": "/v2", "scheme": "http"}},
+			},
+			region:             "dub1",
+			expectedRegionHost: specRegionHost{Host: "dub.other-backend.io", BasePath: "/v2", Scheme: "http"},
+		},
+		{
+			name: "region not defined in the region hosts falls back to the multi-region host",
+			extensions: spec.Extensions{
+				extTfProviderMultiRegionFQDN: "www.${region}.some-backend.com",
+				extTfProviderRegions:         "rst1,dub1",
+				extTfProviderRegionHosts:     map[string]interface{}{"dub1": map[string]interface{}{"host": "dub.other-backend.io"}},
+			},
+			region:             "rst1",
+			expectedRegionHost: specRegionHost{Host: "www.rst1.some-backend.com"},
+		},
+		{
+			name: "regions defined only in the region hosts",
+			extensions: spec.Extensions{
+				extTfProviderRegionHosts: map[string]interface{}{"rst1": map[string]interface{}{"host": "rst.some-backend.com"}, "dub1": map[string]interface{}{"host": "dub.some-backend.com"}},
+			},
+			region:             "rst1",
+			expectedRegionHost: specRegionHost{Host: "rst.some-backend.com"},
+		},
+		{
+			name: "region not supported",
+			extensions: spec.Extensions{
+				extTfProviderRegionHosts: map[string]interface{}{"rst1": map[string]interface{}{"host": "rst.some-backend.com"}},
+			},
+			region:      "dub1",
+			expectedErr: "region dub1 not matching allowed ones [rst1]",
+		},
+		{
+			name: "region without host and without multi-region host",
+			extensions: spec.Extensions{
+				extTfProviderRegions:     "rst1,dub1",
+				extTfProviderRegionHosts: map[string]interface{}{"rst1": map[string]interface{}{"host": "rst.some-backend.com"}},
+			},
+			region:      "rst1",
+			expectedErr: "region 'dub1' is missing the host in the 'x-terraform-provider-region-hosts' extension",
+		},
+		{
+			name: "region host missing the host",
+			extensions: spec.Extensions{
+				extTfProviderRegionHosts: map[string]interface{}{"rst1": map[string]interface{}{"basePath": "/v2"}},
+			},
+			region:      "rst1",
+			expectedErr: "'x-terraform-provider-region-hosts' extension not valid, region 'rst1' is missing the host",
+		},
+		{
+			name: "region host with a scheme not supported",
+			extensions: spec.Extensions{
+				extTfProviderRegionHosts: map[string]interface{}{"rst1": map[string]interface{}{"host": "rst.some-backend.com", "scheme": "ftp"}},
+			},
+			region:      "rst1",
+			expectedErr: "'x-terraform-provider-region-hosts' extension not valid, region 'rst1' scheme 'ftp' not supported - must use http or https",
+		},
+	}
+	for _, tc := range testCases {
+		backendConfiguration, err := newOpenAPIBackendConfigurationV2(&spec.Swagger{
+			SwaggerProps:     spec.SwaggerProps{Swagger: "2.0"},
+			VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions},
+		}, "www.domain.com")
+		require.NoError(t, err, tc.name)
+		regionHost, err := backendConfiguration.getRegionHost(tc.region)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRegionHost, regionHost, tc.name)
+	}
+}
@@ -0,0 +1,64 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+const extTfGlobalPathParameters = "x-terraform-global-path-parameters"
+
+// globalPathParameterExtension defines the attributes of each of the path parameters declared in the
+// 'x-terraform-global-path-parameters' extension, which mirror the OpenAPI 3 server variable ones
+type globalPathParameterExtension struct {
+	Description string        `json:"description"`
+	Default     interface{}   `json:"default"`
+	Enum        []interface{} `json:"enum"`
+	Pattern     string        `json:"pattern"`
+}
+
+// getGlobalPathParameters returns the path parameters declared in the root level 'x-terraform-global-path-parameters'
+// extension of the given document, sorted by name. For instance:
+//
+//	x-terraform-global-path-parameters:
+//	  account_id:
+//	    description: "The account the resources belong to"
+//	  project_id:
+//	    default: "default"
+func getGlobalPathParameters(swagger *spec.Swagger) (SpecGlobalPathParameters, error) {
+	globalPathParameters := SpecGlobalPathParameters{}
+	value, exists := swagger.Extensions[extTfGlobalPathParameters]
+	if !exists || value == nil {
+		return globalPathParameters, nil
+	}
+	extensionJSON, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the '%s' extension: %s", extTfGlobalPathParameters, err)
+	}
+	extension := map[string]globalPathParameterExtension{}
+	if err := json.Unmarshal(extensionJSON, &extension); err != nil {
+		return nil, fmt.Errorf("'%s' extension not valid, it must be a map of path parameter names to their description, default, enum and pattern: %s", extTfGlobalPathParameters, err)
+	}
+	for name, parameter := range extension {
+		globalPathParameter := SpecGlobalPathParameter{
+			Name: name,
+			ProviderPropertyAttributes: ProviderPropertyAttributes{
+				Description: parameter.Description,
+				Pattern:     parameter.Pattern,
+			},
+		}
+		if parameter.Default != nil {
+			globalPathParameter.Default = fmt.Sprintf("%v", parameter.Default)
+		}
+		for _, value := range parameter.Enum {
+			globalPathParameter.AllowedValues = append(globalPathParameter.AllowedValues, fmt.Sprintf("%v", value))
+		}
+		globalPathParameters = append(globalPathParameters, globalPathParameter)
+	}
+	sort.Slice(globalPathParameters, func(i, j int) bool {
+		return globalPathParameters[i].Name < globalPathParameters[j].Name
+	})
+	return globalPathParameters, nil
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGlobalPathParameters(t *testing.T) {
+	swagger := &spec.Swagger{}
+	swagger.AddExtension(extTfGlobalPathParameters, map[string]interface{}{
+		"project_id": map[string]interface{}{
+			"default": "default",
+			"enum":    []interface{}{"default", "web"},
+		},
+		"account_id": map[string]interface{}{
+			"description": "The account the resources belong to",
+			"pattern":     "^[0-9]+$",
+		},
+	})
+	globalPathParameters, err := getGlobalPathParameters(swagger)
+	require.NoError(t, err)
+	assert.Equal(t, SpecGlobalPathParameters{
+		{Name: "account_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "The account the resources belong to", Pattern: "^[0-9]+$"}},
+		{Name: "project_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Default: "default", AllowedValues: []string{"default", "web"}}},
+	}, globalPathParameters)
+}
+
+func TestGetGlobalPathParametersNotDeclared(t *testing.T) {
+	globalPathParameters, err := getGlobalPathParameters(&spec.Swagger{})
+	require.NoError(t, err)
+	assert.Empty(t, globalPathParameters)
+}
+
+func TestGetGlobalPathParametersNotValid(t *testing.T) {
+	swagger := &spec.Swagger{}
+	swagger.AddExtension(extTfGlobalPathParameters, []interface{}{"account_id"})
+	_, err := getGlobalPathParameters(swagger)
+	assert.EqualError(t, err, "'x-terraform-global-path-parameters' extension not valid, it must be a map of path parameter names to their description, default, enum and pattern: json: cannot unmarshal array into Go value of type map[string]openapi.globalPathParameterExtension")
+}
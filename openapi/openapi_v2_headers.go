@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"fmt"
 	"github.com/go-openapi/spec"
 	"log"
 )
@@ -29,11 +30,11 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 				headers[parameter.Name] = parameter.Name
 				switch parameter.In {
 				case "header":
+					headerParameter := SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required, ProviderPropertyAttributes: getHeaderAttributes(parameter)}
 					if preferredName, exists := parameter.Extensions.GetString(extTfHeader); exists {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required})
-					} else {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required})
+						headerParameter.TerraformName = preferredName
 					}
+					headerParameters = append(headerParameters, headerParameter)
 				}
 			} else {
 				log.Printf("[DEBUG] found duplicate header '%s' for an operation, ignoring it as it has been registered already", parameter.Name)
@@ -43,6 +44,30 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 	return headerParameters
 }
 
+// getHeaderAttributes returns the type (only integer and boolean are supported besides string), sensitivity
+// ('x-terraform-sensitive'), description and validation (enum, pattern, minimum and maximum) of the given header parameter
+func getHeaderAttributes(parameter spec.Parameter) ProviderPropertyAttributes {
+	attributes := ProviderPropertyAttributes{
+		Description: parameter.Description,
+		Pattern:     parameter.Pattern,
+		Minimum:     parameter.Minimum,
+		Maximum:     parameter.Maximum,
+	}
+	switch parameter.Type {
+	case "integer":
+		attributes.Type = TypeInt
+	case "boolean":
+		attributes.Type = TypeBool
+	}
+	if sensitive, exists := parameter.Extensions.GetBool(extTfSensitive); exists {
+		attributes.Sensitive = sensitive
+	}
+	for _, value := range parameter.Enum {
+		attributes.AllowedValues = append(attributes.AllowedValues, fmt.Sprintf("%v", value))
+	}
+	return attributes
+}
+
 // getPathHeaderParams aggregates all header type parameters found in the given path and returns the corresponding
 // header configurations
 func getPathHeaderParams(path spec.PathItem) SpecHeaderParameters {
@@ -277,6 +277,45 @@ func TestGetAllHeaderParameters(t *testing.T) {
 	})
 }
 
+func TestGetHeaderAttributes(t *testing.T) {
+	Convey("Given a header parameter of type integer with description, validation and the 'x-terraform-sensitive' extension", t, func() {
+		minimum := 1.0
+		parameter := spec.Parameter{
+			ParamProps: spec.ParamProps{
+				Name:        "X-Retries",
+				In:          "header",
+				Description: "number of retries",
+			},
+			SimpleSchema: spec.SimpleSchema{
+				Type: "integer",
+			},
+			CommonValidations: spec.CommonValidations{
+				Minimum: &minimum,
+				Enum:    []interface{}{float64(1), float64(2)},
+			},
+		}
+		parameter.AddExtension(extTfSensitive, true)
+		Convey("When getHeaderAttributes method is called", func() {
+			attributes := getHeaderAttributes(parameter)
+			Convey("Then the attributes returned should contain the type, sensitivity, description and validation of the header", func() {
+				So(attributes, ShouldResemble, ProviderPropertyAttributes{Type: TypeInt, Sensitive: true, Description: "number of retries", AllowedValues: []string{"1", "2"}, Minimum: &minimum})
+			})
+		})
+	})
+	Convey("Given a header parameter of type string", t, func() {
+		parameter := spec.Parameter{
+			ParamProps:   spec.ParamProps{Name: "X-Request-ID", In: "header"},
+			SimpleSchema: spec.SimpleSchema{Type: "string"},
+		}
+		Convey("When getHeaderAttributes method is called", func() {
+			attributes := getHeaderAttributes(parameter)
+			Convey("Then the attributes returned should be empty as string is the default type", func() {
+				So(attributes, ShouldResemble, ProviderPropertyAttributes{})
+			})
+		})
+	})
+}
+
 func TestGetPathHeaderParams(t *testing.T) {
 	Convey("Given a swagger doc containing a path that contains one POST operation with a header parameter", t, func() {
 		spec := &spec.Swagger{
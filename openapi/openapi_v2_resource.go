@@ -58,6 +58,7 @@ const extTfID = "x-terraform-id"
 const extTfComputed = "x-terraform-computed"
 const extTfIgnoreOrder = "x-terraform-ignore-order"
 const extIgnoreOrder = "x-ignore-order"
+const extTfLabels = "x-terraform-labels"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
@@ -86,6 +87,10 @@ type SpecV2Resource struct {
 
 	Paths map[string]spec.PathItem
 
+	// globalPathParameters contains the names of the global path parameters ('x-terraform-global-path-parameters'), which
+	// are not considered parent resource ids since their values are configured in the provider (or overridden in the resource)
+	globalPathParameters []string
+
 	// Cached objects that are loaded once (when the corresponding function that loads the object is called the first time) and
 	// on subsequent method calls the cached object is returned instead saving executing time.
 
@@ -95,21 +100,25 @@ type SpecV2Resource struct {
 	parentResourceInfoCached *ParentResourceInfo
 	// resolvedPathCached is cached in getResourcePath() method
 	resolvedPathCached string
+	// resolvedPathCachedParentIDs contains the parent IDs used to resolve the resolvedPathCached, so sub-resources belonging
+	// to different parents do not end up sharing the same cached path
+	resolvedPathCachedParentIDs string
 }
 
 // newSpecV2Resource creates a SpecV2Resource with no region and default host
 func newSpecV2Resource(path string, schemaDefinition spec.Schema, rootPathItem, instancePathItem spec.PathItem, schemaDefinitions map[string]spec.Schema, paths map[string]spec.PathItem) (*SpecV2Resource, error) {
-	return newSpecV2ResourceWithConfig(path, schemaDefinition, rootPathItem, instancePathItem, schemaDefinitions, paths)
+	return newSpecV2ResourceWithConfig(path, schemaDefinition, rootPathItem, instancePathItem, schemaDefinitions, paths, nil)
 }
 
-func newSpecV2DataSource(path string, schemaDefinition spec.Schema, rootPathItem spec.PathItem, paths map[string]spec.PathItem) (*SpecV2Resource, error) {
+func newSpecV2DataSource(path string, schemaDefinition spec.Schema, rootPathItem spec.PathItem, paths map[string]spec.PathItem, globalPathParameters []string) (*SpecV2Resource, error) {
 	resource := &SpecV2Resource{
-		Path:              path,
-		SchemaDefinition:  schemaDefinition,
-		RootPathItem:      rootPathItem,
-		InstancePathItem:  spec.PathItem{},
-		SchemaDefinitions: nil,
-		Paths:             paths,
+		Path:                 path,
+		SchemaDefinition:     schemaDefinition,
+		RootPathItem:         rootPathItem,
+		InstancePathItem:     spec.PathItem{},
+		SchemaDefinitions:    nil,
+		Paths:                paths,
+		globalPathParameters: globalPathParameters,
 	}
 	name, err := resource.buildResourceName()
 	if err != nil {
@@ -119,7 +128,8 @@ func newSpecV2DataSource(path string, schemaDefinition spec.Schema, rootPathItem
 	return resource, nil
 }
 
-func newSpecV2ResourceWithConfig(path string, schemaDefinition spec.Schema, rootPathItem, instancePathItem spec.PathItem, schemaDefinitions map[string]spec.Schema, paths map[string]spec.PathItem) (*SpecV2Resource, error) {
+// newSpecV2ResourceWithConfig creates a SpecV2Resource whose path may contain the given global path parameters
+func newSpecV2ResourceWithConfig(path string, schemaDefinition spec.Schema, rootPathItem, instancePathItem spec.PathItem, schemaDefinitions map[string]spec.Schema, paths map[string]spec.PathItem, globalPathParameters []string) (*SpecV2Resource, error) {
 	if path == "" {
 		return nil, fmt.Errorf("path must not be empty")
 	}
@@ -133,6 +143,8 @@ func newSpecV2ResourceWithConfig(path string, schemaDefinition spec.Schema, root
 		InstancePathItem:  instancePathItem,
 		SchemaDefinitions: schemaDefinitions,
 		Paths:             paths,
+
+		globalPathParameters: globalPathParameters,
 	}
 	name, err := resource.buildResourceName()
 	if err != nil {
@@ -204,20 +216,23 @@ func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName s
 // getResourcePath returns the root path of the resource. If the resource is a subresource and therefore the path contains
 // path parameters these will be resolved accordingly based on the ids provided. For instance, considering the given
 // resource path "/v1/cdns/{cdn_id}/v1/firewalls" and the []strin{"cdnID"} the returned path will be "/v1/cdns/cdnID/v1/firewalls".
-// If the resource path is not parameterised, then regular path will be returned accordingly
+// If the resource path is not parameterised, then regular path will be returned accordingly. The global path parameters
+// are not resolved with the parent ids, they are left as is to be resolved with the values configured in the provider
 func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
-	if o.resolvedPathCached != "" {
+	cacheKey := strings.Join(parentIDs, "/")
+	if o.resolvedPathCached != "" && o.resolvedPathCachedParentIDs == cacheKey {
 		log.Printf("[DEBUG] getResourcePath hit the cache for '%s'", o.Name)
 		return o.resolvedPathCached, nil
 	}
 	resolvedPath := o.Path
 
 	pathParameterRegex, _ := regexp.Compile(pathParameterRegex)
-	pathParamsMatches := pathParameterRegex.FindAllStringSubmatch(resolvedPath, -1)
+	pathParamsMatches := o.filterGlobalPathParameters(pathParameterRegex.FindAllStringSubmatch(resolvedPath, -1))
 
 	switch {
 	case len(pathParamsMatches) == 0:
 		o.resolvedPathCached = resolvedPath
+		o.resolvedPathCachedParentIDs = cacheKey
 		log.Printf("[DEBUG] getResourcePath cache loaded for '%s'", o.Name)
 		return resolvedPath, nil
 
@@ -237,10 +252,44 @@ func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
 	}
 
 	o.resolvedPathCached = resolvedPath
+	o.resolvedPathCachedParentIDs = cacheKey
 	log.Printf("[DEBUG] getResourcePath cache loaded for '%s'", o.Name)
 	return resolvedPath, nil
 }
 
+// filterGlobalPathParameters returns the given path parameter matches excluding the global path parameters
+func (o *SpecV2Resource) filterGlobalPathParameters(pathParamsMatches [][]string) [][]string {
+	filteredMatches := [][]string{}
+	for _, match := range pathParamsMatches {
+		if !o.isGlobalPathParameter(match[len(match)-1]) {
+			filteredMatches = append(filteredMatches, match)
+		}
+	}
+	return filteredMatches
+}
+
+// isGlobalPathParameter checks whether the given path parameter (e,g: {account_id}) is a global path parameter
+func (o *SpecV2Resource) isGlobalPathParameter(pathParameter string) bool {
+	name := strings.TrimSuffix(strings.TrimPrefix(pathParameter, "{"), "}")
+	for _, globalPathParameter := range o.globalPathParameters {
+		if globalPathParameter == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getGlobalPathParameters returns the names of the global path parameters contained in the resource path
+func (o *SpecV2Resource) getGlobalPathParameters() []string {
+	var globalPathParameters []string
+	for _, globalPathParameter := range o.globalPathParameters {
+		if strings.Contains(o.Path, fmt.Sprintf("{%s}", globalPathParameter)) {
+			globalPathParameters = append(globalPathParameters, globalPathParameter)
+		}
+	}
+	return globalPathParameters
+}
+
 // getHost can return an empty host in which case the expectation is that the host used will be the one specified in the
 // swagger host attribute or if not present the host used will be the host where the swagger file was served
 func (o *SpecV2Resource) getHost() (string, error) {
@@ -251,6 +300,24 @@ func (o *SpecV2Resource) getHost() (string, error) {
 	return overrideHost, nil
 }
 
+// getRegion returns an empty region since the region is not defined per resource in the OpenAPI document, the region
+// configured in the provider is used instead
+func (o *SpecV2Resource) getRegion() string {
+	return ""
+}
+
+// getBasePath returns an empty base path since the base path is not defined per resource in the OpenAPI document, the
+// base path of the backend configuration is used instead
+func (o *SpecV2Resource) getBasePath() string {
+	return ""
+}
+
+// getScheme returns an empty scheme since the scheme is not defined per resource in the OpenAPI document, the scheme of
+// the backend configuration is used instead
+func (o *SpecV2Resource) getScheme() string {
+	return ""
+}
+
 func (o *SpecV2Resource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
 		List:   o.createResourceOperation(o.RootPathItem.Get),
@@ -292,11 +359,19 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 		for _, match := range parentMatches {
 			fullMatch := match[0]
 			rootPath := match[1]
+			// global path parameters (e,g: /v1/accounts/{account_id}) are part of the parent paths but they are not parents
+			if o.isGlobalPathParameter(fullMatch[strings.LastIndex(fullMatch, "/")+1:]) {
+				parentInstanceURI = parentInstanceURI + fullMatch
+				continue
+			}
 			parentURI = parentInstanceURI + rootPath
 			parentInstanceURI = parentInstanceURI + fullMatch
 			parentURIs = append(parentURIs, parentURI)
 			parentInstanceURIs = append(parentInstanceURIs, parentInstanceURI)
 		}
+		if len(parentURIs) == 0 {
+			return nil
+		}
 
 		fullParentResourceName := ""
 		preferredParentName := ""
@@ -380,6 +455,16 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 				schemaProps[parentPropertyName] = pr
 			}
 		}
+		// the global path parameters can be overridden per resource, otherwise the value configured in the provider is used
+		for _, globalPathParameter := range o.getGlobalPathParameters() {
+			pr, _ := o.createSchemaDefinitionProperty(globalPathParameter, spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}, nil)
+			pr.Description = fmt.Sprintf("The %s the resource belongs to, if not set the value configured in the provider is used", globalPathParameter)
+			pr.Computed = true
+			pr.ForceNew = true
+			pr.IsParentProperty = true
+			pr.IsGlobalPathParameter = true
+			schemaProps[globalPathParameter] = pr
+		}
 	}
 
 	for _, property := range schemaProps {
@@ -493,6 +578,15 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.IsStatusIdentifier = true
 	}
 
+	// A labels property holds the labels of the resource (e,g: team, cost_center) and the provider's default labels are
+	// merged into it when the resource is created or updated
+	if o.isBoolExtensionEnabled(property.Extensions, extTfLabels) {
+		if !schemaDefinitionProperty.isLabelsCompatible() {
+			return nil, fmt.Errorf("failed to process property '%s': the '%s' extension is only supported in maps of strings or lists of objects with '%s' and '%s' string properties", propertyName, extTfLabels, labelsKeyPropertyName, labelsValuePropertyName)
+		}
+		schemaDefinitionProperty.IsLabels = true
+	}
+
 	// Use the default keyword in the parameter schema to specify the default value for an optional parameter. The default
 	// value is the one that the server uses if the client does not supply the parameter value in the request.
 	// Link: https://swagger.io/docs/specification/describing-parameters#default
@@ -597,6 +691,8 @@ func (o *SpecV2Resource) validateArrayItems(property spec.Schema) (schemaDefinit
 func (o *SpecV2Resource) getPropertyType(property spec.Schema) (schemaDefinitionPropertyType, error) {
 	if o.isArrayTypeProperty(property) {
 		return TypeList, nil
+	} else if o.isMapProperty(property) {
+		return TypeMap, nil
 	} else if isObject, _, err := o.isObjectProperty(property); isObject || err != nil {
 		return TypeObject, err
 	} else if property.Type.Contains("string") {
@@ -612,6 +708,9 @@ func (o *SpecV2Resource) getPropertyType(property spec.Schema) (schemaDefinition
 }
 
 func (o *SpecV2Resource) isObjectProperty(property spec.Schema) (bool, *spec.Schema, error) {
+	if o.isMapProperty(property) {
+		return false, nil, nil
+	}
 	if o.isObjectTypeProperty(property) || property.Ref.Ref.GetURL() != nil {
 		// Case of nested object schema
 		if len(property.Properties) != 0 {
@@ -654,6 +753,19 @@ func (o *SpecV2Resource) isArrayProperty(property spec.Schema) (bool, schemaDefi
 	return false, "", nil, nil
 }
 
+// isMapProperty returns true if the property is an object with no properties whose additional properties are strings. Example:
+//
+// tags:
+//  type: "object"
+//  additionalProperties:
+//    type: "string"
+func (o *SpecV2Resource) isMapProperty(property spec.Schema) bool {
+	if !o.isObjectTypeProperty(property) || len(property.Properties) != 0 || property.Ref.Ref.GetURL() != nil {
+		return false
+	}
+	return property.AdditionalProperties != nil && property.AdditionalProperties.Schema != nil && o.isOfType(*property.AdditionalProperties.Schema, "string")
+}
+
 func (o *SpecV2Resource) isArrayTypeProperty(property spec.Schema) bool {
 	return o.isOfType(property, "array")
 }
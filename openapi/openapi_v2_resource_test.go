@@ -43,7 +43,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "users")
@@ -59,7 +59,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "users")
@@ -75,7 +75,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "users_v1")
@@ -91,7 +91,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "users_v12")
@@ -107,7 +107,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "users")
@@ -130,7 +130,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, paths)
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, paths, nil)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(r.GetResourceName(), ShouldEqual, "nodes_v1_proxy")
@@ -153,7 +153,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			r, err := newSpecV2ResourceWithConfig(path, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			resourceName := r.GetResourceName()
 			expectedTerraformName := fmt.Sprintf("%s_v1", expectedResourceName)
 			Convey(fmt.Sprintf("And the value returned should still be '%s'", expectedTerraformName), func() {
@@ -171,7 +171,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		}
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			_, err := newSpecV2ResourceWithConfig(invalidRootPath, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+			_, err := newSpecV2ResourceWithConfig(invalidRootPath, spec.Schema{}, rootPathItem, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{}, nil)
 			Convey("And the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -192,7 +192,7 @@ func TestNewSpecV2ResourceWithConfig(t *testing.T) {
 		var paths map[string]spec.PathItem
 		Convey("When newSpecV2ResourceWithConfig method is called", func() {
 			schemaDefinitions := map[string]spec.Schema{}
-			r, err := newSpecV2ResourceWithConfig("/v1/users", spec.Schema{}, spec.PathItem{}, spec.PathItem{}, schemaDefinitions, paths)
+			r, err := newSpecV2ResourceWithConfig("/v1/users", spec.Schema{}, spec.PathItem{}, spec.PathItem{}, schemaDefinitions, paths, nil)
 			Convey("And the err returned output should match the expectation", func() {
 				So(err.Error(), ShouldEqual, "paths must not be nil")
 				So(r, ShouldBeNil)
@@ -1744,6 +1744,22 @@ func TestGetResourcePath(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a SpecV2Resource with path resource that is parameterised and a resolvedPathCached populated for a different parent", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns/{cdn_id}/v1/firewalls",
+		}
+		_, err := r.getResourcePath([]string{"cdnID"})
+		So(err, ShouldBeNil)
+		Convey("When getResourcePath is called with a different parent ID", func() {
+			resourcePath, err := r.getResourcePath([]string{"otherCdnID"})
+			Convey("Then the returned resource path should be resolved with the given parent ID and not the cached one", func() {
+				So(err, ShouldBeNil)
+				So(resourcePath, ShouldEqual, "/v1/cdns/otherCdnID/v1/firewalls")
+				So(r.resolvedPathCached, ShouldEqual, "/v1/cdns/otherCdnID/v1/firewalls")
+			})
+		})
+	})
 }
 
 func TestCreateSchemaDefinitionProperty(t *testing.T) {
@@ -3983,3 +3999,130 @@ func TestGetResourceOverrideHost(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateSchemaDefinitionPropertyMap(t *testing.T) {
+	r := SpecV2Resource{}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("tags", spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:                 spec.StringOrArray{"object"},
+			AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}},
+		},
+	}, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, TypeMap, schemaDefinitionProperty.Type)
+	assert.Nil(t, schemaDefinitionProperty.SpecSchemaDefinition)
+
+	// objects whose additional properties are not strings are not supported
+	_, err = r.createSchemaDefinitionProperty("tags", spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:                 spec.StringOrArray{"object"},
+			AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}}},
+		},
+	}, []string{})
+	assert.EqualError(t, err, "failed to process property 'tags': object is missing the nested schema definition or the ref is pointing to a non existing schema definition")
+}
+
+func TestCreateSchemaDefinitionPropertyLabels(t *testing.T) {
+	stringSchema := spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}
+	labelsExtension := spec.Extensions{}
+	labelsExtension.Add(extTfLabels, true)
+	testCases := []struct {
+		name          string
+		property      spec.Schema
+		expectedError string
+	}{
+		{
+			name: "map of strings",
+			property: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{Extensions: labelsExtension},
+				SchemaProps: spec.SchemaProps{
+					Type:                 spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: &stringSchema},
+				},
+			},
+		},
+		{
+			name: "list of key/value objects",
+			property: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{Extensions: labelsExtension},
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+						Type:       spec.StringOrArray{"object"},
+						Properties: map[string]spec.Schema{"key": stringSchema, "value": stringSchema},
+					}}},
+				},
+			},
+		},
+		{
+			name: "list of objects without value",
+			property: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{Extensions: labelsExtension},
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+						Type:       spec.StringOrArray{"object"},
+						Properties: map[string]spec.Schema{"key": stringSchema, "name": stringSchema},
+					}}},
+				},
+			},
+			expectedError: "failed to process property 'labels': the 'x-terraform-labels' extension is only supported in maps of strings or lists of objects with 'key' and 'value' string properties",
+		},
+		{
+			name: "list of strings",
+			property: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{Extensions: labelsExtension},
+				SchemaProps: spec.SchemaProps{
+					Type:  spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{Schema: &stringSchema},
+				},
+			},
+			expectedError: "failed to process property 'labels': the 'x-terraform-labels' extension is only supported in maps of strings or lists of objects with 'key' and 'value' string properties",
+		},
+	}
+	r := SpecV2Resource{}
+	for _, tc := range testCases {
+		schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("labels", tc.property, []string{})
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.True(t, schemaDefinitionProperty.IsLabels, tc.name)
+	}
+}
+
+func TestSpecV2ResourceGlobalPathParameters(t *testing.T) {
+	rootPathItem := spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}}
+	schemaDefinition := spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"label": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}}}}
+
+	r, err := newSpecV2ResourceWithConfig("/v1/accounts/{account_id}/cdns", schemaDefinition, rootPathItem, spec.PathItem{}, nil, map[string]spec.PathItem{}, []string{"account_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cdns", r.GetResourceName())
+	assert.Nil(t, r.GetParentResourceInfo(), "global path parameters are not parents")
+	resourcePath, err := r.getResourcePath(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/accounts/{account_id}/cdns", resourcePath)
+
+	r, err = newSpecV2ResourceWithConfig("/v1/accounts/{account_id}/cdns/{cdn_id}/firewalls", schemaDefinition, rootPathItem, spec.PathItem{}, nil, map[string]spec.PathItem{}, []string{"account_id", "project_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cdns_firewalls", r.GetResourceName())
+	parentResourceInfo := r.GetParentResourceInfo()
+	assert.Equal(t, []string{"cdns_id"}, parentResourceInfo.GetParentPropertiesNames())
+	assert.Equal(t, []string{"/v1/accounts/{account_id}/cdns"}, parentResourceInfo.parentURIs)
+	assert.Equal(t, []string{"/v1/accounts/{account_id}/cdns/{cdn_id}"}, parentResourceInfo.parentInstanceURIs)
+	resourcePath, err = r.getResourcePath([]string{"cdn1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/accounts/{account_id}/cdns/cdn1/firewalls", resourcePath)
+
+	resourceSchema, err := r.GetResourceSchema()
+	assert.NoError(t, err)
+	accountIDProperty, err := resourceSchema.getProperty("account_id")
+	assert.NoError(t, err)
+	assert.True(t, accountIDProperty.IsGlobalPathParameter)
+	assert.True(t, accountIDProperty.IsParentProperty)
+	assert.True(t, accountIDProperty.IsOptionalComputed())
+	assert.True(t, accountIDProperty.ForceNew)
+	_, err = resourceSchema.getProperty("project_id")
+	assert.Error(t, err, "the global path parameters not in the resource path are not added to the resource schema")
+}
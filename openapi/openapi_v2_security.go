@@ -2,11 +2,17 @@ package openapi
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/go-openapi/spec"
 )
 
 const extTfAuthenticationSchemeBearer = "x-terraform-authentication-scheme-bearer"
 const extTfAuthenticationRefreshToken = "x-terraform-refresh-token-url" // #nosec G101
+const extTfRequestSigning = "x-terraform-request-signing"
+const extTfRequestSigningAlgorithm = "x-terraform-request-signing-algorithm"
+const extTfRequestSigningAWSRegion = "x-terraform-request-signing-aws-region"
+const extTfRequestSigningAWSService = "x-terraform-request-signing-aws-service"
 
 type specV2Security struct {
 	SecurityDefinitions spec.SecurityDefinitions
@@ -14,15 +20,37 @@ type specV2Security struct {
 }
 
 // GetAPIKeySecurityDefinitions returns a list of SpecSecurityDefinition after looping through the SecurityDefinitions
-// and selecting only the SecurityDefinitions of type apiKey
+// and selecting only the SecurityDefinitions of type apiKey, basic and oauth2 (using the application flow)
 func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
 	securityDefinitions := &SpecSecurityDefinitions{}
 	for secDefName, secDef := range s.SecurityDefinitions {
+		if secDef.Type == "oauth2" {
+			if secDef.Flow != "application" {
+				log.Printf("[WARN] ignoring security definition '%s' since the oauth2 flow '%s' is not supported, only the 'application' (client credentials) flow is supported", secDefName, secDef.Flow)
+				continue
+			}
+			securityDefinition := newOAuth2ClientCredentialsSecurityDefinition(secDefName, secDef.TokenURL)
+			if err := securityDefinition.validate(); err != nil {
+				return nil, err
+			}
+			*securityDefinitions = append(*securityDefinitions, securityDefinition)
+			continue
+		}
+		if secDef.Type == "basic" {
+			securityDefinition := newBasicSecurityDefinition(secDefName)
+			if err := securityDefinition.validate(); err != nil {
+				return nil, err
+			}
+			*securityDefinitions = append(*securityDefinitions, securityDefinition)
+			continue
+		}
 		if secDef.Type == "apiKey" {
 			var securityDefinition SpecSecurityDefinition
 			switch secDef.In {
 			case "header":
-				if refreshTokenURL := s.isRefreshTokenAuth(secDef); refreshTokenURL != "" {
+				if signingMethod, isRequestSigning := secDef.Extensions.GetString(extTfRequestSigning); isRequestSigning {
+					securityDefinition = s.createRequestSigningSecurityDefinition(secDefName, requestSigningMethod(signingMethod), secDef)
+				} else if refreshTokenURL := s.isRefreshTokenAuth(secDef); refreshTokenURL != "" {
 					securityDefinition = newAPIKeyHeaderRefreshTokenSecurityDefinition(secDefName, refreshTokenURL)
 				} else if s.isBearerScheme(secDef) {
 					securityDefinition = newAPIKeyHeaderBearerSecurityDefinition(secDefName)
@@ -55,6 +83,20 @@ func (s *specV2Security) isBearerScheme(secDef *spec.SecurityScheme) bool {
 	return false
 }
 
+// createRequestSigningSecurityDefinition returns the security definition for the request signing method configured in
+// the x-terraform-request-signing extension. Unsupported methods are reported when the security definition is validated.
+func (s *specV2Security) createRequestSigningSecurityDefinition(secDefName string, signingMethod requestSigningMethod, secDef *spec.SecurityScheme) SpecSecurityDefinition {
+	if signingMethod == requestSigningAWSSigV4 {
+		region, _ := secDef.Extensions.GetString(extTfRequestSigningAWSRegion)
+		service, _ := secDef.Extensions.GetString(extTfRequestSigningAWSService)
+		return newAWSSigV4RequestSigningSecurityDefinition(secDefName, region, service)
+	}
+	algorithm, _ := secDef.Extensions.GetString(extTfRequestSigningAlgorithm)
+	securityDefinition := newHMACRequestSigningSecurityDefinition(secDefName, secDef.Name, algorithm)
+	securityDefinition.signingMethod = signingMethod
+	return securityDefinition
+}
+
 func (s *specV2Security) isRefreshTokenAuth(secDef *spec.SecurityScheme) string {
 	refreshTokenURL, isRefreshTokenAuth := secDef.Extensions.GetString(extTfAuthenticationRefreshToken)
 	if isRefreshTokenAuth {
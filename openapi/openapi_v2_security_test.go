@@ -239,6 +239,166 @@ func TestGetAPIKeySecurityDefinitions(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a specV2Security loaded with header security definitions using the request signing extensions", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"hmac_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "header",
+						Type: "apiKey",
+						Name: "X-Signature",
+					},
+					VendorExtensible: spec.VendorExtensible{
+						Extensions: spec.Extensions{
+							extTfRequestSigning:          "hmac",
+							extTfRequestSigningAlgorithm: "hmac-sha512",
+						},
+					},
+				},
+				"sigv4_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "header",
+						Type: "apiKey",
+						Name: "Authorization",
+					},
+					VendorExtensible: spec.VendorExtensible{
+						Extensions: spec.Extensions{
+							extTfRequestSigning:           "aws-sigv4",
+							extTfRequestSigningAWSRegion:  "us-east-1",
+							extTfRequestSigningAWSService: "execute-api",
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the result returned should contain the request signing security definitions", func() {
+				So(err, ShouldBeNil)
+				So(*securityDefinitions, ShouldHaveLength, 2)
+				So(securityDefinitions.findSecurityDefinitionFor("hmac_auth"), ShouldResemble, newHMACRequestSigningSecurityDefinition("hmac_auth", "X-Signature", "hmac-sha512"))
+				So(securityDefinitions.findSecurityDefinitionFor("sigv4_auth"), ShouldResemble, newAWSSigV4RequestSigningSecurityDefinition("sigv4_auth", "us-east-1", "execute-api"))
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a header security definition using a request signing method that is not supported", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"signing_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "header",
+						Type: "apiKey",
+					},
+					VendorExtensible: spec.VendorExtensible{
+						Extensions: spec.Extensions{
+							extTfRequestSigning: "rsa",
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			_, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the error should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "specRequestSigningSecurityDefinition 'signing_auth' request signing method 'rsa' not supported, only 'hmac' and 'aws-sigv4' values are valid")
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a security definition of type basic", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"basic_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type: "basic",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			secDefs := *securityDefinitions
+			Convey("Then the result returned should be the basic security definition", func() {
+				So(err, ShouldBeNil)
+				So(secDefs, ShouldHaveLength, 1)
+				So(secDefs[0], ShouldResemble, newBasicSecurityDefinition("basic_auth"))
+				So(secDefs[0].getAPIKey().Name, ShouldEqual, authorizationHeader)
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a security definition of type oauth2 using the application flow", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:     "oauth2",
+						Flow:     "application",
+						TokenURL: "https://api.iam.com/oauth2/token",
+						Scopes:   map[string]string{"read": "read access"},
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			secDefs := *securityDefinitions
+			Convey("Then the result returned should be the OAuth2 client credentials security definition", func() {
+				So(err, ShouldBeNil)
+				So(secDefs, ShouldHaveLength, 1)
+				So(secDefs[0], ShouldResemble, newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token"))
+				So(secDefs[0].getAPIKey().Name, ShouldEqual, authorizationHeader)
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a security definition of type oauth2 using the application flow that is missing the token URL", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type: "oauth2",
+						Flow: "application",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			_, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the error should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "specOAuth2ClientCredentialsSecurityDefinition 'oauth2_auth' missing mandatory token URL")
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a security definition of type oauth2 using a flow other than application", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:             "oauth2",
+						Flow:             "implicit",
+						AuthorizationURL: "https://api.iam.com/oauth2/authorize",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the security definition should be ignored", func() {
+				So(err, ShouldBeNil)
+				So(*securityDefinitions, ShouldBeEmpty)
+			})
+		})
+	})
 }
 
 func TestGetGlobalSecuritySchemes(t *testing.T) {
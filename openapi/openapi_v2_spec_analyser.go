@@ -24,17 +24,26 @@ type specV2Analyser struct {
 // newSpecAnalyserV2 creates an instance of specV2Analyser which implements the SpecAnalyser interface
 // This implementation provides an analyser that understands an OpenAPI v2 document
 func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error) {
+	return newSpecAnalyserV2WithLoader(openAPIDocumentFilename, nil)
+}
+
+// newSpecAnalyserV2WithLoader creates an instance of specV2Analyser retrieving the OpenAPI document with the given
+// loader (if the document is hosted remotely). If the loader is nil, the default go-openapi loader is used.
+func newSpecAnalyserV2WithLoader(openAPIDocumentFilename string, loader *specLoader) (*specV2Analyser, error) {
 	if openAPIDocumentFilename == "" {
 		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
 	}
-	apiSpec, err := loads.JSONSpec(openAPIDocumentFilename)
+	apiSpec, err := loader.load(openAPIDocumentFilename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
-	apiSpec, err = apiSpec.Expanded()
+	apiSpec, err = loader.expand(apiSpec, openAPIDocumentFilename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
+	if err := loader.configureRegions(apiSpec.Spec(), openAPIDocumentFilename); err != nil {
+		return nil, fmt.Errorf("failed to configure the regions of the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
 	return &specV2Analyser{
 		d:                  apiSpec,
 		openAPIDocumentURL: openAPIDocumentFilename,
@@ -43,21 +52,19 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 
 func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecResource {
 	var dataSources []SpecResource
+	globalPathParameters, err := specAnalyser.GetGlobalPathParameters()
+	if err != nil {
+		log.Printf("[WARN] ignoring the global path parameters while looking up the data sources: %s", err)
+	}
 	spec := specAnalyser.d.Spec()
 	paths := spec.Paths
 	for resourcePath, pathItem := range paths.Paths {
-		schemaDefinition, err := specAnalyser.isEndPointTerraformDataSourceCompliant(pathItem)
+		d, err := specAnalyser.getTerraformCompliantDataSource(resourcePath, pathItem, globalPathParameters.getNames())
 		if err != nil {
 			log.Printf("[DEBUG] resource path '%s' not terraform data source compliant: %s", resourcePath, err)
 			continue
 		}
 
-		d, err := newSpecV2DataSource(resourcePath, *schemaDefinition, pathItem, specAnalyser.d.Spec().Paths.Paths)
-		if err != nil {
-			log.Printf("[WARN] ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
-			continue
-		}
-
 		log.Printf("[INFO] found terraform compliant data source [name='%s', rootPath='%s']", d.GetResourceName(), resourcePath)
 		dataSources = append(dataSources, d)
 	}
@@ -67,27 +74,19 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecRes
 func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecResource, error) {
 	var resources []SpecResource
 	start := time.Now()
+	globalPathParameters, err := specAnalyser.GetGlobalPathParameters()
+	if err != nil {
+		return nil, err
+	}
 	spec := specAnalyser.d.Spec()
 	paths := spec.Paths
-	for resourcePath, pathItem := range paths.Paths {
-		resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
+	for resourcePath := range paths.Paths {
+		r, resourceRootPath, err := specAnalyser.getTerraformCompliantResource(resourcePath, globalPathParameters.getNames())
 		if err != nil {
 			log.Printf("[DEBUG] resource path '%s' not terraform compliant: %s", resourcePath, err)
 			continue
 		}
 
-		r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
-		if err != nil {
-			log.Printf("[WARN] ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
-			continue
-		}
-
-		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
-		if err != nil {
-			log.Printf("[WARN] ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
-			continue
-		}
-
 		log.Printf("[INFO] found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.GetResourceName(), resourceRootPath, resourcePath)
 		resources = append(resources, r)
 	}
@@ -95,6 +94,34 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 	return resources, nil
 }
 
+// getTerraformCompliantResource returns the resource exposed by the given instance path along with its root path, or the
+// reason why the path is not terraform compliant. The resources excluded with the 'x-terraform-exclude-resource' extension
+// are returned too, the callers decide how to handle them.
+func (specAnalyser *specV2Analyser) getTerraformCompliantResource(resourcePath string, globalPathParameterNames []string) (*SpecV2Resource, string, error) {
+	resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err := newSpecV2ResourceWithConfig(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, specAnalyser.d.Spec().Paths.Paths[resourcePath], specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths, globalPathParameterNames)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := specAnalyser.validateSubResourceTerraformCompliance(*r); err != nil {
+		return nil, "", err
+	}
+	return r, resourceRootPath, nil
+}
+
+// getTerraformCompliantDataSource returns the data source exposed by the given path, or the reason why the path is not
+// terraform data source compliant
+func (specAnalyser *specV2Analyser) getTerraformCompliantDataSource(resourcePath string, pathItem spec.PathItem, globalPathParameterNames []string) (*SpecV2Resource, error) {
+	schemaDefinition, err := specAnalyser.isEndPointTerraformDataSourceCompliant(pathItem)
+	if err != nil {
+		return nil, err
+	}
+	return newSpecV2DataSource(resourcePath, *schemaDefinition, pathItem, specAnalyser.d.Spec().Paths.Paths, globalPathParameterNames)
+}
+
 func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
 	parentResourceInfo := r.GetParentResourceInfo()
 	if parentResourceInfo != nil {
@@ -146,6 +173,12 @@ func (specAnalyser *specV2Analyser) GetAllHeaderParameters() SpecHeaderParameter
 	return getAllHeaderParameters(specAnalyser.d.Spec().Paths.Paths)
 }
 
+// GetGlobalPathParameters returns the path parameters declared in the root level 'x-terraform-global-path-parameters'
+// extension
+func (specAnalyser *specV2Analyser) GetGlobalPathParameters() (SpecGlobalPathParameters, error) {
+	return getGlobalPathParameters(specAnalyser.d.Spec())
+}
+
 func (specAnalyser *specV2Analyser) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
 	return newOpenAPIBackendConfigurationV2(specAnalyser.d.Spec(), specAnalyser.openAPIDocumentURL)
 }
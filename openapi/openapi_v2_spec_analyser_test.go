@@ -1846,7 +1846,7 @@ func TestSpecV2AnalyserGetAllHeaderParameters(t *testing.T) {
 			specHeaderParameters := r.GetAllHeaderParameters()
 			Convey("Then the specBackedConfig returned should not be nil", func() {
 				So(len(specHeaderParameters), ShouldEqual, 1)
-				So(specHeaderParameters, ShouldContain, SpecHeaderParam{Name: "header_name"})
+				So(specHeaderParameters, ShouldContain, SpecHeaderParam{Name: "header_name", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "some header to be passed in the POST request"}})
 			})
 		})
 	})
@@ -1943,7 +1943,7 @@ func TestSpecV2AnalyserGetAllHeaderParameters(t *testing.T) {
 			specHeaderParameters := r.GetAllHeaderParameters()
 			Convey("Then the specHeaderParameters should have size one since the same header is present in multiple resources", func() {
 				So(len(specHeaderParameters), ShouldEqual, 1)
-				So(specHeaderParameters, ShouldContain, SpecHeaderParam{Name: "header_name"})
+				So(specHeaderParameters, ShouldContain, SpecHeaderParam{Name: "header_name", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "some header to be passed in the POST request"}})
 			})
 		})
 	})
@@ -3820,6 +3820,69 @@ definitions:
 	}
 }
 
+func TestGetTerraformCompliantResourcesGlobalPathParameters(t *testing.T) {
+	swaggerContent := `swagger: "2.0"
+host: 127.0.0.1
+x-terraform-global-path-parameters:
+  account_id:
+    description: "The account the resources belong to"
+paths:
+  /v1/accounts/{account_id}/cdns:
+    post:
+      parameters:
+      - name: "account_id"
+        in: "path"
+        required: true
+        type: "string"
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/accounts/{account_id}/cdns/{id}:
+    get:
+      parameters:
+      - name: "account_id"
+        in: "path"
+        required: true
+        type: "string"
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+	a := initAPISpecAnalyser(swaggerContent)
+	globalPathParameters, err := a.GetGlobalPathParameters()
+	assert.NoError(t, err)
+	assert.Equal(t, SpecGlobalPathParameters{{Name: "account_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "The account the resources belong to"}}}, globalPathParameters)
+	resources, err := a.GetTerraformCompliantResources()
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "cdns", resources[0].GetResourceName())
+	assert.Nil(t, resources[0].GetParentResourceInfo())
+	resourceSchema, err := resources[0].GetResourceSchema()
+	assert.NoError(t, err)
+	accountIDProperty, err := resourceSchema.getProperty("account_id")
+	assert.NoError(t, err)
+	assert.True(t, accountIDProperty.IsGlobalPathParameter)
+}
+
 func TestGetTerraformCompliantResources(t *testing.T) {
 	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant terraform subresource /v1/cdns/{id}/v1/firewalls but missing the parent resource resource description", t, func() {
 		swaggerContent := `swagger: "2.0"
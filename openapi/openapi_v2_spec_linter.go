@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// lint returns the SpecLintReport for the OpenAPI document. The paths are validated with the same helpers used by
+// GetTerraformCompliantResources and GetTerraformCompliantDataSources, and the unknown extensions are looked up in the
+// original (not expanded) document so the extensions in shared definitions are only reported once.
+func (specAnalyser *specV2Analyser) lint() *SpecLintReport {
+	paths := specAnalyser.d.Spec().Paths.Paths
+	pathNames := []string{}
+	for pathName := range paths {
+		pathNames = append(pathNames, pathName)
+	}
+	sort.Strings(pathNames)
+
+	results := map[string]*SpecPathLintResult{}
+	for _, pathName := range pathNames {
+		results[pathName] = &SpecPathLintResult{Path: pathName}
+	}
+	// the global path parameters are looked up the same way as GetTerraformCompliantResources and GetTerraformCompliantDataSources do
+	globalPathParameters, globalPathParametersErr := specAnalyser.GetGlobalPathParameters()
+	resourcePaths := map[string][]string{}
+	for _, pathName := range pathNames {
+		result := results[pathName]
+		r, resourceRootPath, err := specAnalyser.lintResource(pathName, globalPathParameters, globalPathParametersErr)
+		if err != nil {
+			result.ResourceRejection = err.Error()
+		} else {
+			result.Resource = r.GetResourceName()
+			result.DataSourceInstance = newDataSourceInstanceFactory(r).getDataSourceInstanceName()
+			resourcePaths[result.Resource] = append(resourcePaths[result.Resource], pathName)
+			if rootResult, exists := results[resourceRootPath]; exists {
+				rootResult.ResourceRootPathOf = result.Resource
+			} else if rootResult, exists := results[resourceRootPath+"/"]; exists {
+				rootResult.ResourceRootPathOf = result.Resource
+			}
+		}
+		d, err := specAnalyser.getTerraformCompliantDataSource(pathName, paths[pathName], globalPathParameters.getNames())
+		if err != nil {
+			result.DataSourceRejection = err.Error()
+		} else {
+			result.DataSource = d.GetResourceName()
+		}
+	}
+
+	// resources with duplicate names are not registered in the provider (see createTerraformProviderResourceMapAndDataSourceInstanceMap)
+	for resourceName, duplicatePaths := range resourcePaths {
+		if len(duplicatePaths) < 2 {
+			continue
+		}
+		for _, pathName := range duplicatePaths {
+			result := results[pathName]
+			result.Resource, result.DataSourceInstance = "", ""
+			result.ResourceRejection = fmt.Sprintf("duplicate resource name '%s' (paths: %s)", resourceName, strings.Join(duplicatePaths, ", "))
+			for _, rootResult := range results {
+				if rootResult.ResourceRootPathOf == resourceName {
+					rootResult.ResourceRootPathOf = ""
+				}
+			}
+		}
+	}
+
+	report := &SpecLintReport{Paths: []SpecPathLintResult{}, Warnings: specAnalyser.lintExtensions()}
+	for _, pathName := range pathNames {
+		result := results[pathName]
+		if result.ResourceRootPathOf != "" {
+			result.ResourceRejection = ""
+		}
+		if result.Resource != "" || result.ResourceRootPathOf != "" || result.DataSource != "" {
+			result.Extensions = specAnalyser.getPathItemExtensions(paths[pathName])
+		}
+		report.Paths = append(report.Paths, *result)
+	}
+	return report
+}
+
+// lintResource returns the resource exposed by the given path following the same logic as GetTerraformCompliantResources,
+// or the reason why the path is not exposed as a resource (including the resources excluded with the
+// 'x-terraform-exclude-resource' extension, which are not registered in the provider)
+func (specAnalyser *specV2Analyser) lintResource(pathName string, globalPathParameters SpecGlobalPathParameters, globalPathParametersErr error) (*SpecV2Resource, string, error) {
+	if globalPathParametersErr != nil {
+		return nil, "", globalPathParametersErr
+	}
+	r, resourceRootPath, err := specAnalyser.getTerraformCompliantResource(pathName, globalPathParameters.getNames())
+	if err != nil {
+		return nil, "", err
+	}
+	if r.ShouldIgnoreResource() {
+		return nil, "", fmt.Errorf("resource '%s' is excluded with the '%s' extension", r.GetResourceName(), extTfExcludeResource)
+	}
+	return r, resourceRootPath, nil
+}
+
+// getPathItemExtensions returns the known extensions applied in the given path item, including the ones in its
+// operations, parameters, responses and schemas. The extensions defined at the path item level are located at 'path'
+func (specAnalyser *specV2Analyser) getPathItemExtensions(pathItem spec.PathItem) []SpecExtensionUsage {
+	extensions := []SpecExtensionUsage{}
+	walkPathItemExtensions("", pathItem, func(location, name string, value interface{}) {
+		if location == "" {
+			location = "path"
+		}
+		if isKnownExtension(name) {
+			extensions = append(extensions, SpecExtensionUsage{Location: location, Name: name, Value: value})
+		}
+	})
+	sortSpecExtensionUsages(extensions)
+	return extensions
+}
+
+// lintExtensions returns the warnings for all the unknown extensions found in the original OpenAPI document
+func (specAnalyser *specV2Analyser) lintExtensions() []SpecLintWarning {
+	warnings := []SpecLintWarning{}
+	visit := func(location, name string, value interface{}) {
+		if warning := checkExtension(location, name); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	origSpec := specAnalyser.d.OrigSpec()
+	walkExtensions("", origSpec.Extensions, visit)
+	for name, securityScheme := range origSpec.SecurityDefinitions {
+		walkExtensions(joinLocation("securityDefinitions", name), securityScheme.Extensions, visit)
+	}
+	for name, parameter := range origSpec.Parameters {
+		walkParameterExtensions(joinLocation("parameters", name), parameter, visit)
+	}
+	for name, response := range origSpec.Responses {
+		walkResponseExtensions(joinLocation("responses", name), response, visit)
+	}
+	for name, definition := range origSpec.Definitions {
+		walkSchemaExtensions(joinLocation("definitions", name), &definition, visit)
+	}
+	if origSpec.Paths != nil {
+		for pathName, pathItem := range origSpec.Paths.Paths {
+			walkPathItemExtensions(joinLocation("paths", pathName), pathItem, visit)
+		}
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Location != warnings[j].Location {
+			return warnings[i].Location < warnings[j].Location
+		}
+		return warnings[i].Extension < warnings[j].Extension
+	})
+	return warnings
+}
+
+type extensionVisitor func(location, name string, value interface{})
+
+func joinLocation(location string, elements ...string) string {
+	for _, element := range elements {
+		if location == "" {
+			location = element
+		} else {
+			location = fmt.Sprintf("%s.%s", location, element)
+		}
+	}
+	return location
+}
+
+func walkExtensions(location string, extensions spec.Extensions, visit extensionVisitor) {
+	for name, value := range extensions {
+		if strings.HasPrefix(strings.ToLower(name), "x-") {
+			visit(location, name, value)
+		}
+	}
+}
+
+func walkPathItemExtensions(location string, pathItem spec.PathItem, visit extensionVisitor) {
+	walkExtensions(location, pathItem.Extensions, visit)
+	for _, parameter := range pathItem.Parameters {
+		walkParameterExtensions(joinLocation(location, "parameters", parameter.Name), parameter, visit)
+	}
+	operations := map[string]*spec.Operation{
+		"get":    pathItem.Get,
+		"post":   pathItem.Post,
+		"put":    pathItem.Put,
+		"patch":  pathItem.Patch,
+		"delete": pathItem.Delete,
+	}
+	for method, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		operationLocation := joinLocation(location, method)
+		walkExtensions(operationLocation, operation.Extensions, visit)
+		for _, parameter := range operation.Parameters {
+			walkParameterExtensions(joinLocation(operationLocation, "parameters", parameter.Name), parameter, visit)
+		}
+		if operation.Responses == nil {
+			continue
+		}
+		for statusCode, response := range operation.Responses.StatusCodeResponses {
+			walkResponseExtensions(joinLocation(operationLocation, "responses", fmt.Sprintf("%d", statusCode)), response, visit)
+		}
+		if operation.Responses.Default != nil {
+			walkResponseExtensions(joinLocation(operationLocation, "responses", "default"), *operation.Responses.Default, visit)
+		}
+	}
+}
+
+func walkParameterExtensions(location string, parameter spec.Parameter, visit extensionVisitor) {
+	walkExtensions(location, parameter.Extensions, visit)
+	walkSchemaExtensions(joinLocation(location, "schema"), parameter.Schema, visit)
+}
+
+func walkResponseExtensions(location string, response spec.Response, visit extensionVisitor) {
+	walkExtensions(location, response.Extensions, visit)
+	walkSchemaExtensions(joinLocation(location, "schema"), response.Schema, visit)
+}
+
+func walkSchemaExtensions(location string, schema *spec.Schema, visit extensionVisitor) {
+	if schema == nil {
+		return
+	}
+	walkExtensions(location, schema.Extensions, visit)
+	for name, property := range schema.Properties {
+		walkSchemaExtensions(joinLocation(location, "properties", name), &property, visit)
+	}
+	if schema.Items != nil {
+		walkSchemaExtensions(joinLocation(location, "items"), schema.Items.Schema, visit)
+	}
+	for i, allOf := range schema.AllOf {
+		walkSchemaExtensions(joinLocation(location, "allOf", fmt.Sprintf("%d", i)), &allOf, visit)
+	}
+}
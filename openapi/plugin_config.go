@@ -2,10 +2,13 @@ package openapi
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
 	"gopkg.in/yaml.v2"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"log"
 	"os"
@@ -31,6 +34,9 @@ type PluginConfiguration struct {
 	// the former takes preference. This allows the user to override the url specified in the configuration file with
 	// the value provided in the OTF_VAR_<provider_name>_SWAGGER_URL
 	Configuration io.Reader
+	// EmbeddedFS defines the files compiled into the provider binary (the plugin configuration file and the OpenAPI documents
+	// referenced from it). If set, the swagger-url of the service configuration can be a path to a document in EmbeddedFS
+	EmbeddedFS fs.FS
 }
 
 // NewPluginConfiguration creates a new PluginConfiguration
@@ -56,6 +62,28 @@ func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	}, nil
 }
 
+// newEmbeddedPluginConfiguration creates a new PluginConfiguration that reads the plugin configuration file from the files
+// compiled into the provider binary. The configuration file is optional since the OTF_VAR_<provider_name>_SWAGGER_URL
+// environment variable can still be used to configure the swagger URL.
+func newEmbeddedPluginConfiguration(providerName string, embeddedFS fs.FS) (*PluginConfiguration, error) {
+	var configurationFile io.Reader
+	configurationFileContent, err := fs.ReadFile(embeddedFS, OpenAPIPluginConfigurationFileName)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		log.Printf("[INFO] open api plugin configuration not embedded in the provider binary")
+	case err != nil:
+		return nil, fmt.Errorf("failed to read the embedded %s configuration file: %s", OpenAPIPluginConfigurationFileName, err)
+	default:
+		log.Printf("[INFO] found open api plugin configuration embedded in the provider binary")
+		configurationFile = bytes.NewReader(configurationFileContent)
+	}
+	return &PluginConfiguration{
+		ProviderName:  providerName,
+		Configuration: configurationFile,
+		EmbeddedFS:    embeddedFS,
+	}, nil
+}
+
 func getPluginConfigurationPath(providerName string) (string, error) {
 	pluginConfigurationFileEnvVar := fmt.Sprintf(otfVarPluginConfigurationFile, providerName)
 	pluginConfigurationFileEnvVars := []string{pluginConfigurationFileEnvVar, strings.ToUpper(pluginConfigurationFileEnvVar)}
@@ -123,6 +151,10 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 			if err != nil {
 				return nil, fmt.Errorf("error occurred when getting service configuration from plugin configuration file %s - error = %s", OpenAPIPluginConfigurationFileName, err)
 			}
+			// the swagger-url configured in the embedded plugin configuration can point at the embedded OpenAPI documents
+			if serviceConfigV1, ok := serviceConfig.(*ServiceConfigV1); ok {
+				serviceConfigV1.embeddedFS = p.EmbeddedFS
+			}
 		}
 	}
 
@@ -3,8 +3,10 @@ package openapi
 import (
 	"fmt"
 	"github.com/asaskevich/govalidator"
+	"io/fs"
 	"log"
 	"os"
+	"path"
 )
 
 // ServiceConfiguration defines the interface/expected behaviour for ServiceConfiguration implementations.
@@ -20,6 +22,27 @@ type ServiceConfiguration interface {
 	Validate() error
 	// GetTelemetryConfiguration returns the telemetry configuration for this service provider
 	GetTelemetryConfiguration() TelemetryProvider
+	// GetTLSConfiguration returns the TLS configuration of the service provider, empty if not configured
+	GetTLSConfiguration() TLSConfig
+	// GetSwaggerAuthConfiguration returns the authentication configuration for the OpenAPI document requests, nil if not configured
+	GetSwaggerAuthConfiguration() *SwaggerAuthConfig
+	// GetSwaggerCacheConfiguration returns the cache configuration for the OpenAPI document, nil if not configured
+	GetSwaggerCacheConfiguration() *SwaggerCacheConfig
+	// GetEmbeddedFS returns the files compiled into the provider binary, nil if there are none
+	GetEmbeddedFS() fs.FS
+	// GetSwaggerIntegrityConfiguration returns the integrity configuration for the OpenAPI document, nil if not configured
+	GetSwaggerIntegrityConfiguration() *SwaggerIntegrityConfig
+	// GetSwaggerDocumentsConfiguration returns the OpenAPI documents merged into the provider, empty if the provider is
+	// configured with a single swagger URL
+	GetSwaggerDocumentsConfiguration() []SwaggerDocumentConfig
+	// GetSwaggerPatchConfiguration returns the operations applied to the OpenAPI document, empty if there are none
+	GetSwaggerPatchConfiguration() []SwaggerPatchOperation
+	// GetResourcesConfiguration returns the configuration of the resources exposed by the provider, nil if not configured
+	GetResourcesConfiguration() *ResourcesConfig
+	// GetDataSourcesConfiguration returns the configuration of the data sources exposed by the provider, nil if not configured
+	GetDataSourcesConfiguration() *DataSourcesConfig
+	// GetRegionsConfiguration returns the regions configuration of the service provider, nil if not configured
+	GetRegionsConfiguration() *RegionsConfig
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -34,13 +57,34 @@ type TelemetryConfig struct {
 type ServiceConfigV1 struct {
 	// SwaggerURL defines where the swagger is located
 	SwaggerURL string `yaml:"swagger-url"`
+	// SwaggerDocuments defines the list of swaggers merged into the provider, instead of a single swagger URL
+	SwaggerDocuments []SwaggerDocumentConfig `yaml:"swagger_documents,omitempty"`
 	// InsecureSkipVerify defines whether the internal http client used to fetch the swagger file should verify the server cert
 	// or not. This should only be used purposefully if the server is using a self-signed cert and only if the server is trusted
 	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+	// TLSConfig defines the client certificate and CA bundle used by the provider's HTTP clients
+	TLSConfig *TLSConfig `yaml:"tls,omitempty"`
+	// SwaggerAuthConfig defines how the requests made to retrieve the swagger file are authenticated
+	SwaggerAuthConfig *SwaggerAuthConfig `yaml:"swagger_auth,omitempty"`
+	// SwaggerCacheConfig defines whether the swagger file is cached on disk
+	SwaggerCacheConfig *SwaggerCacheConfig `yaml:"swagger_cache,omitempty"`
+	// SwaggerIntegrityConfig defines how the swagger file is verified before the provider is configured with it
+	SwaggerIntegrityConfig *SwaggerIntegrityConfig `yaml:"swagger_integrity,omitempty"`
+	// SwaggerPatch defines the JSON Patch operations applied to the swagger file before the provider is configured with it
+	SwaggerPatch []SwaggerPatchOperation `yaml:"swagger_patch,omitempty"`
+	// ResourcesConfig defines which resources are exposed by the provider, their names and timeouts
+	ResourcesConfig *ResourcesConfig `yaml:"resources,omitempty"`
+	// DataSourcesConfig defines which data sources are exposed by the provider and their names
+	DataSourcesConfig *DataSourcesConfig `yaml:"data_sources,omitempty"`
+	// RegionsConfig defines the regions of a multi-region service, their hosts and how they are discovered
+	RegionsConfig *RegionsConfig `yaml:"regions,omitempty"`
 	// SchemaConfigurationV1 represents the list of schema property configurations
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
 
 	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+
+	// embeddedFS contains the OpenAPI documents compiled into the provider binary, nil if there are none
+	embeddedFS fs.FS
 }
 
 // NewServiceConfigV1 creates a new instance of NewServiceConfigV1 struct with the values provided
@@ -53,17 +97,76 @@ func NewServiceConfigV1(swaggerURL string, insecureSkipVerifyEnabled bool, telem
 	}
 }
 
-// GetSwaggerURL returns the URL where the service swagger doc is exposed
+// GetSwaggerURL returns the URL where the service swagger doc is exposed. If the service is configured with a list of
+// swagger documents, the URL of the first document is returned.
 func (s *ServiceConfigV1) GetSwaggerURL() string {
+	if s.SwaggerURL == "" && len(s.SwaggerDocuments) > 0 {
+		return s.SwaggerDocuments[0].SwaggerURL
+	}
 	return s.SwaggerURL
 }
 
+// GetSwaggerDocumentsConfiguration returns the swagger documents merged into the provider, empty if the service is
+// configured with a single swagger URL
+func (s *ServiceConfigV1) GetSwaggerDocumentsConfiguration() []SwaggerDocumentConfig {
+	return s.SwaggerDocuments
+}
+
 // IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
 // otherwise
 func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetTLSConfiguration returns the TLS configuration of the service provider, empty if not configured
+func (s *ServiceConfigV1) GetTLSConfiguration() TLSConfig {
+	if s.TLSConfig == nil {
+		return TLSConfig{}
+	}
+	return *s.TLSConfig
+}
+
+// GetSwaggerAuthConfiguration returns the authentication configuration for the requests made to retrieve the swagger file,
+// nil if not configured
+func (s *ServiceConfigV1) GetSwaggerAuthConfiguration() *SwaggerAuthConfig {
+	return s.SwaggerAuthConfig
+}
+
+// GetSwaggerCacheConfiguration returns the cache configuration for the swagger file, nil if not configured
+func (s *ServiceConfigV1) GetSwaggerCacheConfiguration() *SwaggerCacheConfig {
+	return s.SwaggerCacheConfig
+}
+
+// GetSwaggerIntegrityConfiguration returns the integrity configuration for the swagger file, nil if not configured
+func (s *ServiceConfigV1) GetSwaggerIntegrityConfiguration() *SwaggerIntegrityConfig {
+	return s.SwaggerIntegrityConfig
+}
+
+// GetSwaggerPatchConfiguration returns the JSON Patch operations applied to the swagger file, empty if not configured
+func (s *ServiceConfigV1) GetSwaggerPatchConfiguration() []SwaggerPatchOperation {
+	return s.SwaggerPatch
+}
+
+// GetResourcesConfiguration returns the configuration of the resources exposed by the provider, nil if not configured
+func (s *ServiceConfigV1) GetResourcesConfiguration() *ResourcesConfig {
+	return s.ResourcesConfig
+}
+
+// GetDataSourcesConfiguration returns the configuration of the data sources exposed by the provider, nil if not configured
+func (s *ServiceConfigV1) GetDataSourcesConfiguration() *DataSourcesConfig {
+	return s.DataSourcesConfig
+}
+
+// GetRegionsConfiguration returns the regions configuration of the service provider, nil if not configured
+func (s *ServiceConfigV1) GetRegionsConfiguration() *RegionsConfig {
+	return s.RegionsConfig
+}
+
+// GetEmbeddedFS returns the files compiled into the provider binary, nil if there are none
+func (s *ServiceConfigV1) GetEmbeddedFS() fs.FS {
+	return s.embeddedFS
+}
+
 // GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite or HTTPEndpoint
 func (s *ServiceConfigV1) GetTelemetryConfiguration() TelemetryProvider {
 	if s.TelemetryConfig != nil {
@@ -109,10 +212,77 @@ func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName stri
 
 // Validate makes sure the configuration is valid:
 func (s *ServiceConfigV1) Validate() error {
-	if !govalidator.IsURL(s.SwaggerURL) {
+	if len(s.SwaggerDocuments) > 0 {
+		if s.SwaggerURL != "" {
+			return fmt.Errorf("service configuration not valid, 'swagger-url' and 'swagger_documents' can not be configured at the same time")
+		}
+		if s.SwaggerIntegrityConfig != nil && s.SwaggerIntegrityConfig.SHA256 != "" {
+			return fmt.Errorf("service configuration not valid, the 'swagger_integrity' checksum must be configured per swagger document when 'swagger_documents' is configured")
+		}
+		if len(s.SwaggerPatch) > 0 {
+			return fmt.Errorf("service configuration not valid, the 'swagger_patch' must be configured per swagger document when 'swagger_documents' is configured")
+		}
+		for _, swaggerDocument := range s.SwaggerDocuments {
+			if err := s.validateSwaggerURL(swaggerDocument.SwaggerURL); err != nil {
+				return err
+			}
+			if err := swaggerDocument.Validate(); err != nil {
+				return err
+			}
+		}
+	} else if err := s.validateSwaggerURL(s.SwaggerURL); err != nil {
+		return err
+	}
+	if s.SwaggerAuthConfig != nil {
+		if err := s.SwaggerAuthConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.SwaggerCacheConfig != nil {
+		if err := s.SwaggerCacheConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.SwaggerIntegrityConfig != nil {
+		if err := s.SwaggerIntegrityConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateSwaggerPatch(s.SwaggerPatch); err != nil {
+		return err
+	}
+	for _, schemaPropertyConfig := range s.SchemaConfigurationV1 {
+		if err := schemaPropertyConfig.Validate(); err != nil {
+			return fmt.Errorf("schema configuration not valid, %s", err)
+		}
+	}
+	if s.ResourcesConfig != nil {
+		if err := s.ResourcesConfig.Validate(); err != nil {
+			return fmt.Errorf("resources configuration not valid, %s", err)
+		}
+	}
+	if s.DataSourcesConfig != nil {
+		if err := s.DataSourcesConfig.Validate(); err != nil {
+			return fmt.Errorf("data sources configuration not valid, %s", err)
+		}
+	}
+	if s.RegionsConfig != nil {
+		if err := s.RegionsConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ServiceConfigV1) validateSwaggerURL(swaggerURL string) error {
+	if s.embeddedFS != nil && !isURL(swaggerURL) {
+		if _, err := fs.Stat(s.embeddedFS, path.Clean(swaggerURL)); err != nil {
+			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to a swagger file embedded in the provider binary", swaggerURL)
+		}
+	} else if !govalidator.IsURL(swaggerURL) {
 		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
-		if _, err := os.Stat(s.SwaggerURL); os.IsNotExist(err) {
-			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.SwaggerURL)
+		if _, err := os.Stat(swaggerURL); os.IsNotExist(err) {
+			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", swaggerURL)
 		}
 	}
 	return nil
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RegionsConfig defines the regions of a multi-region service, which take precedence over the ones defined in the
+// OpenAPI document. The regions can be configured explicitly with their hosts and/or discovered when the plugin starts.
+type RegionsConfig struct {
+	// Hosts defines the host (and optionally the base path and scheme) of each region keyed by the region name
+	Hosts map[string]RegionHostConfig `yaml:"hosts,omitempty"`
+	// Default defines the region used when the region is not configured in the provider. If not specified, the first
+	// region is used.
+	Default string `yaml:"default,omitempty"`
+	// DiscoveryURL defines the endpoint the regions are retrieved from when the plugin starts, it overrides the
+	// 'x-terraform-provider-regions-endpoint' extension
+	DiscoveryURL string `yaml:"discovery_url,omitempty"`
+}
+
+// RegionHostConfig defines the host the API calls are made against for a given region
+type RegionHostConfig struct {
+	// Host defines the host of the region (e,g: api.rst.example.com)
+	Host string `yaml:"host"`
+	// BasePath defines the base path of the region, the base path of the OpenAPI document is used if not specified
+	BasePath string `yaml:"base_path,omitempty"`
+	// Scheme defines the scheme of the region (http or https), the scheme of the OpenAPI document is used if not specified
+	Scheme string `yaml:"scheme,omitempty"`
+}
+
+// Validate makes sure the regions have a host with a supported scheme and the discovery URL is a URL
+func (c RegionsConfig) Validate() error {
+	for _, region := range c.getRegionNames() {
+		regionHost := c.Hosts[region]
+		if regionHost.Host == "" {
+			return fmt.Errorf("regions configuration not valid, region '%s' is missing the host", region)
+		}
+		if regionHost.Scheme != "" && regionHost.Scheme != "http" && regionHost.Scheme != "https" {
+			return fmt.Errorf("regions configuration not valid, region '%s' scheme '%s' not supported - must use http or https", region, regionHost.Scheme)
+		}
+	}
+	if c.DiscoveryURL != "" && !isURL(c.DiscoveryURL) {
+		return fmt.Errorf("regions configuration not valid, 'discovery_url' must be a URL (%s)", c.DiscoveryURL)
+	}
+	return nil
+}
+
+// getRegionNames returns the names of the regions configured with a host, sorted by name
+func (c RegionsConfig) getRegionNames() []string {
+	regions := []string{}
+	for region := range c.Hosts {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// getRegionHosts returns the hosts of the regions in the format of the 'x-terraform-provider-region-hosts' extension
+func (c RegionsConfig) getRegionHosts() map[string]specRegionHost {
+	regionHosts := map[string]specRegionHost{}
+	for region, regionHost := range c.Hosts {
+		regionHosts[region] = specRegionHost{Host: regionHost.Host, BasePath: regionHost.BasePath, Scheme: regionHost.Scheme}
+	}
+	return regionHosts
+}
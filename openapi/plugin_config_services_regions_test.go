@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionsConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        RegionsConfig
+		expectedError string
+	}{
+		{
+			name: "valid configuration",
+			config: RegionsConfig{
+				Hosts:        map[string]RegionHostConfig{"rst1": {Host: "rst.api.domain.com"}, "dub1": {Host: "dub.api.domain.com", BasePath: "/v2", Scheme: "http"}},
+				Default:      "dub1",
+				DiscoveryURL: "https://api.domain.com/v1/regions",
+			},
+		},
+		{
+			name:          "region without host",
+			config:        RegionsConfig{Hosts: map[string]RegionHostConfig{"rst1": {BasePath: "/v2"}}},
+			expectedError: "regions configuration not valid, region 'rst1' is missing the host",
+		},
+		{
+			name:          "region scheme not supported",
+			config:        RegionsConfig{Hosts: map[string]RegionHostConfig{"rst1": {Host: "rst.api.domain.com", Scheme: "ftp"}}},
+			expectedError: "regions configuration not valid, region 'rst1' scheme 'ftp' not supported - must use http or https",
+		},
+		{
+			name:          "discovery URL not valid",
+			config:        RegionsConfig{DiscoveryURL: "/v1/regions"},
+			expectedError: "regions configuration not valid, 'discovery_url' must be a URL (/v1/regions)",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.config.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestGetRegionsConfiguration(t *testing.T) {
+	regionsConfig := &RegionsConfig{Default: "rst1"}
+	assert.Equal(t, regionsConfig, (&ServiceConfigV1{RegionsConfig: regionsConfig}).GetRegionsConfiguration())
+	assert.Nil(t, (&ServiceConfigV1{}).GetRegionsConfiguration())
+	assert.Equal(t, regionsConfig, (&ServiceConfigStub{Regions: regionsConfig}).GetRegionsConfiguration())
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+// DataSourcesConfig defines which data sources are exposed by the provider and the names they are exposed with. The
+// names are the ones derived from the OpenAPI document, without the provider name prefix (e,g: cdns_v1).
+type DataSourcesConfig struct {
+	// Include defines the glob patterns (e,g: cdns_*) the names of the exposed data sources must match, all the data
+	// sources are exposed if empty
+	Include []string `yaml:"include,omitempty"`
+	// Exclude defines the glob patterns the names of the data sources that are not exposed match, it takes precedence over Include
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Renames defines the names the data sources are exposed with, keyed by the name derived from the OpenAPI document
+	Renames map[string]string `yaml:"renames,omitempty"`
+}
+
+// ResourcesConfig defines which resources (and their data source instances) are exposed by the provider, the names they
+// are exposed with and their timeouts
+type ResourcesConfig struct {
+	DataSourcesConfig `yaml:",inline"`
+	// Timeouts defines the timeouts of the resources, keyed by the name derived from the OpenAPI document. They take
+	// precedence over the x-terraform-resource-timeout extensions.
+	Timeouts map[string]ResourceTimeoutsConfig `yaml:"timeouts,omitempty"`
+}
+
+// ResourceTimeoutsConfig defines the timeouts of a resource's operations (e,g: 30s, 10m, 1h)
+type ResourceTimeoutsConfig struct {
+	Create string `yaml:"create,omitempty"`
+	Read   string `yaml:"read,omitempty"`
+	Update string `yaml:"update,omitempty"`
+	Delete string `yaml:"delete,omitempty"`
+}
+
+// Validate makes sure the glob patterns are well formed and the resources are renamed to Terraform compliant unique names
+func (c DataSourcesConfig) Validate() error {
+	for _, pattern := range append(append([]string{}, c.Include...), c.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("the pattern '%s' is not a valid glob pattern", pattern)
+		}
+	}
+	renamed := map[string]string{}
+	for name, newName := range c.Renames {
+		if newName == "" || terraformutils.ConvertToTerraformCompliantName(newName) != newName {
+			return fmt.Errorf("'%s' can not be renamed to '%s', the name must be terraform name compliant (e,g: snake_case)", name, newName)
+		}
+		if otherName, exists := renamed[newName]; exists {
+			return fmt.Errorf("both '%s' and '%s' are renamed to '%s'", otherName, name, newName)
+		}
+		renamed[newName] = name
+	}
+	return nil
+}
+
+// getRenamedNames returns the names the renames are configured for
+func (c DataSourcesConfig) getRenamedNames() []string {
+	names := []string{}
+	for name := range c.Renames {
+		names = append(names, name)
+	}
+	return names
+}
+
+// getTimeoutsNames returns the names the timeouts are configured for
+func (c ResourcesConfig) getTimeoutsNames() []string {
+	names := []string{}
+	for name := range c.Timeouts {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Validate makes sure the data sources configuration is valid as well as the timeouts
+func (c ResourcesConfig) Validate() error {
+	if err := c.DataSourcesConfig.Validate(); err != nil {
+		return err
+	}
+	for name, timeouts := range c.Timeouts {
+		if _, err := timeouts.getTimeouts(); err != nil {
+			return fmt.Errorf("the timeouts of '%s' are not valid: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// isExposed returns true if the given name matches the include patterns (if any) and does not match the exclude patterns
+func (c *DataSourcesConfig) isExposed(name string) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.Include) > 0 && !matchesAnyGlobPattern(c.Include, name) {
+		return false
+	}
+	return !matchesAnyGlobPattern(c.Exclude, name)
+}
+
+// getName returns the name the given resource (or data source) is exposed with
+func (c *DataSourcesConfig) getName(name string) string {
+	if c == nil {
+		return name
+	}
+	if newName, renamed := c.Renames[name]; renamed {
+		return newName
+	}
+	return name
+}
+
+func matchesAnyGlobPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getTimeouts returns the configured timeouts, the ones not configured are nil
+func (c ResourceTimeoutsConfig) getTimeouts() (*specTimeouts, error) {
+	timeouts := &specTimeouts{}
+	for _, timeout := range []struct {
+		name     string
+		value    string
+		duration **time.Duration
+	}{
+		{"create", c.Create, &timeouts.Post},
+		{"read", c.Read, &timeouts.Get},
+		{"update", c.Update, &timeouts.Put},
+		{"delete", c.Delete, &timeouts.Delete},
+	} {
+		if timeout.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(timeout.value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("'%s' timeout '%s' must be a positive duration (e,g: 30s, 10m, 1h)", timeout.name, timeout.value)
+		}
+		*timeout.duration = &duration
+	}
+	return timeouts, nil
+}
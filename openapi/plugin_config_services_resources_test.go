@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        ResourcesConfig
+		expectedError string
+	}{
+		{
+			name: "valid configuration",
+			config: ResourcesConfig{
+				DataSourcesConfig: DataSourcesConfig{Include: []string{"cdns_*"}, Exclude: []string{"cdns_v1_firewalls"}, Renames: map[string]string{"cdns_v1": "cdn"}},
+				Timeouts:          map[string]ResourceTimeoutsConfig{"cdns_v1": {Create: "10m", Delete: "1h"}},
+			},
+		},
+		{
+			name:          "invalid glob pattern",
+			config:        ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Exclude: []string{"cdns_[v1"}}},
+			expectedError: "the pattern 'cdns_[v1' is not a valid glob pattern",
+		},
+		{
+			name:          "rename not terraform compliant",
+			config:        ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Renames: map[string]string{"cdns_v1": "CDN"}}},
+			expectedError: "'cdns_v1' can not be renamed to 'CDN', the name must be terraform name compliant (e,g: snake_case)",
+		},
+		{
+			name:          "empty rename",
+			config:        ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Renames: map[string]string{"cdns_v1": ""}}},
+			expectedError: "'cdns_v1' can not be renamed to '', the name must be terraform name compliant (e,g: snake_case)",
+		},
+		{
+			name:          "timeout not a duration",
+			config:        ResourcesConfig{Timeouts: map[string]ResourceTimeoutsConfig{"cdns_v1": {Read: "ten minutes"}}},
+			expectedError: "the timeouts of 'cdns_v1' are not valid: 'read' timeout 'ten minutes' must be a positive duration (e,g: 30s, 10m, 1h)",
+		},
+		{
+			name:          "negative timeout",
+			config:        ResourcesConfig{Timeouts: map[string]ResourceTimeoutsConfig{"cdns_v1": {Update: "-5m"}}},
+			expectedError: "the timeouts of 'cdns_v1' are not valid: 'update' timeout '-5m' must be a positive duration (e,g: 30s, 10m, 1h)",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.config.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestDataSourcesConfigValidateDuplicatedRenames(t *testing.T) {
+	err := DataSourcesConfig{Renames: map[string]string{"cdns_v1": "cdn", "cdns_v2": "cdn"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, []string{"both 'cdns_v1' and 'cdns_v2' are renamed to 'cdn'", "both 'cdns_v2' and 'cdns_v1' are renamed to 'cdn'"}, err.Error())
+}
+
+func TestDataSourcesConfigIsExposed(t *testing.T) {
+	testCases := []struct {
+		name            string
+		config          *DataSourcesConfig
+		resourceName    string
+		expectedExposed bool
+	}{
+		{name: "no configuration", config: nil, resourceName: "cdns_v1", expectedExposed: true},
+		{name: "no patterns", config: &DataSourcesConfig{}, resourceName: "cdns_v1", expectedExposed: true},
+		{name: "included", config: &DataSourcesConfig{Include: []string{"lbs_*", "cdns_*"}}, resourceName: "cdns_v1", expectedExposed: true},
+		{name: "not included", config: &DataSourcesConfig{Include: []string{"lbs_*"}}, resourceName: "cdns_v1", expectedExposed: false},
+		{name: "excluded", config: &DataSourcesConfig{Exclude: []string{"*_firewalls"}}, resourceName: "cdns_v1_firewalls", expectedExposed: false},
+		{name: "exclude takes precedence over include", config: &DataSourcesConfig{Include: []string{"cdns_*"}, Exclude: []string{"cdns_v1_firewalls"}}, resourceName: "cdns_v1_firewalls", expectedExposed: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedExposed, tc.config.isExposed(tc.resourceName), tc.name)
+	}
+}
+
+func TestDataSourcesConfigGetName(t *testing.T) {
+	var config *DataSourcesConfig
+	assert.Equal(t, "cdns_v1", config.getName("cdns_v1"))
+	config = &DataSourcesConfig{Renames: map[string]string{"cdns_v1": "cdn"}}
+	assert.Equal(t, "cdn", config.getName("cdns_v1"))
+	assert.Equal(t, "lbs_v1", config.getName("lbs_v1"))
+}
+
+func TestResourceTimeoutsConfigGetTimeouts(t *testing.T) {
+	timeouts, err := ResourceTimeoutsConfig{Create: "10m", Delete: "1h"}.getTimeouts()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, *timeouts.Post)
+	assert.Nil(t, timeouts.Get)
+	assert.Nil(t, timeouts.Put)
+	assert.Equal(t, time.Hour, *timeouts.Delete)
+}
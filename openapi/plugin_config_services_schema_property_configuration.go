@@ -8,6 +8,7 @@ import (
 	"github.com/oliveagle/jsonpath"
 	"log"
 	"os/exec"
+	"regexp"
 	"time"
 )
 
@@ -15,6 +16,10 @@ import (
 type ServiceSchemaPropertyConfiguration interface {
 	GetDefaultValue() (string, error)
 	ExecuteCommand() error
+	// GetCredentialProcess returns the credential process configured for the property, nil if there is none
+	GetCredentialProcess() *CredentialProcess
+	// GetAttributes returns the given attributes of the property overridden by the configured ones
+	GetAttributes(attributes ProviderPropertyAttributes) ProviderPropertyAttributes
 }
 
 const cmdTimeout = 10
@@ -28,6 +33,44 @@ type ServiceSchemaPropertyConfigurationV1 struct {
 	Command               []string                                     `yaml:"cmd,flow,omitempty"`
 	CommandTimeout        int                                          `yaml:"cmd_timeout,omitempty"`
 	ExternalConfiguration ServiceSchemaPropertyExternalConfigurationV1 `yaml:"schema_property_external_configuration,omitempty"`
+	// CredentialProcess defines the command executed to obtain the value of the property when the provider needs it (if
+	// the user did not provide one). The command must print to stdout a JSON object like {"value": "...", "expires_at": "2020-01-01T00:00:00Z"}
+	// where expires_at is optional and is the time (RFC3339) after which the command will be executed again
+	CredentialProcess []string `yaml:"credential_process,flow,omitempty"`
+	// Type defines the type of the property (string, integer or boolean), it takes precedence over the type defined in the OpenAPI document
+	Type string `yaml:"type,omitempty"`
+	// Sensitive defines whether the property holds a secret, it takes precedence over the OpenAPI document (the security
+	// definition properties are sensitive by default)
+	Sensitive *bool `yaml:"sensitive,omitempty"`
+	// Description describes the property, it takes precedence over the description defined in the OpenAPI document
+	Description string `yaml:"description,omitempty"`
+	// AllowedValues, Pattern, Minimum and Maximum define the validation of the property values, they take precedence over
+	// the validation defined in the OpenAPI document
+	AllowedValues []string `yaml:"allowed_values,flow,omitempty"`
+	Pattern       string   `yaml:"pattern,omitempty"`
+	Minimum       *float64 `yaml:"minimum,omitempty"`
+	Maximum       *float64 `yaml:"maximum,omitempty"`
+}
+
+// providerPropertyTypes contains the types supported for the provider properties keyed by the name used in the plugin configuration
+var providerPropertyTypes = map[string]schemaDefinitionPropertyType{
+	"string":  TypeString,
+	"integer": TypeInt,
+	"int":     TypeInt,
+	"boolean": TypeBool,
+	"bool":    TypeBool,
+}
+
+// GetProviderPropertyAttributes returns the given attributes of the provider property overridden by the ones configured
+// for the property in the given service configuration (if any)
+func GetProviderPropertyAttributes(serviceConfiguration ServiceConfiguration, schemaPropertyName string, attributes ProviderPropertyAttributes) ProviderPropertyAttributes {
+	if serviceConfiguration == nil {
+		return attributes
+	}
+	if schemaPropertyConfiguration := serviceConfiguration.GetSchemaPropertyConfiguration(schemaPropertyName); schemaPropertyConfiguration != nil {
+		return schemaPropertyConfiguration.GetAttributes(attributes)
+	}
+	return attributes
 }
 
 // ServiceSchemaPropertyExternalConfigurationV1 defines the external configuration for a provider property.
@@ -66,6 +109,57 @@ func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error)
 	return s.DefaultValue, nil
 }
 
+// GetCredentialProcess returns the CredentialProcess configured in the 'credential_process' field (using the 'cmd_timeout'
+// if set), nil if the field is not set
+func (s ServiceSchemaPropertyConfigurationV1) GetCredentialProcess() *CredentialProcess {
+	if len(s.CredentialProcess) == 0 {
+		return nil
+	}
+	timeout := cmdTimeout
+	if s.CommandTimeout > 0 {
+		timeout = s.CommandTimeout
+	}
+	return &CredentialProcess{Command: s.CredentialProcess, Timeout: time.Duration(timeout) * time.Second}
+}
+
+// GetAttributes returns the given attributes of the property overridden by the ones configured in the 'type', 'sensitive',
+// 'description', 'allowed_values', 'pattern', 'minimum' and 'maximum' fields
+func (s ServiceSchemaPropertyConfigurationV1) GetAttributes(attributes ProviderPropertyAttributes) ProviderPropertyAttributes {
+	if propertyType, exists := providerPropertyTypes[s.Type]; exists {
+		attributes.Type = propertyType
+	}
+	if s.Sensitive != nil {
+		attributes.Sensitive = *s.Sensitive
+	}
+	if s.Description != "" {
+		attributes.Description = s.Description
+	}
+	if len(s.AllowedValues) > 0 {
+		attributes.AllowedValues = s.AllowedValues
+	}
+	if s.Pattern != "" {
+		attributes.Pattern = s.Pattern
+	}
+	if s.Minimum != nil {
+		attributes.Minimum = s.Minimum
+	}
+	if s.Maximum != nil {
+		attributes.Maximum = s.Maximum
+	}
+	return attributes
+}
+
+// Validate makes sure the type is supported and the pattern is a valid regular expression
+func (s ServiceSchemaPropertyConfigurationV1) Validate() error {
+	if _, exists := providerPropertyTypes[s.Type]; s.Type != "" && !exists {
+		return fmt.Errorf("'%s' type '%s' is not supported, the type must be one of string, integer or boolean", s.SchemaPropertyName, s.Type)
+	}
+	if _, err := regexp.Compile(s.Pattern); err != nil {
+		return fmt.Errorf("'%s' pattern '%s' is not a valid regular expression: %s", s.SchemaPropertyName, s.Pattern, err)
+	}
+	return nil
+}
+
 // ExecuteCommand run the 'Command' configured in the ServiceSchemaPropertyConfigurationV1 struct if applicable.
 // - If the command fails to execute the appropriate error will be returned including the error returned by exec
 // - If the command execution does not finish within the expected time (either before CommandTimeout or before the default timeout 10s)
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestServiceSchemaConfigurationV1(t *testing.T) {
@@ -30,6 +31,96 @@ func TestServiceSchemaConfigurationV1(t *testing.T) {
 	})
 }
 
+func TestServiceSchemaConfigurationV1GetCredentialProcess(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a credential process configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+			CredentialProcess:  []string{"vault-token", "--json"},
+		}
+		Convey("When GetCredentialProcess method is called", func() {
+			credentialProcess := serviceSchemaConfigurationV1.GetCredentialProcess()
+			Convey("Then the credential process returned should contain the command and the default timeout", func() {
+				So(credentialProcess, ShouldResemble, &CredentialProcess{Command: []string{"vault-token", "--json"}, Timeout: 10 * time.Second})
+			})
+		})
+		Convey("When GetCredentialProcess method is called and the cmd_timeout is configured", func() {
+			serviceSchemaConfigurationV1.CommandTimeout = 30
+			credentialProcess := serviceSchemaConfigurationV1.GetCredentialProcess()
+			Convey("Then the credential process returned should contain the command and the configured timeout", func() {
+				So(credentialProcess, ShouldResemble, &CredentialProcess{Command: []string{"vault-token", "--json"}, Timeout: 30 * time.Second})
+			})
+		})
+	})
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 without a credential process configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+		}
+		Convey("When GetCredentialProcess method is called", func() {
+			credentialProcess := serviceSchemaConfigurationV1.GetCredentialProcess()
+			Convey("Then the credential process returned should be nil", func() {
+				So(credentialProcess, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestServiceSchemaConfigurationV1GetAttributes(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with the type, sensitivity and validation configured", t, func() {
+		sensitive := false
+		minimum := 1.0
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+			Type:               "int",
+			Sensitive:          &sensitive,
+			Minimum:            &minimum,
+		}
+		Convey("When GetAttributes method is called with the attributes from the OpenAPI document", func() {
+			attributes := serviceSchemaConfigurationV1.GetAttributes(ProviderPropertyAttributes{Sensitive: true, Description: "some description"})
+			Convey("Then the attributes returned should be the ones from the OpenAPI document overridden by the configured ones", func() {
+				So(attributes, ShouldResemble, ProviderPropertyAttributes{Type: TypeInt, Sensitive: false, Description: "some description", Minimum: &minimum})
+			})
+		})
+	})
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 without attributes configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+		}
+		Convey("When GetAttributes method is called with the attributes from the OpenAPI document", func() {
+			attributes := serviceSchemaConfigurationV1.GetAttributes(ProviderPropertyAttributes{Type: TypeBool, Sensitive: true, AllowedValues: []string{"true"}})
+			Convey("Then the attributes returned should be the ones from the OpenAPI document", func() {
+				So(attributes, ShouldResemble, ProviderPropertyAttributes{Type: TypeBool, Sensitive: true, AllowedValues: []string{"true"}})
+			})
+		})
+	})
+}
+
+func TestServiceSchemaConfigurationV1Validate(t *testing.T) {
+	Convey("Given a list of ServiceSchemaPropertyConfigurationV1", t, func() {
+		testCases := []struct {
+			name          string
+			configuration ServiceSchemaPropertyConfigurationV1
+			expectedError string
+		}{
+			{name: "no attributes", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "some_property_name"}},
+			{name: "valid attributes", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "some_property_name", Type: "boolean", Pattern: "^[a-z]+$"}},
+			{name: "unsupported type", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "some_property_name", Type: "number"}, expectedError: "'some_property_name' type 'number' is not supported, the type must be one of string, integer or boolean"},
+			{name: "invalid pattern", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "some_property_name", Pattern: "["}, expectedError: "'some_property_name' pattern '[' is not a valid regular expression: error parsing regexp: missing closing ]: `[`"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When Validate method is called (%s)", tc.name), func() {
+				err := tc.configuration.Validate()
+				Convey("Then the error returned should be the expected one", func() {
+					if tc.expectedError == "" {
+						So(err, ShouldBeNil)
+					} else {
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					}
+				})
+			})
+		}
+	})
+}
+
 func TestServiceSchemaConfigurationV1ExecuteCommand(t *testing.T) {
 	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command (that exists successfully) configured", t, func() {
 		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
@@ -1,5 +1,7 @@
 package openapi
 
+import "io/fs"
+
 // ServiceConfigStub implements the ServiceConfiguration interface and can be used to simplify the creation of the ProviderOpenAPI
 // provider by calling the CreateSchemaProviderWithConfiguration function passing in the stub wit the swagger URL populated
 // with the URL where the openapi doc is hosted.
@@ -7,6 +9,16 @@ type ServiceConfigStub struct {
 	SwaggerURL          string
 	PluginVersion       string
 	InsecureSkipVerify  bool
+	TLS                 TLSConfig
+	SwaggerAuth         *SwaggerAuthConfig
+	SwaggerCache        *SwaggerCacheConfig
+	SwaggerIntegrity    *SwaggerIntegrityConfig
+	SwaggerDocuments    []SwaggerDocumentConfig
+	SwaggerPatch        []SwaggerPatchOperation
+	Resources           *ResourcesConfig
+	DataSources         *DataSourcesConfig
+	Regions             *RegionsConfig
+	EmbeddedFS          fs.FS
 	Telemetry           TelemetryProvider
 	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
 	Err                 error
@@ -20,6 +32,8 @@ type ServiceSchemaPropertyConfigurationStub struct {
 	Err                  error
 	GetDefaultValueFunc  func() (string, error)
 	ExecuteCommandCalled bool
+	CredentialProcess    *CredentialProcess
+	Attributes           *ProviderPropertyAttributes
 }
 
 // GetSwaggerURL returns the swagger URL value configured in the ServiceConfigStub.SwaggerURL field
@@ -32,6 +46,56 @@ func (s *ServiceConfigStub) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetTLSConfiguration returns the TLS configuration configured in the ServiceConfigStub.TLS field
+func (s *ServiceConfigStub) GetTLSConfiguration() TLSConfig {
+	return s.TLS
+}
+
+// GetSwaggerAuthConfiguration returns the swagger auth configuration configured in the ServiceConfigStub.SwaggerAuth field
+func (s *ServiceConfigStub) GetSwaggerAuthConfiguration() *SwaggerAuthConfig {
+	return s.SwaggerAuth
+}
+
+// GetSwaggerCacheConfiguration returns the swagger cache configuration configured in the ServiceConfigStub.SwaggerCache field
+func (s *ServiceConfigStub) GetSwaggerCacheConfiguration() *SwaggerCacheConfig {
+	return s.SwaggerCache
+}
+
+// GetSwaggerIntegrityConfiguration returns the swagger integrity configuration configured in the ServiceConfigStub.SwaggerIntegrity field
+func (s *ServiceConfigStub) GetSwaggerIntegrityConfiguration() *SwaggerIntegrityConfig {
+	return s.SwaggerIntegrity
+}
+
+// GetSwaggerDocumentsConfiguration returns the swagger documents configured in the ServiceConfigStub.SwaggerDocuments field
+func (s *ServiceConfigStub) GetSwaggerDocumentsConfiguration() []SwaggerDocumentConfig {
+	return s.SwaggerDocuments
+}
+
+// GetSwaggerPatchConfiguration returns the swagger patch configured in the ServiceConfigStub.SwaggerPatch field
+func (s *ServiceConfigStub) GetSwaggerPatchConfiguration() []SwaggerPatchOperation {
+	return s.SwaggerPatch
+}
+
+// GetResourcesConfiguration returns the resources configuration configured in the ServiceConfigStub.Resources field
+func (s *ServiceConfigStub) GetResourcesConfiguration() *ResourcesConfig {
+	return s.Resources
+}
+
+// GetDataSourcesConfiguration returns the data sources configuration configured in the ServiceConfigStub.DataSources field
+func (s *ServiceConfigStub) GetDataSourcesConfiguration() *DataSourcesConfig {
+	return s.DataSources
+}
+
+// GetRegionsConfiguration returns the regions configuration configured in the ServiceConfigStub.Regions field
+func (s *ServiceConfigStub) GetRegionsConfiguration() *RegionsConfig {
+	return s.Regions
+}
+
+// GetEmbeddedFS returns the files configured in the ServiceConfigStub.EmbeddedFS field
+func (s *ServiceConfigStub) GetEmbeddedFS() fs.FS {
+	return s.EmbeddedFS
+}
+
 // Validate returns an error if the ServiceConfigStub.Err field is set with an error
 func (s *ServiceConfigStub) Validate() error {
 	return s.Err
@@ -66,3 +130,17 @@ func (s *ServiceSchemaPropertyConfigurationStub) ExecuteCommand() error {
 	s.ExecuteCommandCalled = true
 	return s.Err
 }
+
+// GetCredentialProcess returns the credential process configured in the ServiceSchemaPropertyConfigurationStub.CredentialProcess field
+func (s *ServiceSchemaPropertyConfigurationStub) GetCredentialProcess() *CredentialProcess {
+	return s.CredentialProcess
+}
+
+// GetAttributes returns the attributes configured in the ServiceSchemaPropertyConfigurationStub.Attributes field, the
+// given ones if not set
+func (s *ServiceSchemaPropertyConfigurationStub) GetAttributes(attributes ProviderPropertyAttributes) ProviderPropertyAttributes {
+	if s.Attributes != nil {
+		return *s.Attributes
+	}
+	return attributes
+}
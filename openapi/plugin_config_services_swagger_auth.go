@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// SwaggerAuthConfig defines how the requests made to retrieve the OpenAPI document (and the documents referenced from it
+// that are hosted on the same host) are authenticated
+type SwaggerAuthConfig struct {
+	// Headers defines the headers sent along with the requests (e,g: an API key expected by the API gateway)
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// BearerToken defines where to obtain the token sent in the Authorization header using the Bearer scheme
+	BearerToken *SwaggerBearerTokenConfig `yaml:"bearer_token,omitempty"`
+	// BasicAuth defines the credentials sent in the Authorization header using the Basic scheme
+	BasicAuth *SwaggerBasicAuthConfig `yaml:"basic_auth,omitempty"`
+}
+
+// SwaggerBearerTokenConfig defines the source of the bearer token, only one of the sources can be configured
+type SwaggerBearerTokenConfig struct {
+	// File defines the path to the file containing the token
+	File string `yaml:"file,omitempty"`
+	// Env defines the name of the environment variable containing the token
+	Env string `yaml:"env,omitempty"`
+	// Command defines the command (exec form) that prints the token to stdout
+	Command []string `yaml:"cmd,flow,omitempty"`
+	// CommandTimeout defines the max timeout, in seconds, for the command to execute (10s by default)
+	CommandTimeout int `yaml:"cmd_timeout,omitempty"`
+}
+
+// SwaggerBasicAuthConfig defines the credentials used for HTTP Basic authentication
+type SwaggerBasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate makes sure only one of the Authorization header sources is configured
+func (c SwaggerAuthConfig) Validate() error {
+	if c.BearerToken != nil && c.BasicAuth != nil {
+		return errors.New("swagger auth configuration not valid, only one of 'bearer_token' or 'basic_auth' can be configured")
+	}
+	if c.BearerToken != nil {
+		return c.BearerToken.Validate()
+	}
+	if c.BasicAuth != nil && c.BasicAuth.Username == "" {
+		return errors.New("swagger auth configuration not valid, 'basic_auth' is missing the 'username'")
+	}
+	return nil
+}
+
+// Validate makes sure exactly one source of the token is configured
+func (c SwaggerBearerTokenConfig) Validate() error {
+	sources := 0
+	for _, configured := range []bool{c.File != "", c.Env != "", len(c.Command) > 0} {
+		if configured {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("swagger auth configuration not valid, 'bearer_token' must be configured with one of 'file', 'env' or 'cmd'")
+	}
+	return nil
+}
+
+// getHeaders returns the headers to send along with the OpenAPI document requests, including the Authorization header
+// if a bearer token or basic auth is configured
+func (c SwaggerAuthConfig) getHeaders() (map[string]string, error) {
+	headers := map[string]string{}
+	for name, value := range c.Headers {
+		headers[name] = value
+	}
+	if c.BearerToken != nil {
+		token, err := c.BearerToken.getToken()
+		if err != nil {
+			return nil, err
+		}
+		headers[authorizationHeader] = fmt.Sprintf("%s %s", bearerScheme, token)
+	}
+	if c.BasicAuth != nil {
+		credentials := base64.StdEncoding.EncodeToString([]byte(c.BasicAuth.Username + ":" + c.BasicAuth.Password))
+		headers[authorizationHeader] = fmt.Sprintf("%s %s", basicScheme, credentials)
+	}
+	return headers, nil
+}
+
+// getToken returns the token from the configured source (file, env or command) with the leading and trailing white spaces
+// trimmed. The token is never included in the errors or logs.
+func (c SwaggerBearerTokenConfig) getToken() (string, error) {
+	var token string
+	switch {
+	case c.File != "":
+		content, err := getFileContent(c.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the swagger bearer token file '%s': %s", c.File, err)
+		}
+		token = content
+	case c.Env != "":
+		token = os.Getenv(c.Env)
+		if token == "" {
+			return "", fmt.Errorf("the environment variable '%s' containing the swagger bearer token is not set", c.Env)
+		}
+	case len(c.Command) > 0:
+		timeout := cmdTimeout
+		if c.CommandTimeout > 0 {
+			timeout = c.CommandTimeout
+		}
+		log.Printf("[INFO] executing swagger bearer token command '%s'", c.Command)
+		stdout, err := runCommand(c.Command, time.Duration(timeout)*time.Second)
+		if err != nil {
+			return "", fmt.Errorf("swagger bearer token command '%s' %s", c.Command, err)
+		}
+		token = string(stdout)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("the swagger bearer token is empty")
+	}
+	return token, nil
+}
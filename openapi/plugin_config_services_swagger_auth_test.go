@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerAuthConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        SwaggerAuthConfig
+		expectedError string
+	}{
+		{name: "headers only", config: SwaggerAuthConfig{Headers: map[string]string{"X-Api-Key": "key"}}},
+		{name: "bearer token from env", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_TOKEN"}}},
+		{name: "basic auth", config: SwaggerAuthConfig{BasicAuth: &SwaggerBasicAuthConfig{Username: "user"}}},
+		{name: "bearer token and basic auth", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_TOKEN"}, BasicAuth: &SwaggerBasicAuthConfig{Username: "user"}}, expectedError: "swagger auth configuration not valid, only one of 'bearer_token' or 'basic_auth' can be configured"},
+		{name: "bearer token without source", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{}}, expectedError: "swagger auth configuration not valid, 'bearer_token' must be configured with one of 'file', 'env' or 'cmd'"},
+		{name: "bearer token with multiple sources", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_TOKEN", Command: []string{"echo", "token"}}}, expectedError: "swagger auth configuration not valid, 'bearer_token' must be configured with one of 'file', 'env' or 'cmd'"},
+		{name: "basic auth without username", config: SwaggerAuthConfig{BasicAuth: &SwaggerBasicAuthConfig{Password: "password"}}, expectedError: "swagger auth configuration not valid, 'basic_auth' is missing the 'username'"},
+	}
+	for _, tc := range testCases {
+		err := tc.config.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestSwaggerAuthConfigGetHeaders(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "swagger_token")
+	require.NoError(t, err)
+	defer os.Remove(tokenFile.Name())
+	_, err = tokenFile.WriteString("fileToken\n")
+	require.NoError(t, err)
+	require.NoError(t, tokenFile.Close())
+	os.Setenv("SWAGGER_AUTH_TEST_TOKEN", "envToken")
+	defer os.Unsetenv("SWAGGER_AUTH_TEST_TOKEN")
+
+	testCases := []struct {
+		name            string
+		config          SwaggerAuthConfig
+		expectedHeaders map[string]string
+		expectedError   string
+	}{
+		{name: "no configuration", config: SwaggerAuthConfig{}, expectedHeaders: map[string]string{}},
+		{name: "headers", config: SwaggerAuthConfig{Headers: map[string]string{"X-Api-Key": "key"}}, expectedHeaders: map[string]string{"X-Api-Key": "key"}},
+		{name: "bearer token from file", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{File: tokenFile.Name()}}, expectedHeaders: map[string]string{authorizationHeader: "Bearer fileToken"}},
+		{name: "bearer token from env along with headers", config: SwaggerAuthConfig{Headers: map[string]string{"X-Api-Key": "key"}, BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_AUTH_TEST_TOKEN"}}, expectedHeaders: map[string]string{"X-Api-Key": "key", authorizationHeader: "Bearer envToken"}},
+		{name: "bearer token from command", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Command: []string{"echo", "cmdToken"}}}, expectedHeaders: map[string]string{authorizationHeader: "Bearer cmdToken"}},
+		{name: "basic auth", config: SwaggerAuthConfig{BasicAuth: &SwaggerBasicAuthConfig{Username: "user", Password: "password"}}, expectedHeaders: map[string]string{authorizationHeader: "Basic dXNlcjpwYXNzd29yZA=="}},
+		{name: "bearer token file does not exist", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{File: "/non/existing/token"}}, expectedError: "failed to read the swagger bearer token file '/non/existing/token': open /non/existing/token: no such file or directory"},
+		{name: "bearer token env not set", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_AUTH_TEST_NON_EXISTING"}}, expectedError: "the environment variable 'SWAGGER_AUTH_TEST_NON_EXISTING' containing the swagger bearer token is not set"},
+		{name: "bearer token command fails", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Command: []string{"sh", "-c", "echo oops >&2; exit 1"}}}, expectedError: "swagger bearer token command '[sh -c echo oops >&2; exit 1]' failed: oops\n(exit status 1)"},
+		{name: "bearer token command prints nothing", config: SwaggerAuthConfig{BearerToken: &SwaggerBearerTokenConfig{Command: []string{"true"}}}, expectedError: "the swagger bearer token is empty"},
+	}
+	for _, tc := range testCases {
+		headers, err := tc.config.getHeaders()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedHeaders, headers, tc.name)
+	}
+}
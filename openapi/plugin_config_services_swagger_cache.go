@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+// swaggerCacheDefaultDir defines the directory, relative to the Terraform plugins directory, where the OpenAPI documents
+// are cached if the swagger cache configuration does not specify one
+const swaggerCacheDefaultDir = "openapi-cache"
+
+// SwaggerCacheConfig defines whether the OpenAPI document retrieved from the swagger URL is cached on disk so the
+// following executions of the plugin do not need to retrieve and expand it again
+type SwaggerCacheConfig struct {
+	// Enabled defines whether the OpenAPI document is cached
+	Enabled bool `yaml:"enabled"`
+	// MaxAge defines the time, in seconds, during which the cached document is used without revalidating it with the
+	// server. If not specified, the cached document is revalidated (using ETag/If-Modified-Since) every time.
+	MaxAge int `yaml:"max_age,omitempty"`
+	// Dir defines the directory where the documents are cached (by default, openapi-cache in the Terraform plugins directory)
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// Validate makes sure the max age is not negative
+func (c SwaggerCacheConfig) Validate() error {
+	if c.MaxAge < 0 {
+		return fmt.Errorf("swagger cache configuration not valid, 'max_age' must not be negative (%d)", c.MaxAge)
+	}
+	return nil
+}
+
+// getDir returns the directory where the documents are cached, with the leading ~ expanded to the user's home directory
+func (c SwaggerCacheConfig) getDir() (string, error) {
+	if c.Dir != "" {
+		return expandPath(c.Dir)
+	}
+	terraformUtils, err := terraformutils.NewTerraformUtils()
+	if err != nil {
+		return "", err
+	}
+	pluginsDir, err := terraformUtils.GetTerraformPluginsVendorDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pluginsDir, swaggerCacheDefaultDir), nil
+}
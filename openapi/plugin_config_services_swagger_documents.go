@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// resourceNamePrefixRegex defines the format of the resource name prefixes, which become part of the Terraform resource names
+var resourceNamePrefixRegex = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+
+// SwaggerDocumentConfig defines one of the OpenAPI documents merged into the provider. Each document keeps its own host,
+// base path and security as defined in the document itself.
+type SwaggerDocumentConfig struct {
+	// SwaggerURL defines where the swagger is located
+	SwaggerURL string `yaml:"swagger-url"`
+	// ResourceNamePrefix defines the prefix added to the names of the resources and data sources of the document (e,g: with
+	// the 'billing' prefix the 'invoices_v1' resource is exposed as 'billing_invoices_v1')
+	ResourceNamePrefix string `yaml:"resource_name_prefix,omitempty"`
+	// SwaggerIntegrityConfig defines how the swagger file is verified, overriding the service swagger integrity configuration
+	SwaggerIntegrityConfig *SwaggerIntegrityConfig `yaml:"swagger_integrity,omitempty"`
+	// SwaggerPatch defines the JSON Patch operations applied to the swagger file before it's merged
+	SwaggerPatch []SwaggerPatchOperation `yaml:"swagger_patch,omitempty"`
+}
+
+// Validate makes sure the resource name prefix is Terraform compliant and the integrity and patch configuration are valid.
+// The swagger URL is validated by the service configuration since it depends on where the service configuration was loaded
+// from.
+func (c SwaggerDocumentConfig) Validate() error {
+	if c.ResourceNamePrefix != "" && !resourceNamePrefixRegex.MatchString(c.ResourceNamePrefix) {
+		return fmt.Errorf("swagger document configuration not valid ('%s'), 'resource_name_prefix' must only contain lower case letters, numbers and underscores, starting with a letter (%s)", c.SwaggerURL, c.ResourceNamePrefix)
+	}
+	if c.SwaggerIntegrityConfig != nil {
+		if err := c.SwaggerIntegrityConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateSwaggerPatch(c.SwaggerPatch)
+}
@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// SwaggerIntegrityConfig defines how the OpenAPI document is verified before the provider is configured with it. The
+// provider refuses to start if the verification fails.
+type SwaggerIntegrityConfig struct {
+	// SHA256 defines the hex encoded SHA-256 checksum the OpenAPI document must match
+	SHA256 string `yaml:"sha256,omitempty"`
+	// Signature defines the detached signature the OpenAPI document must be signed with
+	Signature *SwaggerSignatureConfig `yaml:"signature,omitempty"`
+}
+
+// SwaggerSignatureConfig defines the ed25519 public key used to verify the detached signature of the OpenAPI document
+type SwaggerSignatureConfig struct {
+	// PublicKey defines the base64 encoded ed25519 public key, either the raw key or a minisign public key
+	PublicKey string `yaml:"public_key"`
+	// URL defines where the detached signature is located, by default next to the OpenAPI document with the .sig extension
+	URL string `yaml:"url,omitempty"`
+}
+
+// Validate makes sure the checksum is a valid SHA-256 hex encoded checksum and the public key is a valid ed25519 key
+func (c SwaggerIntegrityConfig) Validate() error {
+	if c.SHA256 == "" && c.Signature == nil {
+		return errors.New("swagger integrity configuration not valid, at least one of 'sha256' or 'signature' must be configured")
+	}
+	if c.SHA256 != "" {
+		if checksum, err := hex.DecodeString(c.SHA256); err != nil || len(checksum) != 32 {
+			return errors.New("swagger integrity configuration not valid, 'sha256' must be a hex encoded SHA-256 checksum")
+		}
+	}
+	if c.Signature != nil {
+		if _, err := parseSignaturePublicKey(c.Signature.PublicKey); err != nil {
+			return errors.New("swagger integrity configuration not valid, 'signature.public_key' must be a base64 encoded ed25519 or minisign public key")
+		}
+	}
+	return nil
+}
+
+// getURL returns the location of the detached signature of the given OpenAPI document
+func (c SwaggerSignatureConfig) getURL(openAPIDocumentURL string) string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return openAPIDocumentURL + ".sig"
+}
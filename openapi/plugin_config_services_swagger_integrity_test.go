@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerIntegrityConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        SwaggerIntegrityConfig
+		expectedError string
+	}{
+		{name: "sha256 checksum", config: SwaggerIntegrityConfig{SHA256: "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"}},
+		{name: "ed25519 public key", config: SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}}},
+		{name: "minisign public key", config: SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"}}},
+		{name: "nothing configured", config: SwaggerIntegrityConfig{}, expectedError: "swagger integrity configuration not valid, at least one of 'sha256' or 'signature' must be configured"},
+		{name: "sha256 checksum not hex encoded", config: SwaggerIntegrityConfig{SHA256: "not hex"}, expectedError: "swagger integrity configuration not valid, 'sha256' must be a hex encoded SHA-256 checksum"},
+		{name: "sha256 checksum too short", config: SwaggerIntegrityConfig{SHA256: "9f86d081"}, expectedError: "swagger integrity configuration not valid, 'sha256' must be a hex encoded SHA-256 checksum"},
+		{name: "public key not valid", config: SwaggerIntegrityConfig{Signature: &SwaggerSignatureConfig{PublicKey: "bm90IGEga2V5"}}, expectedError: "swagger integrity configuration not valid, 'signature.public_key' must be a base64 encoded ed25519 or minisign public key"},
+	}
+	for _, tc := range testCases {
+		err := tc.config.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestSwaggerSignatureConfigGetURL(t *testing.T) {
+	assert.Equal(t, "https://api.example.com/swagger.yaml.sig", SwaggerSignatureConfig{}.getURL("https://api.example.com/swagger.yaml"))
+	assert.Equal(t, "https://api.example.com/swagger.yaml.minisig", SwaggerSignatureConfig{URL: "https://api.example.com/swagger.yaml.minisig"}.getURL("https://api.example.com/swagger.yaml"))
+}
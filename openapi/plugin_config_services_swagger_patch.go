@@ -0,0 +1,58 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// swaggerPatchOperations defines the JSON Patch (RFC 6902) operations supported in the swagger patch
+var swaggerPatchOperations = []string{"add", "remove", "replace", "move", "copy", "test"}
+
+// SwaggerPatchOperation defines a JSON Patch (RFC 6902) operation applied to the OpenAPI document before it's analysed,
+// so the document can be amended (e,g: adding x-terraform-* extensions) without forking it
+type SwaggerPatchOperation struct {
+	// Op defines the operation: add, remove, replace, move, copy or test
+	Op string `yaml:"op"`
+	// Path defines the JSON pointer (RFC 6901) to the target location of the operation (e,g: /paths/~1v1~1cdns/post/x-terraform-exclude-resource)
+	Path string `yaml:"path"`
+	// From defines the JSON pointer to the source location of the move and copy operations
+	From string `yaml:"from,omitempty"`
+	// Value defines the value of the add, replace and test operations
+	Value interface{} `yaml:"value,omitempty"`
+}
+
+// Validate makes sure the operation is supported and its JSON pointers are well formed
+func (o SwaggerPatchOperation) Validate() error {
+	supported := false
+	for _, op := range swaggerPatchOperations {
+		if o.Op == op {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("swagger patch configuration not valid, operation '%s' not supported, must be one of %v", o.Op, swaggerPatchOperations)
+	}
+	if o.Path != "" && !strings.HasPrefix(o.Path, "/") {
+		return fmt.Errorf("swagger patch configuration not valid, 'path' must be a JSON pointer starting with '/' (%s)", o.Path)
+	}
+	if o.Op == "move" || o.Op == "copy" {
+		if o.From != "" && !strings.HasPrefix(o.From, "/") {
+			return fmt.Errorf("swagger patch configuration not valid, 'from' must be a JSON pointer starting with '/' (%s)", o.From)
+		}
+		if o.Op == "move" && o.Path != o.From && strings.HasPrefix(o.Path+"/", o.From+"/") {
+			return fmt.Errorf("swagger patch configuration not valid, a location can not be moved into one of its children (%s -> %s)", o.From, o.Path)
+		}
+	}
+	return nil
+}
+
+// validateSwaggerPatch makes sure all the operations of the given patch are valid
+func validateSwaggerPatch(patch []SwaggerPatchOperation) error {
+	for _, operation := range patch {
+		if err := operation.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
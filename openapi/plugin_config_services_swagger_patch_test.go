@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerPatchOperationValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		operation     SwaggerPatchOperation
+		expectedError string
+	}{
+		{
+			name:      "valid add operation",
+			operation: SwaggerPatchOperation{Op: "add", Path: "/paths/~1v1~1cdns/x-terraform-exclude-resource", Value: true},
+		},
+		{
+			name:      "valid move operation onto itself",
+			operation: SwaggerPatchOperation{Op: "move", From: "/paths/~1v1~1cdns", Path: "/paths/~1v1~1cdns"},
+		},
+		{
+			name:          "operation not supported",
+			operation:     SwaggerPatchOperation{Op: "merge", Path: "/host"},
+			expectedError: "swagger patch configuration not valid, operation 'merge' not supported, must be one of [add remove replace move copy test]",
+		},
+		{
+			name:          "path not a JSON pointer",
+			operation:     SwaggerPatchOperation{Op: "remove", Path: "host"},
+			expectedError: "swagger patch configuration not valid, 'path' must be a JSON pointer starting with '/' (host)",
+		},
+		{
+			name:          "from not a JSON pointer",
+			operation:     SwaggerPatchOperation{Op: "copy", From: "host", Path: "/x-host"},
+			expectedError: "swagger patch configuration not valid, 'from' must be a JSON pointer starting with '/' (host)",
+		},
+		{
+			name:          "move into a child",
+			operation:     SwaggerPatchOperation{Op: "move", From: "/paths", Path: "/paths/~1v1~1cdns"},
+			expectedError: "swagger patch configuration not valid, a location can not be moved into one of its children (/paths -> /paths/~1v1~1cdns)",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.operation.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package openapi
 
 import (
 	"bytes"
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -36,6 +38,17 @@ func TestServiceConfigV1GetSwaggerURL(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a ServiceConfigV1 containing a list of swagger documents", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "http://iam-api.com/swagger.yaml"}, {SwaggerURL: "http://billing-api.com/swagger.yaml"}},
+		}
+		Convey("When GetSwaggerURL method is called", func() {
+			swaggerURL := serviceConfiguration.GetSwaggerURL()
+			Convey("Then the swagger url returned should be the one of the first document", func() {
+				So(swaggerURL, ShouldEqual, "http://iam-api.com/swagger.yaml")
+			})
+		})
+	})
 }
 
 func TestServiceConfigV1IsSecureSkipVerifyEnabled(t *testing.T) {
@@ -116,6 +129,152 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a ServiceConfigV1 containing a swagger auth configuration with both bearer token and basic auth", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "https://api.domain.com/swagger.yaml",
+			SwaggerAuthConfig: &SwaggerAuthConfig{
+				BearerToken: &SwaggerBearerTokenConfig{Env: "SWAGGER_TOKEN"},
+				BasicAuth:   &SwaggerBasicAuthConfig{Username: "user"},
+			},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "swagger auth configuration not valid, only one of 'bearer_token' or 'basic_auth' can be configured")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a swagger cache configuration with a negative max age", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:         "https://api.domain.com/swagger.yaml",
+			SwaggerCacheConfig: &SwaggerCacheConfig{Enabled: true, MaxAge: -1},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "swagger cache configuration not valid, 'max_age' must not be negative (-1)")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing swagger documents or a swagger patch", t, func() {
+		testCases := []struct {
+			name                 string
+			serviceConfiguration *ServiceConfigV1
+			expectedError        string
+		}{
+			{
+				name: "valid swagger documents",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml"}, {SwaggerURL: "https://billing.domain.com/swagger.yaml", ResourceNamePrefix: "billing"}},
+				},
+			},
+			{
+				name: "swagger URL and swagger documents configured",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:       "https://api.domain.com/swagger.yaml",
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml"}},
+				},
+				expectedError: "service configuration not valid, 'swagger-url' and 'swagger_documents' can not be configured at the same time",
+			},
+			{
+				name: "invalid swagger document URL",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "htpt:/non-valid-url"}},
+				},
+				expectedError: "service swagger URL configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk",
+			},
+			{
+				name: "invalid resource name prefix",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://billing.domain.com/swagger.yaml", ResourceNamePrefix: "Billing-"}},
+				},
+				expectedError: "swagger document configuration not valid ('https://billing.domain.com/swagger.yaml'), 'resource_name_prefix' must only contain lower case letters, numbers and underscores, starting with a letter (Billing-)",
+			},
+			{
+				name: "service swagger integrity checksum",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments:       []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml"}},
+					SwaggerIntegrityConfig: &SwaggerIntegrityConfig{SHA256: strings.Repeat("a", 64)},
+				},
+				expectedError: "service configuration not valid, the 'swagger_integrity' checksum must be configured per swagger document when 'swagger_documents' is configured",
+			},
+			{
+				name: "service swagger patch",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml"}},
+					SwaggerPatch:     []SwaggerPatchOperation{{Op: "remove", Path: "/paths/~1v1~1users"}},
+				},
+				expectedError: "service configuration not valid, the 'swagger_patch' must be configured per swagger document when 'swagger_documents' is configured",
+			},
+			{
+				name: "invalid swagger document patch",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml", SwaggerPatch: []SwaggerPatchOperation{{Op: "delete", Path: "/paths/~1v1~1users"}}}},
+				},
+				expectedError: "swagger patch configuration not valid, operation 'delete' not supported, must be one of [add remove replace move copy test]",
+			},
+			{
+				name: "invalid swagger patch",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:   "https://api.domain.com/swagger.yaml",
+					SwaggerPatch: []SwaggerPatchOperation{{Op: "remove", Path: "paths"}},
+				},
+				expectedError: "swagger patch configuration not valid, 'path' must be a JSON pointer starting with '/' (paths)",
+			},
+			{
+				name: "invalid swagger document integrity",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerDocuments: []SwaggerDocumentConfig{{SwaggerURL: "https://iam.domain.com/swagger.yaml", SwaggerIntegrityConfig: &SwaggerIntegrityConfig{}}},
+				},
+				expectedError: "swagger integrity configuration not valid, at least one of 'sha256' or 'signature' must be configured",
+			},
+			{
+				name: "invalid resources configuration",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:      "https://api.domain.com/swagger.yaml",
+					ResourcesConfig: &ResourcesConfig{Timeouts: map[string]ResourceTimeoutsConfig{"cdns_v1": {Create: "0s"}}},
+				},
+				expectedError: "resources configuration not valid, the timeouts of 'cdns_v1' are not valid: 'create' timeout '0s' must be a positive duration (e,g: 30s, 10m, 1h)",
+			},
+			{
+				name: "invalid data sources configuration",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:        "https://api.domain.com/swagger.yaml",
+					DataSourcesConfig: &DataSourcesConfig{Include: []string{"["}},
+				},
+				expectedError: "data sources configuration not valid, the pattern '[' is not a valid glob pattern",
+			},
+			{
+				name: "invalid regions configuration",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:    "https://api.domain.com/swagger.yaml",
+					RegionsConfig: &RegionsConfig{Hosts: map[string]RegionHostConfig{"rst1": {}}},
+				},
+				expectedError: "regions configuration not valid, region 'rst1' is missing the host",
+			},
+			{
+				name: "invalid schema configuration",
+				serviceConfiguration: &ServiceConfigV1{
+					SwaggerURL:            "https://api.domain.com/swagger.yaml",
+					SchemaConfigurationV1: []ServiceSchemaPropertyConfigurationV1{{SchemaPropertyName: "retries", Type: "float"}},
+				},
+				expectedError: "schema configuration not valid, 'retries' type 'float' is not supported, the type must be one of string, integer or boolean",
+			},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When Validate method is called (%s)", tc.name), func() {
+				err := tc.serviceConfiguration.Validate()
+				Convey("Then the error returned should be the expected one", func() {
+					if tc.expectedError == "" {
+						So(err, ShouldBeNil)
+					} else {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					}
+				})
+			})
+		}
+	})
 }
 
 func TestGetTelemetryConfiguration(t *testing.T) {
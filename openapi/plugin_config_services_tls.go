@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const pemBlockPrefix = "-----BEGIN"
+
+// TLSConfig contains the TLS configuration used by the HTTP clients of a provider (the OpenAPI document retrieval, the
+// API requests and the token requests). The values can be either a path to a PEM encoded file or the PEM content itself.
+type TLSConfig struct {
+	// ClientCertificate defines the client certificate used for mutual TLS, it must be configured along with ClientKey
+	ClientCertificate string `yaml:"client_certificate,omitempty"`
+	// ClientKey defines the private key of the client certificate used for mutual TLS
+	ClientKey string `yaml:"client_key,omitempty"`
+	// CABundle defines the certificates of the CAs trusted when verifying the server certificate, in addition to the system ones
+	CABundle string `yaml:"ca_bundle,omitempty"`
+}
+
+// newHTTPTransport returns a dedicated http.Transport (based on the http.DefaultTransport settings) configured with the
+// client certificate and the CA bundle. The transport is not shared with other providers so each provider running in
+// the same process can have its own TLS configuration.
+func (t TLSConfig) newHTTPTransport(insecureSkipVerify bool) (*http.Transport, error) {
+	// #nosec G402 InsecureSkipVerify is only enabled if the user explicitly configures it
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if insecureSkipVerify {
+		log.Printf("[WARN] TLSClientConfig has been configured with InsecureSkipVerify set to true, this means that TLS connections will accept any certificate presented by the server and any host name in that certificate")
+	}
+	if t.ClientCertificate != "" || t.ClientKey != "" {
+		if t.ClientCertificate == "" || t.ClientKey == "" {
+			return nil, errors.New("the TLS client certificate and client key must be configured together")
+		}
+		certificate, err := getPEMContent(t.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS client certificate: %s", err)
+		}
+		key, err := getPEMContent(t.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS client key: %s", err)
+		}
+		clientCertificate, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCertificate}
+	}
+	if t.CABundle != "" {
+		caBundle, err := getPEMContent(t.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS CA bundle: %s", err)
+		}
+		certPool, err := x509.SystemCertPool()
+		if err != nil || certPool == nil {
+			log.Printf("[WARN] failed to load the system cert pool, only the CA bundle configured will be trusted: %s", err)
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM([]byte(caBundle)) {
+			return nil, errors.New("the TLS CA bundle does not contain any valid PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// getPEMContent returns the given value if it's PEM content already; otherwise the value is considered a path to the
+// PEM encoded file and its content is returned
+func getPEMContent(value string) (string, error) {
+	if strings.Contains(value, pemBlockPrefix) {
+		return value, nil
+	}
+	return getFileContent(value)
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate returns a PEM encoded self-signed certificate and key valid for localhost, both for server and client auth
+func newTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})), string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}))
+}
+
+// newTestMutualTLSServer returns a server using the given certificate that requires the clients to present the same certificate
+func newTestMutualTLSServer(t *testing.T, certificate, key string) *httptest.Server {
+	serverCertificate, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(certificate))
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCertificate}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	return server
+}
+
+func TestTLSConfigNewHTTPTransport(t *testing.T) {
+	certificate, key := newTestCertificate(t)
+	server := newTestMutualTLSServer(t, certificate, key)
+	defer server.Close()
+
+	certificateFile, err := ioutil.TempFile("", "client_certificate")
+	require.NoError(t, err)
+	defer os.Remove(certificateFile.Name())
+	_, err = certificateFile.WriteString(certificate)
+	require.NoError(t, err)
+	require.NoError(t, certificateFile.Close())
+
+	testCases := []struct {
+		name               string
+		tlsConfig          TLSConfig
+		insecureSkipVerify bool
+		expectedError      string
+		expectedRequestErr bool
+	}{
+		{name: "inline client certificate, key and CA bundle", tlsConfig: TLSConfig{ClientCertificate: certificate, ClientKey: key, CABundle: certificate}},
+		{name: "client certificate and CA bundle from files", tlsConfig: TLSConfig{ClientCertificate: certificateFile.Name(), ClientKey: key, CABundle: certificateFile.Name()}},
+		{name: "insecure skip verify with client certificate", tlsConfig: TLSConfig{ClientCertificate: certificate, ClientKey: key}, insecureSkipVerify: true},
+		{name: "missing client certificate", tlsConfig: TLSConfig{CABundle: certificate}, expectedRequestErr: true},
+		{name: "server certificate not trusted", tlsConfig: TLSConfig{ClientCertificate: certificate, ClientKey: key}, expectedRequestErr: true},
+		{name: "client key missing", tlsConfig: TLSConfig{ClientCertificate: certificate}, expectedError: "the TLS client certificate and client key must be configured together"},
+		{name: "client certificate file does not exist", tlsConfig: TLSConfig{ClientCertificate: "/non/existing/cert.pem", ClientKey: key}, expectedError: "failed to read the TLS client certificate: open /non/existing/cert.pem: no such file or directory"},
+		{name: "client key does not match the certificate", tlsConfig: TLSConfig{ClientCertificate: certificate, ClientKey: certificate}, expectedError: "failed to load the TLS client certificate: tls: found a certificate rather than a key in the PEM for the private key"},
+		{name: "CA bundle without certificates", tlsConfig: TLSConfig{CABundle: "-----BEGIN CERTIFICATE-----\nnot a cert\n-----END CERTIFICATE-----"}, expectedError: "the TLS CA bundle does not contain any valid PEM encoded certificate"},
+	}
+	for _, tc := range testCases {
+		transport, err := tc.tlsConfig.newHTTPTransport(tc.insecureSkipVerify)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			assert.Nil(t, transport, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.NotSame(t, http.DefaultTransport, transport, tc.name)
+		assert.Equal(t, tc.insecureSkipVerify, transport.TLSClientConfig.InsecureSkipVerify, tc.name)
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if tc.expectedRequestErr {
+			assert.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, tc.name)
+	}
+}
+
+func TestGetTLSConfiguration(t *testing.T) {
+	tlsConfig := TLSConfig{ClientCertificate: "cert.pem", ClientKey: "key.pem", CABundle: "ca.pem"}
+	assert.Equal(t, tlsConfig, (&ServiceConfigV1{TLSConfig: &tlsConfig}).GetTLSConfiguration())
+	assert.Equal(t, TLSConfig{}, (&ServiceConfigV1{}).GetTLSConfiguration())
+	assert.Equal(t, tlsConfig, (&ServiceConfigStub{TLS: tlsConfig}).GetTLSConfiguration())
+}
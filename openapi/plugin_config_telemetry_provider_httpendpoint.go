@@ -97,7 +97,7 @@ func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *s
 	for _, propSchemaName := range g.ProviderSchemaProperties {
 		propSchemaValue := data.Get(propSchemaName)
 		if propSchemaValue != nil {
-			tpConfig.Headers[propSchemaName] = propSchemaValue.(string)
+			tpConfig.Headers[propSchemaName] = fmt.Sprintf("%v", propSchemaValue)
 		}
 	}
 	return tpConfig
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 const providerName = "test"
@@ -320,3 +321,43 @@ func udpServer(metricChannel chan string) (net.PacketConn, string, string) {
 	}()
 	return pc, telemetryHost, telemetryPort
 }
+
+func TestNewEmbeddedPluginConfiguration(t *testing.T) {
+	Convey("Given the files embedded in the provider binary containing the plugin configuration", t, func() {
+		embeddedFS := fstest.MapFS{OpenAPIPluginConfigurationFileName: {Data: []byte(fmt.Sprintf(`version: '1'
+services:
+   %s:
+       swagger-url: swagger.yaml`, providerName))}, "swagger.yaml": {Data: []byte(`swagger: "2.0"`)}}
+		Convey("When newEmbeddedPluginConfiguration and getServiceConfiguration are called", func() {
+			pluginConfiguration, err := newEmbeddedPluginConfiguration(providerName, embeddedFS)
+			So(err, ShouldBeNil)
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the service configuration should be read from the embedded plugin configuration and have access to the embedded files", func() {
+				So(err, ShouldBeNil)
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, "swagger.yaml")
+				So(serviceConfiguration.GetEmbeddedFS(), ShouldResemble, embeddedFS)
+			})
+		})
+		Convey("When the OTF_VAR_test_SWAGGER_URL is set and getServiceConfiguration is called", func() {
+			os.Setenv(otfVarNameLc, otfVarSwaggerURLValue)
+			pluginConfiguration, err := newEmbeddedPluginConfiguration(providerName, embeddedFS)
+			So(err, ShouldBeNil)
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			os.Unsetenv(otfVarNameLc)
+			Convey("Then the environment variable should take preference and the document should not be loaded from the embedded files", func() {
+				So(err, ShouldBeNil)
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, otfVarSwaggerURLValue)
+				So(serviceConfiguration.GetEmbeddedFS(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given the files embedded in the provider binary without the plugin configuration", t, func() {
+		Convey("When newEmbeddedPluginConfiguration is called", func() {
+			pluginConfiguration, err := newEmbeddedPluginConfiguration(providerName, fstest.MapFS{})
+			Convey("Then the plugin configuration should not have any configuration", func() {
+				So(err, ShouldBeNil)
+				So(pluginConfiguration.Configuration, ShouldBeNil)
+			})
+		})
+	})
+}
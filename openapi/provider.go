@@ -1,24 +1,38 @@
 package openapi
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
+	"io/fs"
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+// openAPIDocumentRequestTimeout is the timeout of the requests made to retrieve the OpenAPI document
+const openAPIDocumentRequestTimeout = 30 * time.Second
+
 // ProviderOpenAPI defines the struct for the OpenAPI Terraform Provider
 type ProviderOpenAPI struct {
 	ProviderName string
+	// EmbeddedFS defines the files compiled into the provider binary: the plugin configuration file (terraform-provider-openapi.yaml)
+	// and the OpenAPI documents referenced from it. If set, the plugin configuration is read from it instead of the
+	// Terraform plugins directory. Otherwise, the plugin configuration and OpenAPI documents are loaded externally.
+	EmbeddedFS   fs.FS
 	provider     *schema.Provider
-	err          error
+	specAnalyser SpecAnalyser
+	// providerFactory is the factory the provider was created with, used to retrieve the resources exposed by the provider
+	providerFactory *providerFactory
+	err             error
 }
 
 // CreateSchemaProvider returns a terraform.ResourceProvider.
 func (p *ProviderOpenAPI) CreateSchemaProvider() (*schema.Provider, error) {
-	serviceConfiguration, err := getServiceConfiguration(p.ProviderName)
+	serviceConfiguration, err := getServiceConfiguration(p.ProviderName, p.EmbeddedFS)
 	if err != nil {
 		return nil, fmt.Errorf("plugin init error: %s", err)
 	}
@@ -38,17 +52,10 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 
 	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
 		log.Printf("[WARN] Provider '%s' is using insecure skip verify, therefore the HTTPs client will not verify the API server's certificate chain and host name. This should only be used for testing purposes and it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable or configuring the ServiceConfiguration with InsecureSkipVerifyEnabled when executing this provider", p.ProviderName)
-		tr := http.DefaultTransport.(*http.Transport)
-		// #nosec G402
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
-		log.Printf("[WARN] TLSClientConfig has been configured with InsecureSkipVerify set to true, this means that TLS connections will accept any certificate presented by the server and any host name in that certificate")
 	}
-
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	openAPISpecAnalyser, err := createServiceSpecAnalyser(serviceConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
+		return nil, err
 	}
 
 	providerFactory, err := newProviderFactory(p.ProviderName, openAPISpecAnalyser, serviceConfiguration)
@@ -60,15 +67,117 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 	if err != nil {
 		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
 	}
+	p.specAnalyser = openAPISpecAnalyser
+	p.providerFactory = providerFactory
 	return p.provider, nil
 }
 
+// createServiceSpecAnalyser returns the SpecAnalyser for the OpenAPI document of the given service configuration, which
+// is retrieved using the TLS, swagger auth, swagger cache, swagger integrity, swagger patch, regions and embedded files
+// configuration of the service. If the service is configured with several swagger documents, the returned SpecAnalyser merges all of them.
+func createServiceSpecAnalyser(serviceConfiguration ServiceConfiguration) (SpecAnalyser, error) {
+	transport, err := serviceConfiguration.GetTLSConfiguration().newHTTPTransport(serviceConfiguration.IsInsecureSkipVerifyEnabled())
+	if err != nil {
+		return nil, fmt.Errorf("plugin TLS configuration error: %s", err)
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: openAPIDocumentRequestTimeout}
+	swaggerDocuments := serviceConfiguration.GetSwaggerDocumentsConfiguration()
+	if len(swaggerDocuments) == 0 {
+		loader, err := newSpecLoader(serviceConfiguration, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("plugin OpenAPI document loader configuration error: %s", err)
+		}
+		openAPISpecAnalyser, err := createSpecAnalyserWithLoader(specAnalyserV2, serviceConfiguration.GetSwaggerURL(), loader)
+		if err != nil {
+			return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
+		}
+		return openAPISpecAnalyser, nil
+	}
+	documents := []*mergedSpecDocument{}
+	for i, swaggerDocument := range swaggerDocuments {
+		loader, err := newSpecLoader(serviceConfiguration, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("plugin OpenAPI document loader configuration error: %s", err)
+		}
+		// the backend configuration (including the regions) of the merged documents is the one of the first document
+		if i > 0 {
+			loader.regions = nil
+		}
+		if swaggerDocument.SwaggerIntegrityConfig != nil {
+			loader.integrity = swaggerDocument.SwaggerIntegrityConfig
+		}
+		loader.patch = swaggerDocument.SwaggerPatch
+		openAPISpecAnalyser, err := createSpecAnalyserWithLoader(specAnalyserV2, swaggerDocument.SwaggerURL, loader)
+		if err != nil {
+			return nil, fmt.Errorf("plugin OpenAPI spec analyser error ('%s'): %s", swaggerDocument.SwaggerURL, err)
+		}
+		documents = append(documents, &mergedSpecDocument{
+			openAPIDocumentURL: swaggerDocument.SwaggerURL,
+			resourceNamePrefix: swaggerDocument.ResourceNamePrefix,
+			specAnalyser:       openAPISpecAnalyser,
+		})
+	}
+	openAPISpecAnalyser, err := newSpecAnalyserMerged(documents)
+	if err != nil {
+		return nil, fmt.Errorf("plugin OpenAPI documents merge error: %s", err)
+	}
+	return openAPISpecAnalyser, nil
+}
+
+// createProviderClient configures the provider previously created with CreateSchemaProvider (or CreateSchemaProviderFromServiceConfiguration)
+// and returns the client used to call the service provider's API. Since the provider is being used outside Terraform
+// (e,g: provider commands), the provider configuration is read from the environment variables that match the provider's
+// properties names in upper case (e,g: APIKEY_AUTH, REGION, etc).
+func (p *ProviderOpenAPI) createProviderClient() (ClientOpenAPI, error) {
+	if p.provider == nil {
+		return nil, fmt.Errorf("provider '%s' has not been initialised yet", p.ProviderName)
+	}
+	diags := p.provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				return nil, fmt.Errorf("failed to configure the provider '%s': %s", p.ProviderName, d.Summary)
+			}
+		}
+	}
+	providerClient, ok := p.provider.Meta().(ClientOpenAPI)
+	if !ok {
+		return nil, fmt.Errorf("failed to configure the provider '%s': unexpected provider client %T", p.ProviderName, p.provider.Meta())
+	}
+	return providerClient, nil
+}
+
+// getProviderResources returns the resources exposed by the provider (only those that were successfully registered in
+// the provider's resources map)
+func (p *ProviderOpenAPI) getProviderResources() ([]SpecResource, error) {
+	if p.provider == nil || p.providerFactory == nil {
+		return nil, fmt.Errorf("provider '%s' has not been initialised yet", p.ProviderName)
+	}
+	resources, err := p.providerFactory.getResources()
+	if err != nil {
+		return nil, err
+	}
+	providerResources := []SpecResource{}
+	for _, r := range resources {
+		if _, exists := p.provider.ResourcesMap[fmt.Sprintf("%s_%s", p.ProviderName, r.GetResourceName())]; exists {
+			providerResources = append(providerResources, r)
+		}
+	}
+	return providerResources, nil
+}
+
 // This function is implemented with temporary code thus it can serve as an example
 // on how the same code base can be used by binaries of this same provider named differently
 // but internally each will end up calling a different service provider's api
-func getServiceConfiguration(providerName string) (ServiceConfiguration, error) {
+func getServiceConfiguration(providerName string, embeddedFS fs.FS) (ServiceConfiguration, error) {
 	var serviceConfiguration ServiceConfiguration
-	pluginConfiguration, err := NewPluginConfiguration(providerName)
+	var pluginConfiguration *PluginConfiguration
+	var err error
+	if embeddedFS != nil {
+		pluginConfiguration, err = newEmbeddedPluginConfiguration(providerName, embeddedFS)
+	} else {
+		pluginConfiguration, err = NewPluginConfiguration(providerName)
+	}
 	if err != nil {
 		return nil, err
 	}
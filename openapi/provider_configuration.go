@@ -1,11 +1,18 @@
 package openapi
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const providerPropertyRegion = "region"
 const providerPropertyEndPoints = "endpoints"
+const providerPropertyBaseURL = "base_url"
+const providerPropertyClientCertificate = "client_certificate"
+const providerPropertyClientKey = "client_key"
+const providerPropertyCABundle = "ca_bundle"
+const providerPropertyDefaultLabels = "default_labels"
 
 // providerConfiguration contains all the configuration related to the OpenAPI provider. The configuration at the moment
 // supports:
@@ -14,12 +21,20 @@ const providerPropertyEndPoints = "endpoints"
 // - Security Definitions: The security definitions map contains the security definition names as well as the values provided by the user in the terraform configuration
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
 // - Endpoints contains the endpoints configured by the user, which effectively will override the default host set in the swagger file
+// - BaseURL contains the URL configured by the user that overrides the scheme, host and base path of all the resources (empty if not provided)
 // - Region contains the region if user provided value for it (only supported for multi-region providers)
+// - TLS contains the client certificate and CA bundle used when calling the API
+// - DefaultLabels contains the labels merged into the labels of every resource that has a labels property
+// - GlobalPathParameters contains the values of the global path parameters keyed by the path parameter name (empty if not provided)
 type providerConfiguration struct {
 	Headers                   map[string]string
 	SecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
 	Endpoints                 map[string]string
+	BaseURL                   string
 	Region                    string
+	TLS                       TLSConfig
+	DefaultLabels             map[string]string
+	GlobalPathParameters      map[string]string
 }
 
 // createProviderConfig returns a providerConfiguration populated with the values provided by the user in the provider's terraform
@@ -37,8 +52,18 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 	if securitySchemaDefinitions != nil {
 		for _, secDef := range *securitySchemaDefinitions {
 			secDefTerraformCompliantName := secDef.GetTerraformConfigurationName()
-			if value, exists := data.GetOkExists(secDefTerraformCompliantName); exists {
-				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value.(string))
+			if credentialsSecDef, ok := secDef.(specCredentialsSecurityDefinition); ok {
+				values := map[string]string{}
+				for _, property := range credentialsSecDef.getCredentialProperties() {
+					if value, exists := getProviderPropertyValue(data, property.Name); exists {
+						values[property.Name] = value
+					}
+				}
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = credentialsSecDef.createAuthenticator(values)
+				continue
+			}
+			if value, exists := getProviderPropertyValue(data, secDefTerraformCompliantName); exists {
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value)
 			} else {
 				// Initialise the api authenticator with an empty value since the user did not provide one
 				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, "")
@@ -50,24 +75,55 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 	if headers != nil {
 		for _, headerParam := range headers {
 			headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
-			if value, exists := data.GetOkExists(headerTerraformCompliantName); exists {
-				providerConfiguration.Headers[headerTerraformCompliantName] = value.(string)
+			if value, exists := getProviderPropertyValue(data, headerTerraformCompliantName); exists {
+				providerConfiguration.Headers[headerTerraformCompliantName] = value
 			}
 		}
 	}
 
+	globalPathParameters, err := specAnalyser.GetGlobalPathParameters()
+	if err != nil {
+		return nil, err
+	}
+	providerConfiguration.GlobalPathParameters = map[string]string{}
+	for _, globalPathParameter := range globalPathParameters {
+		providerConfiguration.GlobalPathParameters[globalPathParameter.Name], _ = getProviderPropertyValue(data, globalPathParameter.GetTerraformConfigurationName())
+	}
+
 	region := data.Get(providerPropertyRegion)
 	if region != nil {
 		providerConfiguration.Region = region.(string)
 	}
 
+	providerConfiguration.TLS.ClientCertificate, _ = data.Get(providerPropertyClientCertificate).(string)
+	providerConfiguration.TLS.ClientKey, _ = data.Get(providerPropertyClientKey).(string)
+	providerConfiguration.TLS.CABundle, _ = data.Get(providerPropertyCABundle).(string)
+
+	providerConfiguration.DefaultLabels = map[string]string{}
+	if defaultLabels, ok := data.Get(providerPropertyDefaultLabels).(map[string]interface{}); ok {
+		for key, value := range defaultLabels {
+			providerConfiguration.DefaultLabels[key] = value.(string)
+		}
+	}
+
 	if providerConfigurationEndPoints != nil {
 		providerConfiguration.Endpoints = providerConfigurationEndPoints.configureEndpoints(data)
 	}
+	providerConfiguration.BaseURL, _ = data.Get(providerPropertyBaseURL).(string)
 
 	return providerConfiguration, nil
 }
 
+// getProviderPropertyValue returns the value of the given provider property converted to string (the properties can be of
+// type string, integer or boolean) and whether the value is set
+func getProviderPropertyValue(data *schema.ResourceData, schemaPropertyName string) (string, bool) {
+	value, exists := data.GetOkExists(schemaPropertyName)
+	if !exists || value == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%v", value), true
+}
+
 func (p *providerConfiguration) getAuthenticatorFor(s SpecSecurityScheme) specAPIKeyAuthenticator {
 	securitySchemeConfigName := s.GetTerraformConfigurationName()
 	return p.SecuritySchemaDefinitions[securitySchemeConfigName]
@@ -83,12 +139,13 @@ func (p *providerConfiguration) getRegion() string {
 	return p.Region
 }
 
-// getEndPoint resolves the endpoint value for a given resource name
+// getEndPoint resolves the endpoint value for a given resource name, falling back to the base URL if the resource does
+// not have an endpoint configured
 func (p *providerConfiguration) getEndPoint(resourceName string) string {
 	if p.Endpoints != nil {
-		if endpoint, ok := p.Endpoints[resourceName]; ok {
+		if endpoint, ok := p.Endpoints[resourceName]; ok && endpoint != "" {
 			return endpoint
 		}
 	}
-	return ""
+	return p.BaseURL
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapiutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"hash/crc32"
+	"net/url"
+	"strings"
 )
 
 type providerConfigurationEndPoints struct {
@@ -22,7 +24,7 @@ func (p *providerConfigurationEndPoints) endpointsSchema() *schema.Schema {
 				Optional:     true,
 				Default:      "",
 				ValidateFunc: p.endpointsValidateFunc(),
-				Description:  "Use this to override the resource endpoint URL (the default one or the one constructed from the `region`).\n",
+				Description:  "Use this to override the resource endpoint URL (the default one or the one constructed from the `region`). The value can be either a host (e,g: www.api.com:8080) or a URL including the scheme and optionally the base path (e,g: http://localhost:8080/mock/v2).\n",
 			}
 		}
 		return &schema.Schema{
@@ -40,11 +42,62 @@ func (p *providerConfigurationEndPoints) endpointsSchema() *schema.Schema {
 func (p *providerConfigurationEndPoints) endpointsValidateFunc() schema.SchemaValidateFunc {
 	return func(value interface{}, key string) (warns []string, errs []error) {
 		userValue := value.(string)
-		if openapiutils.IsValidHost(userValue) {
+		if _, err := parseEndPoint(userValue); err != nil {
+			return nil, []error{fmt.Errorf("property '%s' value '%s' is not valid, please make sure the value is a valid FQDN or well formed IP (the host may contain non standard ports too followed by a colon - e,g: www.api.com:8080), or a URL including the scheme (http or https) and optionally the base path (e,g: http://localhost:8080/mock/v2). The protocol used when performing the API call will be populated based on the swagger specification if the value is a host", key, userValue)}
+		}
+		return nil, nil
+	}
+}
+
+// baseURLValidateFunc returns the validate function of the provider's base URL property, which must be a URL including the
+// scheme and optionally the base path
+func baseURLValidateFunc() schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (warns []string, errs []error) {
+		userValue := value.(string)
+		if userValue == "" {
 			return nil, nil
 		}
-		return nil, []error{fmt.Errorf("property '%s' value '%s' is not valid, please make sure the value is a valid FQDN or well formed IP (the host may contain non standard ports too followed by a colon - e,g: www.api.com:8080). The protocol used when performing the API call will be populated based on the swagger specification", key, userValue)}
+		if endPoint, err := parseEndPoint(userValue); err != nil || endPoint.scheme == "" {
+			return nil, []error{fmt.Errorf("property '%s' value '%s' is not valid, please make sure the value is a URL including the scheme (http or https), the host and optionally the base path (e,g: http://localhost:8080/mock/v2)", key, userValue)}
+		}
+		return nil, nil
+	}
+}
+
+// endPoint defines the scheme, host and base path the API calls of a resource are made against. The scheme and base path
+// are empty when they are not overridden (the value is a host), in which case the ones from the swagger specification are
+// used.
+type endPoint struct {
+	scheme   string
+	host     string
+	basePath string
+}
+
+// parseEndPoint parses the given endpoint value, which can be either a host (e,g: www.api.com:8080) or a URL including the
+// scheme and optionally the base path (e,g: http://localhost:8080/mock/v2). A URL without path (or with the root path)
+// results in the root base path, so the API calls are made against the root of the host.
+func parseEndPoint(value string) (*endPoint, error) {
+	if value == "" || openapiutils.IsValidHost(value) {
+		return &endPoint{host: value}, nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("scheme '%s' not supported - must use http or https", u.Scheme)
+	}
+	if !openapiutils.IsValidHost(u.Host) {
+		return nil, fmt.Errorf("host '%s' is not valid", u.Host)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("the URL must not contain user info, query parameters or fragments")
+	}
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if basePath == "" {
+		basePath = "/"
 	}
+	return &endPoint{scheme: u.Scheme, host: u.Host, basePath: basePath}, nil
 }
 
 // endpointsToHash calculates the unique ID used to store the endpoints element in a hash.
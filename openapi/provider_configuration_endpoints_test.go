@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -89,17 +90,73 @@ func TestEndpointsValidateFunc(t *testing.T) {
 				So(errs, ShouldBeNil)
 			})
 		})
-		Convey("When endpointsValidateFunc is invoked with a whole URL (only hostnames are valid)", func() {
-			warns, errs := p.endpointsValidateFunc()("http://www.valid-domain.com", "something")
+		Convey("When endpointsValidateFunc is invoked with a whole URL", func() {
+			warns, errs := p.endpointsValidateFunc()("http://localhost:8080/mock/v2", "something")
+			Convey("Then the warns should be nil and the errs should be nil", func() {
+				So(warns, ShouldBeNil)
+				So(errs, ShouldBeNil)
+			})
+		})
+		Convey("When endpointsValidateFunc is invoked with a URL using a scheme not supported", func() {
+			warns, errs := p.endpointsValidateFunc()("ftp://www.valid-domain.com", "something")
 			Convey("Then the warns should be nil and the errs should be the expected one", func() {
 				So(warns, ShouldBeNil)
-				So(errs[0].Error(), ShouldEqual, "property 'something' value 'http://www.valid-domain.com' is not valid, please make sure the value is a valid FQDN or well formed IP (the host may contain non standard ports too followed by a colon - e,g: www.api.com:8080). The protocol used when performing the API call will be populated based on the swagger specification")
-
+				So(errs[0].Error(), ShouldEqual, "property 'something' value 'ftp://www.valid-domain.com' is not valid, please make sure the value is a valid FQDN or well formed IP (the host may contain non standard ports too followed by a colon - e,g: www.api.com:8080), or a URL including the scheme (http or https) and optionally the base path (e,g: http://localhost:8080/mock/v2). The protocol used when performing the API call will be populated based on the swagger specification if the value is a host")
 			})
 		})
 	})
 }
 
+func TestBaseURLValidateFunc(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedError string
+	}{
+		{name: "empty value", value: ""},
+		{name: "URL with base path", value: "http://localhost:8080/mock/v2"},
+		{name: "URL without base path", value: "https://canary.api.com"},
+		{name: "host without scheme", value: "www.api.com", expectedError: "property 'base_url' value 'www.api.com' is not valid, please make sure the value is a URL including the scheme (http or https), the host and optionally the base path (e,g: http://localhost:8080/mock/v2)"},
+		{name: "URL with query parameters", value: "https://canary.api.com?debug=true", expectedError: "property 'base_url' value 'https://canary.api.com?debug=true' is not valid, please make sure the value is a URL including the scheme (http or https), the host and optionally the base path (e,g: http://localhost:8080/mock/v2)"},
+	}
+	for _, tc := range testCases {
+		warns, errs := baseURLValidateFunc()(tc.value, providerPropertyBaseURL)
+		assert.Nil(t, warns, tc.name)
+		if tc.expectedError == "" {
+			assert.Empty(t, errs, tc.name)
+		} else {
+			assert.Len(t, errs, 1, tc.name)
+			assert.EqualError(t, errs[0], tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestParseEndPoint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		value            string
+		expectedEndPoint *endPoint
+		expectedError    string
+	}{
+		{name: "host", value: "www.api.com:8080", expectedEndPoint: &endPoint{host: "www.api.com:8080"}},
+		{name: "URL with base path", value: "http://localhost:8080/mock/v2/", expectedEndPoint: &endPoint{scheme: "http", host: "localhost:8080", basePath: "/mock/v2"}},
+		{name: "URL without base path", value: "https://canary.api.com", expectedEndPoint: &endPoint{scheme: "https", host: "canary.api.com", basePath: "/"}},
+		{name: "URL with root path", value: "http://localhost:8080/", expectedEndPoint: &endPoint{scheme: "http", host: "localhost:8080", basePath: "/"}},
+		{name: "scheme not supported", value: "ftp://www.api.com", expectedError: "scheme 'ftp' not supported - must use http or https"},
+		{name: "host not valid", value: "https://www.api_com", expectedError: "host 'www.api_com' is not valid"},
+		{name: "URL with fragment", value: "https://www.api.com/v1#cdns", expectedError: "the URL must not contain user info, query parameters or fragments"},
+	}
+	for _, tc := range testCases {
+		endPoint, err := parseEndPoint(tc.value)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedEndPoint, endPoint, tc.name)
+	}
+}
+
 //func TestGetProviderConfigEndPointsFromData(t *testing.T) {
 //	Convey("Given a provider factory", t, func() {
 //		expectedResource := "resource_name"
@@ -2,6 +2,8 @@ package openapi
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -42,6 +44,42 @@ func TestNewProviderConfiguration(t *testing.T) {
 	})
 }
 
+func TestNewProviderConfigurationDefaultLabels(t *testing.T) {
+	specAnalyser := &specAnalyserStub{security: &specSecurityStub{}}
+	defaultLabelsProperty := &SpecSchemaDefinitionProperty{Name: providerPropertyDefaultLabels, Type: TypeMap, Default: map[string]interface{}{"team": "network"}}
+	providerConfiguration, err := newProviderConfiguration(specAnalyser, newTestSchema(defaultLabelsProperty).getResourceData(t), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "network"}, providerConfiguration.DefaultLabels)
+
+	providerConfiguration, err = newProviderConfiguration(specAnalyser, newTestSchema(stringProperty).getResourceData(t), nil)
+	require.NoError(t, err)
+	assert.Empty(t, providerConfiguration.DefaultLabels)
+}
+
+func TestNewProviderConfigurationTypedHeaders(t *testing.T) {
+	specAnalyser := &specAnalyserStub{
+		headers: SpecHeaderParameters{
+			SpecHeaderParam{Name: intProperty.Name, ProviderPropertyAttributes: ProviderPropertyAttributes{Type: TypeInt}},
+			SpecHeaderParam{Name: boolProperty.Name, ProviderPropertyAttributes: ProviderPropertyAttributes{Type: TypeBool}},
+		},
+		security: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+	}
+	providerConfiguration, err := newProviderConfiguration(specAnalyser, newTestSchema(intProperty, boolProperty).getResourceData(t), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{intProperty.Name: "12", boolProperty.Name: "true"}, providerConfiguration.Headers)
+}
+
+func TestNewProviderConfigurationGlobalPathParameters(t *testing.T) {
+	specAnalyser := &specAnalyserStub{
+		globalPathParameters: SpecGlobalPathParameters{{Name: "accountId"}, {Name: "project_id"}},
+		security:             &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+	}
+	accountIDProperty := newStringSchemaDefinitionPropertyWithDefaults("account_id", "", false, false, "1234")
+	providerConfiguration, err := newProviderConfiguration(specAnalyser, newTestSchema(accountIDProperty).getResourceData(t), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"accountId": "1234", "project_id": ""}, providerConfiguration.GlobalPathParameters)
+}
+
 func TestGetAuthenticatorFor(t *testing.T) {
 	Convey("Given a providerConfiguration with some security schema definitions", t, func() {
 		providerConfiguration := providerConfiguration{
@@ -51,14 +89,14 @@ func TestGetAuthenticatorFor(t *testing.T) {
 			},
 		}
 		Convey("When getAuthenticatorFor method with an existing sec def", func() {
-			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{"registered_sec_def_name"})
+			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{Name: "registered_sec_def_name"})
 			Convey("Then the apikey name should be headerName and the apikey value should have the expected value", func() {
 				So(apiKeyAuth.getContext().(apiKey).name, ShouldEqual, "headerName")
 				So(apiKeyAuth.getContext().(apiKey).value, ShouldEqual, "value")
 			})
 		})
 		Convey("When getAuthenticatorFor method with a NON existing sec def", func() {
-			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{"nonExistingSecDef"})
+			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{Name: "nonExistingSecDef"})
 			Convey("Then the apiKeyAuth returned should be nil", func() {
 				So(apiKeyAuth, ShouldBeNil)
 			})
@@ -145,4 +183,25 @@ func TestGetEndPoint(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a providerConfiguration configured with some endpoints and a base URL", t, func() {
+		providerConfiguration := providerConfiguration{
+			Endpoints: map[string]string{
+				"cdn_v1": "www.endpoint.com",
+				"lb_v1":  "",
+			},
+			BaseURL: "http://localhost:8080/mock",
+		}
+		Convey("When getEndPoint method is called with a resource name that has an endpoint configured", func() {
+			value := providerConfiguration.getEndPoint("cdn_v1")
+			Convey("Then the value returned should be the endpoint", func() {
+				So(value, ShouldEqual, "www.endpoint.com")
+			})
+		})
+		Convey("When getEndPoint method is called with a resource name that does not have an endpoint configured", func() {
+			value := providerConfiguration.getEndPoint("lb_v1")
+			Convey("Then the value returned should be the base URL", func() {
+				So(value, ShouldEqual, "http://localhost:8080/mock")
+			})
+		})
+	})
 }
@@ -84,6 +84,7 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 // - api key auth which will be used as the authentication mechanism when making http requests to the service provider
 // - specific headers used in operations
 // - endpoints override in case the user wants to point the resource to a different API (e,g: staging environment endpoint)
+// - base URL override in case the user wants to point all the resources to a different API (e,g: local mock API)
 func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) (map[string]*schema.Schema, error) {
 	s := map[string]*schema.Schema{}
 
@@ -110,27 +111,40 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		return nil, err
 	}
 	for _, securityDefinition := range *securityDefinitions {
-		secDefName := securityDefinition.GetTerraformConfigurationName()
 		required := false
 		if globalSecuritySchemes.securitySchemeExists(securityDefinition) {
 			required = true
 		}
-		p.configureProviderPropertyFromPluginConfig(s, secDefName, required)
+		for _, securityDefinitionProperty := range GetSecurityDefinitionProperties(securityDefinition) {
+			p.configureProviderPropertyFromPluginConfig(s, securityDefinitionProperty.Name, required && !securityDefinitionProperty.Optional, ProviderPropertyAttributes{Sensitive: securityDefinitionProperty.Sensitive})
+		}
 	}
 
 	headers := p.specAnalyser.GetAllHeaderParameters()
 	log.Printf("[DEBUG] all header parameters: %+v", headers)
 	for _, headerParam := range headers {
 		headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
-		p.configureProviderPropertyFromPluginConfig(s, headerTerraformCompliantName, false)
+		p.configureProviderPropertyFromPluginConfig(s, headerTerraformCompliantName, false, headerParam.ProviderPropertyAttributes)
+	}
+
+	globalPathParameters, err := p.specAnalyser.GetGlobalPathParameters()
+	if err != nil {
+		return nil, err
+	}
+	for _, globalPathParameter := range globalPathParameters {
+		p.configureProviderPropertyFromPluginConfig(s, globalPathParameter.GetTerraformConfigurationName(), globalPathParameter.IsRequired(), globalPathParameter.ProviderPropertyAttributes)
 	}
 
+	p.configureTLSProviderProperties(s)
+	p.configureDefaultLabelsProviderProperty(s)
+
 	if providerConfigurationEndPoints != nil {
 		endpoints := providerConfigurationEndPoints.endpointsSchema()
 		if endpoints != nil {
 			s[providerPropertyEndPoints] = endpoints
 		}
 	}
+	p.configureBaseURLProviderProperty(s)
 
 	return s, nil
 }
@@ -146,22 +160,103 @@ func (p providerFactory) getResourceNames(resourceMap map[string]*schema.Resourc
 	return resourceNames
 }
 
-func (p providerFactory) configureProviderPropertyFromPluginConfig(providerSchema map[string]*schema.Schema, schemaPropertyName string, required bool) {
-	var defaultValue = ""
-	var err error
+// configureProviderPropertyFromPluginConfig adds the given property to the provider schema with the given attributes
+// (type, default value, sensitivity, description and validation) overridden by the ones in the plugin configuration, if any
+func (p providerFactory) configureProviderPropertyFromPluginConfig(providerSchema map[string]*schema.Schema, schemaPropertyName string, required bool, attributes ProviderPropertyAttributes) {
+	var defaultValue = attributes.Default
 	schemaPropertyConfiguration := p.serviceConfiguration.GetSchemaPropertyConfiguration(schemaPropertyName)
 	if schemaPropertyConfiguration != nil {
-		err = schemaPropertyConfiguration.ExecuteCommand()
+		if p.getCredentialProcess(schemaPropertyName) != nil && required {
+			log.Printf("[DEBUG] property '%s' is optional since its value can be obtained from the credential process configured", schemaPropertyName)
+			required = false
+		}
+		err := schemaPropertyConfiguration.ExecuteCommand()
 		if err != nil {
 			log.Printf("[ERROR] %s", err)
 		}
-		defaultValue, err = schemaPropertyConfiguration.GetDefaultValue()
+		configuredDefaultValue, err := schemaPropertyConfiguration.GetDefaultValue()
 		if err != nil {
 			log.Printf("[ERROR] %s", err)
 		}
+		if configuredDefaultValue != "" {
+			defaultValue = configuredDefaultValue
+		}
 	}
-	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
-	log.Printf("[DEBUG] registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required)
+	attributes = GetProviderPropertyAttributes(p.serviceConfiguration, schemaPropertyName, attributes)
+	providerSchema[schemaPropertyName] = terraformutils.CreateSchemaProperty(schemaPropertyName, attributes.getTerraformType(), required, defaultValue)
+	attributes.configureSchema(providerSchema[schemaPropertyName])
+	log.Printf("[DEBUG] registered new property '%s' (type=%s, required=%t, sensitive=%t) into provider schema", schemaPropertyName, attributes.GetTypeName(), required, attributes.Sensitive)
+}
+
+// configureTLSProviderProperties adds the optional TLS properties (client certificate, client key and CA bundle) to the
+// provider schema, defaulting to the TLS configuration from the plugin configuration. Properties with the same name
+// defined in the OpenAPI document take precedence.
+func (p providerFactory) configureTLSProviderProperties(providerSchema map[string]*schema.Schema) {
+	tlsConfiguration := TLSConfig{}
+	if p.serviceConfiguration != nil {
+		tlsConfiguration = p.serviceConfiguration.GetTLSConfiguration()
+	}
+	tlsProperties := []struct {
+		name         string
+		defaultValue string
+		sensitive    bool
+		description  string
+	}{
+		{name: providerPropertyClientCertificate, defaultValue: tlsConfiguration.ClientCertificate, description: "PEM encoded client certificate (or path to the file) used for mutual TLS when calling the API"},
+		{name: providerPropertyClientKey, defaultValue: tlsConfiguration.ClientKey, sensitive: true, description: "PEM encoded private key of the client certificate (or path to the file) used for mutual TLS when calling the API"},
+		{name: providerPropertyCABundle, defaultValue: tlsConfiguration.CABundle, description: "PEM encoded CA certificates (or path to the file) trusted when verifying the API server certificate, in addition to the system ones"},
+	}
+	for _, tlsProperty := range tlsProperties {
+		if _, exists := providerSchema[tlsProperty.name]; exists {
+			log.Printf("[WARN] provider property '%s' is already defined in the OpenAPI document, the TLS configuration can only be provided in the plugin configuration", tlsProperty.name)
+			continue
+		}
+		providerSchema[tlsProperty.name] = terraformutils.CreateStringSchemaProperty(tlsProperty.name, false, tlsProperty.defaultValue)
+		providerSchema[tlsProperty.name].Sensitive = tlsProperty.sensitive
+		providerSchema[tlsProperty.name].Description = tlsProperty.description
+	}
+}
+
+// configureDefaultLabelsProviderProperty adds the optional default labels property to the provider schema if any of the
+// resources exposed has a labels property ('x-terraform-labels'). Properties with the same name defined in the OpenAPI
+// document take precedence.
+func (p providerFactory) configureDefaultLabelsProviderProperty(providerSchema map[string]*schema.Schema) {
+	resources, err := p.getResources()
+	if err != nil {
+		return
+	}
+	for _, resource := range resources {
+		resourceSchema, err := resource.GetResourceSchema()
+		if err != nil || resourceSchema == nil || resourceSchema.getLabelsProperty() == nil {
+			continue
+		}
+		if _, exists := providerSchema[providerPropertyDefaultLabels]; exists {
+			log.Printf("[WARN] provider property '%s' is already defined in the OpenAPI document, the default labels can not be configured", providerPropertyDefaultLabels)
+			return
+		}
+		providerSchema[providerPropertyDefaultLabels] = &schema.Schema{
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Labels merged into the labels of every resource, the labels configured in the resources take precedence",
+		}
+		log.Printf("[DEBUG] registered new property '%s' into provider schema", providerPropertyDefaultLabels)
+		return
+	}
+}
+
+// configureBaseURLProviderProperty adds the optional base URL property to the provider schema, which overrides the scheme,
+// host and base path of all the resources (the endpoints configured per resource take precedence). Properties with the
+// same name defined in the OpenAPI document take precedence.
+func (p providerFactory) configureBaseURLProviderProperty(providerSchema map[string]*schema.Schema) {
+	if _, exists := providerSchema[providerPropertyBaseURL]; exists {
+		log.Printf("[WARN] provider property '%s' is already defined in the OpenAPI document, the base URL can not be configured", providerPropertyBaseURL)
+		return
+	}
+	providerSchema[providerPropertyBaseURL] = terraformutils.CreateStringSchemaProperty(providerPropertyBaseURL, false, "")
+	providerSchema[providerPropertyBaseURL].ValidateFunc = baseURLValidateFunc()
+	providerSchema[providerPropertyBaseURL].Description = "Use this to override the URL (scheme, host and base path) the API calls of all the resources are made against (e,g: http://localhost:8080/mock/v2), the endpoints configured per resource take precedence"
+	log.Printf("[DEBUG] registered new property '%s' into provider schema", providerPropertyBaseURL)
 }
 
 func (p providerFactory) configureProviderProperty(providerSchema map[string]*schema.Schema, schemaPropertyName string, defaultValue string, required bool, allowedValues []string) error {
@@ -188,7 +283,10 @@ func (p providerFactory) createValidateFunc(allowedValues []string) func(val int
 
 func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*schema.Resource, error) {
 	dataSourceMap := map[string]*schema.Resource{}
-	openAPIDataResources := p.specAnalyser.GetTerraformCompliantDataSources()
+	openAPIDataResources, err := p.getDataSources()
+	if err != nil {
+		return nil, err
+	}
 	for _, openAPIDataSource := range openAPIDataResources {
 		dataSourceName, err := p.getProviderResourceName(openAPIDataSource.GetResourceName())
 		if err != nil {
@@ -213,7 +311,7 @@ func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*sch
 func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstanceMap() (resourceMap, dataSourceInstanceMap map[string]*schema.Resource, err error) {
 	resourceMap = map[string]*schema.Resource{}
 	dataSourceInstanceMap = map[string]*schema.Resource{}
-	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	openAPIResources, err := p.getResources()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -268,6 +366,10 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 		if err != nil {
 			return nil, err
 		}
+		httpClient, err := p.createHTTPClient(config)
+		if err != nil {
+			return nil, err
+		}
 		telemetryHandler := p.GetTelemetryHandler(data)
 		if telemetryHandler != nil {
 			telemetryHandler.SubmitPluginExecutionMetrics()
@@ -275,7 +377,7 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 		openAPIClient := &ProviderClient{
 			openAPIBackendConfiguration: openAPIBackendConfiguration,
 			apiAuthenticator:            authenticator,
-			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: httpClient},
 			providerConfiguration:       *config,
 			telemetryHandler:            telemetryHandler,
 		}
@@ -283,6 +385,21 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 	}
 }
 
+// createHTTPClient returns the HTTP client used by the provider to call the API, with a dedicated transport configured
+// with the TLS configuration provided by the user. The authenticators that make HTTP requests to obtain their credentials
+// (e,g: refresh token, OAuth2) are configured to use the same HTTP client.
+func (p providerFactory) createHTTPClient(config *providerConfiguration) (*http.Client, error) {
+	transport, err := config.TLS.newHTTPTransport(p.serviceConfiguration.IsInsecureSkipVerifyEnabled())
+	if err != nil {
+		return nil, fmt.Errorf("provider TLS configuration error: %s", err)
+	}
+	httpClient := &http.Client{Transport: transport}
+	for name, authenticator := range config.SecuritySchemaDefinitions {
+		config.SecuritySchemaDefinitions[name] = configureAuthenticatorHTTPClient(authenticator, httpClient)
+	}
+	return httpClient, nil
+}
+
 // GetTelemetryHandler returns a handler containing validated telemetry providers
 func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) TelemetryHandler {
 	telemetryProvider := p.serviceConfiguration.GetTelemetryConfiguration()
@@ -311,9 +428,112 @@ func (p providerFactory) createProviderConfig(data *schema.ResourceData, provide
 	if err != nil {
 		return nil, err
 	}
+	if err := p.configureCredentialProcesses(providerConfiguration, data); err != nil {
+		return nil, err
+	}
 	return providerConfiguration, nil
 }
 
+// configureCredentialProcesses replaces the authenticators of the security definitions that have properties configured
+// with a credential process in the plugin configuration (and no value provided by the user) with authenticators that
+// obtain the values from the credential processes when an API request needs them
+func (p providerFactory) configureCredentialProcesses(providerConfiguration *providerConfiguration, data *schema.ResourceData) error {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	securityDefinitions, err := p.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+	if err != nil {
+		return err
+	}
+	for _, securityDefinition := range *securityDefinitions {
+		securityDefinition := securityDefinition
+		values := map[string]string{}
+		processes := map[string]CredentialProcess{}
+		for _, property := range GetSecurityDefinitionProperties(securityDefinition) {
+			value, _ := getProviderPropertyValue(data, property.Name)
+			if credentialProcess := p.getCredentialProcess(property.Name); value == "" && credentialProcess != nil {
+				processes[property.Name] = *credentialProcess
+				continue
+			}
+			values[property.Name] = value
+		}
+		if len(processes) == 0 {
+			continue
+		}
+		log.Printf("[DEBUG] security definition '%s' configured to obtain its values from credential processes", securityDefinition.getName())
+		providerConfiguration.SecuritySchemaDefinitions[securityDefinition.GetTerraformConfigurationName()] = newCredentialProcessAuthenticator(securityDefinition.GetTerraformConfigurationName(), values, processes, func(values map[string]string) specAPIKeyAuthenticator {
+			return createSecurityDefinitionAuthenticator(securityDefinition, values)
+		})
+	}
+	return nil
+}
+
+// getCredentialProcess returns the credential process configured in the plugin configuration for the given property, nil
+// if there is none
+func (p providerFactory) getCredentialProcess(schemaPropertyName string) *CredentialProcess {
+	schemaPropertyConfiguration := p.serviceConfiguration.GetSchemaPropertyConfiguration(schemaPropertyName)
+	if schemaPropertyConfiguration == nil {
+		return nil
+	}
+	return schemaPropertyConfiguration.GetCredentialProcess()
+}
+
+// getResources returns the resources from the OpenAPI document that are exposed according to the resources configuration
+// of the service (if any), with the names and timeouts defined in it. The resources of multi-region providers expose the
+// region argument.
+func (p providerFactory) getResources() ([]SpecResource, error) {
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	if p.serviceConfiguration != nil {
+		openAPIResources, err = configureResources(openAPIResources, p.serviceConfiguration.GetResourcesConfiguration())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.configureRegions(openAPIResources)
+}
+
+// getDataSources returns the data sources from the OpenAPI document that are exposed according to the data sources
+// configuration of the service (if any), with the names defined in it. The data sources of multi-region providers
+// expose the region argument.
+func (p providerFactory) getDataSources() ([]SpecResource, error) {
+	dataSources := p.specAnalyser.GetTerraformCompliantDataSources()
+	if p.serviceConfiguration != nil {
+		var err error
+		dataSources, err = configureDataSources(dataSources, p.serviceConfiguration.GetDataSourcesConfiguration(), p.serviceConfiguration.GetResourcesConfiguration())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.configureRegions(dataSources)
+}
+
+// configureRegions returns the given resources with the region argument if the provider is multi-region, so the region
+// configured in the provider can be overridden per resource. Otherwise, the resources are returned as is.
+func (p providerFactory) configureRegions(openAPIResources []SpecResource) ([]SpecResource, error) {
+	openAPIBackendConfiguration, err := p.specAnalyser.GetAPIBackendConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	if openAPIBackendConfiguration == nil {
+		return openAPIResources, nil
+	}
+	isMultiRegion, _, regions, err := openAPIBackendConfiguration.IsMultiRegion()
+	if err != nil {
+		return nil, err
+	}
+	if !isMultiRegion {
+		return openAPIResources, nil
+	}
+	regionResources := []SpecResource{}
+	for _, openAPIResource := range openAPIResources {
+		regionResources = append(regionResources, NewRegionSpecResource(openAPIResource, regions))
+	}
+	return regionResources, nil
+}
+
 func (p providerFactory) getProviderResourceName(resourceName string) (string, error) {
 	if resourceName == "" {
 		return "", fmt.Errorf("resource name can not be empty")
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/dikhan/http_goclient"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -463,6 +465,77 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 		})
 	})
 
+	Convey("Given a provider factory that is configured with a global security definition whose value is obtained from a credential process", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+						{
+							"apikey_auth": []string{""},
+						},
+					}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{
+				SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+					{
+						SchemaPropertyName: "apikey_auth",
+						CredentialProcess:  &CredentialProcess{Command: []string{"echo", `{"value":"token"}`}, Timeout: time.Second},
+					},
+				},
+			},
+		}
+		Convey("When createTerraformProviderSchema is called with a backend configuration that is not multi-region", func() {
+			backendConfig := &specStubBackendConfiguration{}
+			providerSchema, err := p.createTerraformProviderSchema(backendConfig, nil)
+			Convey("Then the security definition property should be optional as the value can be obtained from the credential process", func() {
+				So(err, ShouldBeNil)
+				So(providerSchema, ShouldContainKey, "apikey_auth")
+				So(providerSchema["apikey_auth"].Required, ShouldBeFalse)
+				So(providerSchema["apikey_auth"].Optional, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a provider factory that is configured with a global OAuth2 client credentials security definition", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token"),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+						{
+							"oauth2_auth": []string{"read"},
+						},
+					}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+		Convey("When createTerraformProviderSchema is called with a backend configuration that is not multi-region", func() {
+			backendConfig := &specStubBackendConfiguration{}
+			providerSchema, err := p.createTerraformProviderSchema(backendConfig, nil)
+			Convey("Then the provider schema should contain the required client ID and the required sensitive client secret properties", func() {
+				So(err, ShouldBeNil)
+				So(providerSchema, ShouldNotContainKey, "oauth2_auth")
+				So(providerSchema, ShouldContainKey, "oauth2_auth_client_id")
+				So(providerSchema, ShouldContainKey, "oauth2_auth_client_secret")
+				So(providerSchema["oauth2_auth_client_id"].Required, ShouldBeTrue)
+				So(providerSchema["oauth2_auth_client_id"].Sensitive, ShouldBeFalse)
+				So(providerSchema["oauth2_auth_client_secret"].Required, ShouldBeTrue)
+				So(providerSchema["oauth2_auth_client_secret"].Sensitive, ShouldBeTrue)
+			})
+		})
+	})
+
 	Convey("Given a provider factory", t, func() {
 		p := providerFactory{
 			name: "provider",
@@ -539,6 +612,198 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a provider factory configured with TLS configuration in the plugin configuration", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{
+					SpecHeaderParam{
+						Name: providerPropertyCABundle,
+					},
+				},
+				security: &specSecurityStub{
+					securityDefinitions:   &SpecSecurityDefinitions{},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{
+				TLS: TLSConfig{ClientCertificate: "/path/to/cert.pem", ClientKey: "/path/to/key.pem", CABundle: "/path/to/ca.pem"},
+			},
+		}
+		Convey("When createTerraformProviderSchema is called", func() {
+			providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+			Convey("Then the provider schema should contain the optional TLS properties defaulting to the plugin configuration values", func() {
+				So(err, ShouldBeNil)
+				So(providerSchema[providerPropertyClientCertificate].Optional, ShouldBeTrue)
+				defaultValue, err := providerSchema[providerPropertyClientCertificate].DefaultFunc()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, "/path/to/cert.pem")
+				So(providerSchema[providerPropertyClientKey].Sensitive, ShouldBeTrue)
+				defaultValue, err = providerSchema[providerPropertyClientKey].DefaultFunc()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, "/path/to/key.pem")
+			})
+			Convey("And the properties defined in the OpenAPI document should take precedence over the TLS properties", func() {
+				So(providerSchema[providerPropertyCABundle].Description, ShouldBeEmpty)
+				defaultValue, err := providerSchema[providerPropertyCABundle].DefaultFunc()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateProviderMultiRegionResources(t *testing.T) {
+	newProviderFactory := func(backendConfiguration SpecBackendConfiguration) providerFactory {
+		return providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources:            []SpecResource{newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty, newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil)}}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})},
+				dataSources:          []SpecResource{newSpecStubResource("lbs_v1", "/v1/lbs", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}})},
+				security:             &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+				backendConfiguration: backendConfiguration,
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+	}
+
+	p := newProviderFactory(&specStubBackendConfiguration{host: "some-service.${region}.api.com", regions: []string{"rst", "dub"}})
+	provider, err := p.createProvider()
+	require.NoError(t, err)
+	assert.NoError(t, provider.InternalValidate())
+	for _, resourceSchema := range []map[string]*schema.Schema{provider.ResourcesMap["provider_cdns_v1"].Schema, provider.DataSourcesMap["provider_cdns_v1_instance"].Schema, provider.DataSourcesMap["provider_lbs_v1"].Schema} {
+		require.Contains(t, resourceSchema, providerPropertyRegion)
+		assert.True(t, resourceSchema[providerPropertyRegion].Optional)
+		assert.True(t, resourceSchema[providerPropertyRegion].Computed)
+		assert.True(t, resourceSchema[providerPropertyRegion].ForceNew)
+		assert.Empty(t, resourceSchema[providerPropertyRegion].ValidateDiagFunc("dub", nil))
+		assert.NotEmpty(t, resourceSchema[providerPropertyRegion].ValidateDiagFunc("sfo", nil))
+	}
+
+	// the resources of providers that are not multi-region do not expose the region argument
+	p = newProviderFactory(&specStubBackendConfiguration{host: "some-service.api.com"})
+	provider, err = p.createProvider()
+	require.NoError(t, err)
+	assert.NotContains(t, provider.ResourcesMap["provider_cdns_v1"].Schema, providerPropertyRegion)
+	assert.NotContains(t, provider.DataSourcesMap["provider_lbs_v1"].Schema, providerPropertyRegion)
+}
+
+func TestCreateTerraformProviderSchemaDefaultLabels(t *testing.T) {
+	newProviderFactory := func(resources ...SpecResource) providerFactory {
+		return providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: resources,
+				security:  &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+	}
+	labelsResource := newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty, newLabelsMapProperty(nil)}})
+	otherResource := newSpecStubResource("lbs_v1", "/v1/lbs", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}})
+
+	providerSchema, err := newProviderFactory(otherResource, labelsResource).createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, providerSchema, providerPropertyDefaultLabels)
+	assert.Equal(t, schema.TypeMap, providerSchema[providerPropertyDefaultLabels].Type)
+	assert.True(t, providerSchema[providerPropertyDefaultLabels].Optional)
+	assert.NoError(t, schema.InternalMap(providerSchema).InternalValidate(nil))
+
+	// the default labels are only exposed if any of the resources has a labels property
+	providerSchema, err = newProviderFactory(otherResource).createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, providerSchema, providerPropertyDefaultLabels)
+}
+
+func TestCreateTerraformProviderSchemaBaseURL(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyBaseURL)
+	assert.True(t, providerSchema[providerPropertyBaseURL].Optional)
+	_, errs := providerSchema[providerPropertyBaseURL].ValidateFunc("http://localhost:8080/mock/v2", providerPropertyBaseURL)
+	assert.Empty(t, errs)
+	_, errs = providerSchema[providerPropertyBaseURL].ValidateFunc("localhost:8080", providerPropertyBaseURL)
+	assert.NotEmpty(t, errs)
+	assert.NoError(t, schema.InternalMap(providerSchema).InternalValidate(nil))
+
+	// the properties defined in the OpenAPI document take precedence over the base URL
+	p.specAnalyser.(*specAnalyserStub).headers = SpecHeaderParameters{{Name: providerPropertyBaseURL}}
+	providerSchema, err = p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, providerSchema[providerPropertyBaseURL].ValidateFunc)
+}
+
+func TestCreateTerraformProviderSchemaProviderPropertyAttributes(t *testing.T) {
+	minimum := 0.0
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			headers: SpecHeaderParameters{
+				SpecHeaderParam{Name: "retries", ProviderPropertyAttributes: ProviderPropertyAttributes{Type: TypeInt, Description: "number of retries", Minimum: &minimum}},
+				SpecHeaderParam{Name: "session_token"},
+			},
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+				},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{SchemaPropertyName: "session_token", Attributes: &ProviderPropertyAttributes{Sensitive: true}},
+			},
+		},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	// security definition properties are sensitive by default
+	assert.Equal(t, schema.TypeString, providerSchema["apikey_auth"].Type)
+	assert.True(t, providerSchema["apikey_auth"].Sensitive)
+	// header properties are created with the attributes from the OpenAPI document
+	assert.Equal(t, schema.TypeInt, providerSchema["retries"].Type)
+	assert.False(t, providerSchema["retries"].Sensitive)
+	assert.Equal(t, "number of retries", providerSchema["retries"].Description)
+	_, errs := providerSchema["retries"].ValidateFunc(-1, "retries")
+	assert.Len(t, errs, 1)
+	// the attributes configured in the plugin configuration take precedence
+	assert.Equal(t, schema.TypeString, providerSchema["session_token"].Type)
+	assert.True(t, providerSchema["session_token"].Sensitive)
+	assert.NoError(t, schema.InternalMap(providerSchema).InternalValidate(nil))
+}
+
+func TestCreateTerraformProviderSchemaGlobalPathParameters(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			globalPathParameters: SpecGlobalPathParameters{
+				{Name: "accountId", ProviderPropertyAttributes: ProviderPropertyAttributes{Description: "The account"}},
+				{Name: "project_id", ProviderPropertyAttributes: ProviderPropertyAttributes{Default: "default", AllowedValues: []string{"default", "web"}}},
+			},
+			security: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	// global path parameters without default value are required
+	assert.True(t, providerSchema["account_id"].Required)
+	assert.Equal(t, "The account", providerSchema["account_id"].Description)
+	assert.False(t, providerSchema["project_id"].Required)
+	defaultValue, err := providerSchema["project_id"].DefaultFunc()
+	require.NoError(t, err)
+	assert.Equal(t, "default", defaultValue)
+	_, errs := providerSchema["project_id"].ValidateFunc("mobile", "project_id")
+	assert.Len(t, errs, 1)
+	assert.NoError(t, schema.InternalMap(providerSchema).InternalValidate(nil))
 }
 
 func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
@@ -561,7 +826,7 @@ func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
 		}
 		Convey("When createTerraformProviderSchema is called with an empty provider schema", func() {
 			providerSchema := map[string]*schema.Schema{}
-			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true)
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true, ProviderPropertyAttributes{})
 			Convey("Then the provider schema for the resource should contain the attribute with the expected default value and the provider schema properties commands should have been executed", func() {
 				So(providerSchema, ShouldContainKey, apiKeyAuthProperty.Name)
 				defaultValue, err := providerSchema[apiKeyAuthProperty.Name].DefaultFunc()
@@ -602,7 +867,7 @@ func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
 		}
 		Convey("When createTerraformProviderSchema is called with an empty provider schema", func() {
 			providerSchema := map[string]*schema.Schema{}
-			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true)
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true, ProviderPropertyAttributes{})
 			Convey("Then the provider schema for the resource should contain the attribute with default empty value and the provider schema properties commands should have been executed", func() {
 				// The APIs are expected to complain about the value being empty instead of the plugin failing at this stage
 				So(providerSchema, ShouldContainKey, apiKeyAuthProperty.Name)
@@ -728,6 +993,42 @@ func TestConfigureProvider(t *testing.T) {
 	})
 }
 
+func TestCreateHTTPClient(t *testing.T) {
+	Convey("Given a provider factory configured with insecure skip verify", t, func() {
+		p := providerFactory{
+			name:                 "provider",
+			serviceConfiguration: &ServiceConfigStub{InsecureSkipVerify: true},
+		}
+		Convey("When createHTTPClient is called with a provider configuration containing authenticators that make HTTP requests", func() {
+			config := &providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"apikey_auth":   newAPIKeyHeaderAuthenticator(authorizationHeader, "token", "apikey_auth"),
+					"refresh_token": newAPIRefreshTokenAuthenticator(authorizationHeader, "refreshToken", "https://api.iam.com/token", "refresh_token"),
+					"oauth2_auth":   newOAuth2ClientCredentialsAuthenticator("clientID", "clientSecret", "https://api.iam.com/oauth2/token", "oauth2_auth"),
+				},
+			}
+			httpClient, err := p.createHTTPClient(config)
+			Convey("Then the HTTP client should use a dedicated transport configured to skip verify", func() {
+				So(err, ShouldBeNil)
+				transport := httpClient.Transport.(*http.Transport)
+				So(transport, ShouldNotEqual, http.DefaultTransport)
+				So(transport.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
+			})
+			Convey("And the authenticators that make HTTP requests should be configured with the same HTTP client", func() {
+				So(config.SecuritySchemaDefinitions["apikey_auth"], ShouldResemble, newAPIKeyHeaderAuthenticator(authorizationHeader, "token", "apikey_auth"))
+				So(config.SecuritySchemaDefinitions["refresh_token"].(apiRefreshTokenAuthenticator).httpClient.(*http_goclient.HttpClient).HttpClient, ShouldEqual, httpClient)
+				So(config.SecuritySchemaDefinitions["oauth2_auth"].(oauth2ClientCredentialsAuthenticator).httpClient, ShouldEqual, httpClient)
+			})
+		})
+		Convey("When createHTTPClient is called with a provider configuration containing a wrong TLS configuration", func() {
+			_, err := p.createHTTPClient(&providerConfiguration{TLS: TLSConfig{ClientKey: "/path/to/key.pem"}})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "provider TLS configuration error: the TLS client certificate and client key must be configured together")
+			})
+		})
+	})
+}
+
 func TestCreateProviderConfig(t *testing.T) {
 	Convey("Given a provider factory configured with a global header and security scheme", t, func() {
 		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", true, false, "someAuthValue")
@@ -765,6 +1066,106 @@ func TestCreateProviderConfig(t *testing.T) {
 		})
 	})
 
+	Convey("Given a provider factory configured with a security scheme whose value is obtained from a credential process", t, func() {
+		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", false, false, "")
+		otherAPIKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("other_apikey_auth", "", false, false, "userValue")
+		credentialProcess := &CredentialProcess{Command: []string{"echo", `{"value":"tokenFromProcess"}`}, Timeout: 5 * time.Second}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+						newAPIKeyHeaderSecurityDefinition("other_apikey_auth", "Other-Authorization"),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{
+				SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+					{SchemaPropertyName: "apikey_auth", CredentialProcess: credentialProcess},
+					{SchemaPropertyName: "other_apikey_auth", CredentialProcess: credentialProcess},
+				},
+			},
+		}
+		Convey("When createProviderConfig is called with a resource data where only one of the security schemes has a value", func() {
+			testProviderSchema := newTestSchema(apiKeyAuthProperty, otherAPIKeyAuthProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the security scheme without value should obtain it from the credential process when the auth is prepared", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.SecuritySchemaDefinitions["apikey_auth"], ShouldHaveSameTypeAs, credentialProcessAuthenticator{})
+				ctx := &authContext{headers: map[string]string{}}
+				So(providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].prepareAuth(ctx), ShouldBeNil)
+				So(ctx.headers[authorizationHeader], ShouldEqual, "tokenFromProcess")
+			})
+			Convey("And the security scheme with a value provided by the user should use it", func() {
+				So(providerConfiguration.SecuritySchemaDefinitions["other_apikey_auth"], ShouldHaveSameTypeAs, apiKeyHeaderAuthenticator{})
+				So(providerConfiguration.SecuritySchemaDefinitions["other_apikey_auth"].getContext().(apiKey).value, ShouldEqual, "userValue")
+			})
+		})
+	})
+
+	Convey("Given a provider factory configured with a basic security scheme", t, func() {
+		usernameProperty := newStringSchemaDefinitionPropertyWithDefaults("basic_auth_username", "", true, false, "someUser")
+		passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("basic_auth_password", "", true, false, "somePassword")
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newBasicSecurityDefinition("basic_auth"),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+						{
+							"basic_auth": []string{""},
+						},
+					}),
+				},
+			},
+		}
+		Convey("When createProviderConfig is called with a resource data containing the values for the username and password properties", func() {
+			testProviderSchema := newTestSchema(usernameProperty, passwordProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the provider configuration returned should contain the basic authenticator configured with the values coming from the resource schema", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.SecuritySchemaDefinitions["basic_auth"], ShouldHaveSameTypeAs, basicAuthenticator{})
+				So(providerConfiguration.SecuritySchemaDefinitions["basic_auth"].getContext(), ShouldResemble, basicCredentials{username: "someUser", password: "somePassword"})
+			})
+		})
+	})
+
+	Convey("Given a provider factory configured with an OAuth2 client credentials security scheme", t, func() {
+		clientIDProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_id", "", true, false, "someClientID")
+		clientSecretProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_secret", "", true, false, "someClientSecret")
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token"),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+						{
+							"oauth2_auth": []string{""},
+						},
+					}),
+				},
+			},
+		}
+		Convey("When createProviderConfig is called with a resource data containing the values for the client ID and client secret properties", func() {
+			testProviderSchema := newTestSchema(clientIDProperty, clientSecretProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the provider configuration returned should contain the OAuth2 client credentials authenticator configured with the values coming from the resource schema", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"], ShouldHaveSameTypeAs, oauth2ClientCredentialsAuthenticator{})
+				So(providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"].getContext(), ShouldResemble, oauth2ClientCredentials{clientID: "someClientID", clientSecret: "someClientSecret"})
+			})
+		})
+	})
+
 	Convey("Given a provider factory configured with a global header and security scheme that use non terraform compliant names", t, func() {
 		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", true, false, "someAuthValue")
 		var headerProperty = newStringSchemaDefinitionPropertyWithDefaults("header_name", "", true, false, "someHeaderValue")
@@ -984,6 +1385,48 @@ func TestCreateTerraformProviderDataSourceInstanceMap_duplicate_resource(t *test
 	})
 }
 
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap_resources_configuration(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			resources: []SpecResource{
+				newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{}),
+				newSpecStubResource("cdns_v1_firewalls", "/v1/cdns/{id}/firewalls", false, &SpecSchemaDefinition{}),
+				newSpecStubResource("lbs_v1", "/v1/lbs", false, &SpecSchemaDefinition{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{
+			Resources: &ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Include: []string{"cdns_*"}, Exclude: []string{"*_firewalls"}, Renames: map[string]string{"cdns_v1": "cdn"}}},
+		},
+	}
+	resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	assert.Nil(t, err)
+	assert.Len(t, resourceMap, 1)
+	assert.Contains(t, resourceMap, "provider_cdn")
+	assert.Len(t, dataSourceMap, 1)
+	assert.Contains(t, dataSourceMap, "provider_cdn_instance")
+}
+
+func TestCreateTerraformProviderDataSourceMap_data_sources_configuration(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			dataSources: []SpecResource{
+				newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{}),
+				newSpecStubResource("lbs_v1", "/v1/lbs", false, &SpecSchemaDefinition{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{
+			Resources:   &ResourcesConfig{DataSourcesConfig: DataSourcesConfig{Exclude: []string{"cdns_*"}}},
+			DataSources: &DataSourcesConfig{Include: []string{"cdns_*"}, Renames: map[string]string{"cdns_v1": "cdns"}},
+		},
+	}
+	dataSourceMap, err := p.createTerraformProviderDataSourceMap()
+	assert.Nil(t, err)
+	assert.Len(t, dataSourceMap, 1)
+	assert.Contains(t, dataSourceMap, "provider_cdns")
+}
+
 func TestCreateTerraformProviderDataSourceMap(t *testing.T) {
 
 	testCases := []struct {
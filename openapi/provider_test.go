@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -9,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"testing/fstest"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -750,7 +752,7 @@ definitions:
       label:
         type: "string"`
 
-		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		swaggerServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Write([]byte(swaggerContent))
 		}))
 		p := ProviderOpenAPI{ProviderName: providerName}
@@ -762,12 +764,34 @@ definitions:
 			Convey("And the tfProvider returned should not be nil (skipping schema verification since that's covered in other tests)", func() {
 				So(tfProvider, ShouldNotBeNil)
 			})
-			Convey("And default TLS transport configuration should be configured to skip verify", func() {
+			Convey("And the default TLS transport configuration should not be altered as the provider uses its own transport", func() {
 				tr := http.DefaultTransport.(*http.Transport)
-				So(tr.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
+				So(tr.TLSClientConfig == nil || !tr.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
 			})
 
 		})
+		Convey("When CreateSchemaProviderWithConfiguration method is called with a serviceConfiguration that has the server certificate configured as the CA bundle", func() {
+			serverCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: swaggerServer.Certificate().Raw})
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, TLS: TLSConfig{CABundle: string(serverCertificate)}})
+			Convey("Then the error returned should be the nil and the tfProvider returned should not be nil", func() {
+				So(err, ShouldBeNil)
+				So(tfProvider, ShouldNotBeNil)
+			})
+		})
+		Convey("When CreateSchemaProviderWithConfiguration method is called with a serviceConfiguration that does not trust the server certificate", func() {
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+			Convey("Then the error returned should be the certificate verification error", func() {
+				So(err.Error(), ShouldContainSubstring, "certificate")
+				So(tfProvider, ShouldBeNil)
+			})
+		})
+		Convey("When CreateSchemaProviderWithConfiguration method is called with a serviceConfiguration that has a wrong TLS configuration", func() {
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, TLS: TLSConfig{ClientCertificate: "/some/cert.pem"}})
+			Convey("Then the error returned should be the TLS configuration error", func() {
+				So(err.Error(), ShouldEqual, "plugin TLS configuration error: the TLS client certificate and client key must be configured together")
+				So(tfProvider, ShouldBeNil)
+			})
+		})
 	})
 
 	Convey("Given a ProviderOpenAPI missing the providerName", t, func() {
@@ -977,7 +1001,7 @@ func TestGetServiceConfiguration(t *testing.T) {
 		os.Setenv(fmt.Sprintf(otfVarSwaggerURL, providerName), expectedSwaggerURL)
 		os.Setenv(otfVarInsecureSkipVerify, "false")
 		Convey("When getServiceConfiguration method is called", func() {
-			serviceConfiguration, err := getServiceConfiguration(providerName)
+			serviceConfiguration, err := getServiceConfiguration(providerName, nil)
 			Convey("Then the service configuration swagger URL should be the expected one, the service configuration should be false and error returned should be nil", func() {
 				So(err, ShouldBeNil)
 				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, expectedSwaggerURL)
@@ -987,6 +1011,89 @@ func TestGetServiceConfiguration(t *testing.T) {
 	})
 }
 
+func TestCreateProviderClient(t *testing.T) {
+	Convey("Given a ProviderOpenAPI created from a swagger file containing a resource and a global security definition", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: "localhost:8443"
+security:
+  - apikey_auth: []
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(swaggerContent))
+		}))
+		defer swaggerServer.Close()
+		p := ProviderOpenAPI{ProviderName: "openapi"}
+		Convey("When createProviderClient is called before the provider is created", func() {
+			providerClient, err := p.createProviderClient()
+			Convey("Then the error returned should be the expected one", func() {
+				So(providerClient, ShouldBeNil)
+				So(err.Error(), ShouldEqual, "provider 'openapi' has not been initialised yet")
+			})
+		})
+		Convey("When createProviderClient is called after the provider is created and the provider properties are set in the environment", func() {
+			_, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+			So(err, ShouldBeNil)
+			os.Setenv("APIKEY_AUTH", "apiKeyValue")
+			defer os.Unsetenv("APIKEY_AUTH")
+			providerClient, err := p.createProviderClient()
+			Convey("Then the provider client returned should be configured with the values from the environment", func() {
+				So(err, ShouldBeNil)
+				So(providerClient, ShouldHaveSameTypeAs, &ProviderClient{})
+				So(providerClient.(*ProviderClient).providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].getContext(), ShouldResemble, apiKey{name: "Authorization", value: "apiKeyValue"})
+			})
+			Convey("And the provider resources returned should contain the resource registered in the provider", func() {
+				resources, err := p.getProviderResources()
+				So(err, ShouldBeNil)
+				So(len(resources), ShouldEqual, 1)
+				So(resources[0].GetResourceName(), ShouldEqual, "cdns_v1")
+			})
+		})
+	})
+}
+
 type logWriter struct {
 	written string
 }
@@ -999,3 +1106,233 @@ func (w *logWriter) Write(p []byte) (n int, err error) {
 	w.written = w.written + string(p)
 	return 0, nil
 }
+
+func TestOpenAPIProviderEmbedded(t *testing.T) {
+	Convey("Given a provider with the plugin configuration and the OpenAPI documents embedded", t, func() {
+		embeddedFS := fstest.MapFS{
+			OpenAPIPluginConfigurationFileName: {Data: []byte(`version: '1'
+services:
+  embedded:
+    swagger-url: specs/swagger.yaml`)},
+			"specs/swagger.yaml": {Data: []byte(`swagger: "2.0"
+host: "localhost:8443"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "definitions.yaml#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "definitions.yaml#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "definitions.yaml#/definitions/ContentDeliveryNetwork"`)},
+			"specs/definitions.yaml": {Data: []byte(`definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`)},
+		}
+		Convey("When CreateSchemaProvider method is called", func() {
+			p := ProviderOpenAPI{ProviderName: "embedded", EmbeddedFS: embeddedFS}
+			tfProvider, err := p.CreateSchemaProvider()
+			Convey("Then the provider should be created from the embedded files", func() {
+				So(err, ShouldBeNil)
+				So(tfProvider.ResourcesMap, ShouldContainKey, "embedded_cdns_v1")
+				So(tfProvider.ResourcesMap["embedded_cdns_v1"].Schema, ShouldContainKey, "label")
+			})
+		})
+		Convey("When the swagger-url configured in the embedded plugin configuration does not exist", func() {
+			embeddedFS[OpenAPIPluginConfigurationFileName] = &fstest.MapFile{Data: []byte(`version: '1'
+services:
+  embedded:
+    swagger-url: specs/non_existing.yaml`)}
+			p := ProviderOpenAPI{ProviderName: "embedded", EmbeddedFS: embeddedFS}
+			_, err := p.CreateSchemaProvider()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "plugin init error: service configuration for 'embedded' not valid: service swagger URL configuration not valid ('specs/non_existing.yaml'). URL must be either a valid formed URL or a path to a swagger file embedded in the provider binary")
+			})
+		})
+		Convey("When the plugin configuration is not embedded and the swagger url is not configured", func() {
+			p := ProviderOpenAPI{ProviderName: "embedded", EmbeddedFS: fstest.MapFS{}}
+			_, err := p.CreateSchemaProvider()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldContainSubstring, "plugin init error: swagger url not provided")
+			})
+		})
+	})
+}
+
+func TestOpenAPIProviderSwaggerDocuments(t *testing.T) {
+	Convey("Given a provider configured with several swagger documents", t, func() {
+		embeddedFS := fstest.MapFS{
+			OpenAPIPluginConfigurationFileName: {Data: []byte(`version: '1'
+services:
+  platform:
+    swagger_documents:
+    - swagger-url: specs/cdn.yaml
+    - swagger-url: specs/billing.yaml
+      resource_name_prefix: billing`)},
+			"specs/cdn.yaml": {Data: []byte(`swagger: "2.0"
+host: "cdn.api.com"
+basePath: "/api"
+schemes:
+- "https"
+security:
+- apikey_auth: []
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`)},
+			"specs/billing.yaml": {Data: []byte(`swagger: "2.0"
+host: "billing.api.com"
+schemes:
+- "https"
+securityDefinitions:
+  token_auth:
+    type: "apiKey"
+    name: "X-Token"
+    in: "header"
+paths:
+  /v1/cdns:
+    post:
+      security:
+      - token_auth: []
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/Invoice"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Invoice"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Invoice"
+definitions:
+  Invoice:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      amount:
+        type: "integer"`)},
+		}
+		Convey("When CreateSchemaProvider method is called", func() {
+			p := ProviderOpenAPI{ProviderName: "platform", EmbeddedFS: embeddedFS}
+			tfProvider, err := p.CreateSchemaProvider()
+			Convey("Then the provider should expose the resources and security definitions of all the documents", func() {
+				So(err, ShouldBeNil)
+				So(tfProvider.ResourcesMap, ShouldContainKey, "platform_cdns_v1")
+				So(tfProvider.ResourcesMap, ShouldContainKey, "platform_billing_cdns_v1")
+				So(tfProvider.ResourcesMap["platform_billing_cdns_v1"].Schema, ShouldContainKey, "amount")
+				So(tfProvider.DataSourcesMap, ShouldContainKey, "platform_billing_cdns_v1_instance")
+				So(tfProvider.Schema, ShouldContainKey, "apikey_auth")
+				So(tfProvider.Schema["apikey_auth"].Required, ShouldBeFalse)
+				So(tfProvider.Schema, ShouldContainKey, "token_auth")
+			})
+			Convey("And the API calls of each resource should be made against its document's host and base path", func() {
+				resources, err := p.getProviderResources()
+				So(err, ShouldBeNil)
+				So(resources, ShouldHaveLength, 2)
+				backendConfiguration, err := p.specAnalyser.GetAPIBackendConfiguration()
+				So(err, ShouldBeNil)
+				client := ProviderClient{openAPIBackendConfiguration: backendConfiguration}
+				resourceURL, err := client.getResourceURL(resources[0], nil)
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "https://cdn.api.com/api/v1/cdns")
+				resourceURL, err = client.getResourceURL(resources[1], nil)
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "https://billing.api.com/v1/cdns")
+			})
+		})
+		Convey("When Lint method is called", func() {
+			p := ProviderOpenAPI{ProviderName: "platform", EmbeddedFS: embeddedFS}
+			report, err := p.Lint()
+			Convey("Then the report should explain how the paths of each document are interpreted", func() {
+				So(err, ShouldBeNil)
+				So(report.Paths, ShouldHaveLength, 4)
+				So(report.Paths[1].Document, ShouldEqual, "specs/cdn.yaml")
+				So(report.Paths[1].Path, ShouldEqual, "/v1/cdns/{id}")
+				So(report.Paths[1].Resource, ShouldEqual, "platform_cdns_v1")
+				So(report.Paths[3].Document, ShouldEqual, "specs/billing.yaml")
+				So(report.Paths[3].Path, ShouldEqual, "/v1/cdns/{id}")
+				So(report.Paths[3].Resource, ShouldEqual, "platform_billing_cdns_v1")
+				So(report.Paths[3].DataSourceInstance, ShouldEqual, "platform_billing_cdns_v1_instance")
+				So(report.IsClean(), ShouldBeTrue)
+			})
+		})
+		Convey("When the documents expose resources with the same name", func() {
+			embeddedFS[OpenAPIPluginConfigurationFileName] = &fstest.MapFile{Data: []byte(`version: '1'
+services:
+  platform:
+    swagger_documents:
+    - swagger-url: specs/cdn.yaml
+    - swagger-url: specs/billing.yaml`)}
+			p := ProviderOpenAPI{ProviderName: "platform", EmbeddedFS: embeddedFS}
+			_, err := p.CreateSchemaProvider()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "plugin OpenAPI documents merge error: resource name 'cdns_v1' conflict: it is exposed by both 'specs/cdn.yaml' and 'specs/billing.yaml', please configure a 'resource_name_prefix' for one of them")
+			})
+		})
+	})
+}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mergeDefaultLabels adds the given default labels to the labels property (if the resource has one) of the given payload.
+// The labels configured in the resource take precedence over the default ones with the same key.
+func (r resourceFactory) mergeDefaultLabels(payload map[string]interface{}, defaultLabels map[string]string) {
+	labelsProperty := r.getLabelsProperty()
+	if labelsProperty == nil || len(defaultLabels) == 0 {
+		return
+	}
+	labels := getLabels(payload[labelsProperty.Name])
+	if labelsProperty.isMapProperty() {
+		mergedLabels := map[string]interface{}{}
+		for key, value := range defaultLabels {
+			mergedLabels[key] = value
+		}
+		for key, value := range labels {
+			mergedLabels[key] = value
+		}
+		payload[labelsProperty.Name] = mergedLabels
+		return
+	}
+	mergedLabels, _ := payload[labelsProperty.Name].([]interface{})
+	mergedLabels = append([]interface{}{}, mergedLabels...)
+	keys := []string{}
+	for key := range defaultLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, exists := labels[key]; !exists {
+			mergedLabels = append(mergedLabels, map[string]interface{}{labelsKeyPropertyName: key, labelsValuePropertyName: defaultLabels[key]})
+		}
+	}
+	payload[labelsProperty.Name] = mergedLabels
+}
+
+// removeDefaultLabels removes from the labels property (if the resource has one) of the given payload received from the
+// API the labels injected by the provider, that is, the default labels that are not configured in the resource and whose
+// value is the default one. This way the default labels do not show up as a diff for the resources. On the other hand,
+// the default labels with a different value are kept and the default labels missing in the API (e,g: added to the
+// provider after the resource was created) are stored with an empty value, so an update is planned to apply them.
+func (r resourceFactory) removeDefaultLabels(remoteData map[string]interface{}, resourceLocalData *schema.ResourceData, defaultLabels map[string]string) {
+	labelsProperty := r.getLabelsProperty()
+	if labelsProperty == nil || len(defaultLabels) == 0 || remoteData[labelsProperty.Name] == nil {
+		return
+	}
+	configuredLabels := getLabels(resourceLocalData.Get(labelsProperty.GetTerraformCompliantPropertyName()))
+	remoteLabels := getLabels(remoteData[labelsProperty.Name])
+	isInjected := func(key string, value interface{}) bool {
+		defaultValue, isDefault := defaultLabels[key]
+		_, isConfigured := configuredLabels[key]
+		return isDefault && !isConfigured && value == defaultValue
+	}
+	missingKeys := []string{}
+	for key := range defaultLabels {
+		_, isConfigured := configuredLabels[key]
+		if _, exists := remoteLabels[key]; !exists && !isConfigured {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	sort.Strings(missingKeys)
+	switch remoteLabelsValue := remoteData[labelsProperty.Name].(type) {
+	case map[string]interface{}:
+		labels := map[string]interface{}{}
+		for key, value := range remoteLabelsValue {
+			if !isInjected(key, value) {
+				labels[key] = value
+			}
+		}
+		for _, key := range missingKeys {
+			labels[key] = ""
+		}
+		remoteData[labelsProperty.Name] = labels
+	case []interface{}:
+		labels := []interface{}{}
+		for _, label := range remoteLabelsValue {
+			if object, ok := label.(map[string]interface{}); ok {
+				if key, ok := object[labelsKeyPropertyName].(string); ok && isInjected(key, object[labelsValuePropertyName]) {
+					continue
+				}
+			}
+			labels = append(labels, label)
+		}
+		for _, key := range missingKeys {
+			labels = append(labels, map[string]interface{}{labelsKeyPropertyName: key, labelsValuePropertyName: ""})
+		}
+		remoteData[labelsProperty.Name] = labels
+	}
+}
+
+func (r resourceFactory) getLabelsProperty() *SpecSchemaDefinitionProperty {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return nil
+	}
+	return resourceSchema.getLabelsProperty()
+}
+
+// getLabels returns the labels contained in the given value of the labels property keyed by the label key, either if the
+// property is a map or a list of objects with 'key' and 'value' properties
+func getLabels(value interface{}) map[string]interface{} {
+	labels := map[string]interface{}{}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, labelValue := range value {
+			labels[key] = labelValue
+		}
+	case []interface{}:
+		for _, label := range value {
+			if object, ok := label.(map[string]interface{}); ok {
+				if key, ok := object[labelsKeyPropertyName].(string); ok {
+					labels[key] = object[labelsValuePropertyName]
+				}
+			}
+		}
+	}
+	return labels
+}
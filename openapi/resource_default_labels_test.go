@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLabelsMapProperty(labels map[string]interface{}) *SpecSchemaDefinitionProperty {
+	return &SpecSchemaDefinitionProperty{Name: "labels", Type: TypeMap, IsLabels: true, Default: labels}
+}
+
+func newLabelsListProperty(labels ...interface{}) *SpecSchemaDefinitionProperty {
+	return &SpecSchemaDefinitionProperty{
+		Name:           "labels",
+		Type:           TypeList,
+		ArrayItemsType: TypeObject,
+		IsLabels:       true,
+		Default:        labels,
+		SpecSchemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults(labelsKeyPropertyName, "", true, false, nil),
+				newStringSchemaDefinitionPropertyWithDefaults(labelsValuePropertyName, "", true, false, nil),
+			},
+		},
+	}
+}
+
+func TestCreatePayloadFromLocalStateDataDefaultLabels(t *testing.T) {
+	defaultLabels := map[string]string{"team": "network", "cost_center": "cc1"}
+	testCases := []struct {
+		name           string
+		labelsProperty *SpecSchemaDefinitionProperty
+		defaultLabels  map[string]string
+		expectedLabels interface{}
+	}{
+		{
+			name:           "map labels",
+			labelsProperty: newLabelsMapProperty(map[string]interface{}{"team": "storage"}),
+			defaultLabels:  defaultLabels,
+			expectedLabels: map[string]interface{}{"team": "storage", "cost_center": "cc1"},
+		},
+		{
+			name:           "map labels without default labels",
+			labelsProperty: newLabelsMapProperty(map[string]interface{}{"team": "storage"}),
+			expectedLabels: map[string]interface{}{"team": "storage"},
+		},
+		{
+			name:           "list labels",
+			labelsProperty: newLabelsListProperty(map[string]interface{}{"key": "team", "value": "storage"}),
+			defaultLabels:  defaultLabels,
+			expectedLabels: []interface{}{
+				map[string]interface{}{"key": "team", "value": "storage"},
+				map[string]interface{}{"key": "cost_center", "value": "cc1"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		r, resourceData := testCreateResourceFactory(t, tc.labelsProperty)
+		payload := r.createPayloadFromLocalStateData(resourceData, tc.defaultLabels)
+		assert.Equal(t, tc.expectedLabels, payload["labels"], tc.name)
+	}
+}
+
+func TestCreatePayloadFromLocalStateDataDefaultLabelsNotConfigured(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, stringProperty, newLabelsMapProperty(nil))
+	payload := r.createPayloadFromLocalStateData(resourceData, map[string]string{"team": "network"})
+	assert.Equal(t, map[string]interface{}{"team": "network"}, payload["labels"])
+
+	// resources without labels property are not modified
+	r, resourceData = testCreateResourceFactory(t, stringProperty)
+	payload = r.createPayloadFromLocalStateData(resourceData, map[string]string{"team": "network"})
+	assert.NotContains(t, payload, "labels")
+}
+
+func TestReadDefaultLabels(t *testing.T) {
+	defaultLabels := map[string]string{"team": "network", "cost_center": "cc1", "env": "prod"}
+	testCases := []struct {
+		name           string
+		labelsProperty *SpecSchemaDefinitionProperty
+		remoteLabels   interface{}
+		expectedLabels interface{}
+	}{
+		{
+			name:           "map labels",
+			labelsProperty: newLabelsMapProperty(map[string]interface{}{"team": "storage"}),
+			// the default env label has been modified outside of terraform, so it's kept to show the diff
+			remoteLabels:   map[string]interface{}{"team": "storage", "cost_center": "cc1", "env": "dev", "owner": "jane"},
+			expectedLabels: map[string]interface{}{"team": "storage", "env": "dev", "owner": "jane"},
+		},
+		{
+			name:           "map labels missing a default label",
+			labelsProperty: newLabelsMapProperty(map[string]interface{}{"team": "storage"}),
+			// the default cost_center label was added to the provider after the resource was created, so it's stored
+			// with an empty value to show the diff
+			remoteLabels:   map[string]interface{}{"team": "storage", "env": "prod"},
+			expectedLabels: map[string]interface{}{"team": "storage", "cost_center": ""},
+		},
+		{
+			name:           "map labels configured with the default value",
+			labelsProperty: newLabelsMapProperty(map[string]interface{}{"team": "network"}),
+			remoteLabels:   map[string]interface{}{"team": "network", "cost_center": "cc1", "env": "prod"},
+			expectedLabels: map[string]interface{}{"team": "network"},
+		},
+		{
+			name:           "list labels",
+			labelsProperty: newLabelsListProperty(map[string]interface{}{"key": "team", "value": "storage"}),
+			remoteLabels: []interface{}{
+				map[string]interface{}{"key": "team", "value": "storage"},
+				map[string]interface{}{"key": "cost_center", "value": "cc1"},
+				map[string]interface{}{"key": "env", "value": "dev"},
+			},
+			expectedLabels: []interface{}{
+				map[string]interface{}{"key": "team", "value": "storage"},
+				map[string]interface{}{"key": "env", "value": "dev"},
+			},
+		},
+		{
+			name:           "list labels missing default labels",
+			labelsProperty: newLabelsListProperty(map[string]interface{}{"key": "team", "value": "storage"}),
+			remoteLabels: []interface{}{
+				map[string]interface{}{"key": "team", "value": "storage"},
+			},
+			expectedLabels: []interface{}{
+				map[string]interface{}{"key": "team", "value": "storage"},
+				map[string]interface{}{"key": "cost_center", "value": ""},
+				map[string]interface{}{"key": "env", "value": ""},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, tc.labelsProperty)
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{idProperty.Name: idProperty.Default, "labels": tc.remoteLabels},
+			defaultLabels:   defaultLabels,
+		}
+		err := r.read(resourceData, client)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedLabels, resourceData.Get("labels"), tc.name)
+	}
+}
@@ -25,6 +25,10 @@ type resourceFactory struct {
 // only applicable when remote resource no longer exists and GET operations return 404 NotFound
 const defaultDestroyStatus = "destroyed"
 
+// import natural key separators used when the import ID is provided as a natural key (e,g: attr1=x,attr2=y)
+const importNaturalKeySeparator = ","
+const importNaturalKeyAttributeSeparator = "="
+
 var defaultPollInterval = time.Duration(5 * time.Second)
 var defaultPollMinTimeout = time.Duration(10 * time.Second)
 var defaultPollDelay = time.Duration(1 * time.Second)
@@ -97,13 +101,19 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "")
 
+	openAPIResource, err := configureResourceInstance(r.openAPIResource, data, providerClient)
+	if err != nil {
+		return err
+	}
+	r.openAPIResource = openAPIResource
+
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
 	}
 
 	operation := r.openAPIResource.getResourceOperations().Post
-	requestPayload := r.createPayloadFromLocalStateData(data)
+	requestPayload := r.createPayloadFromLocalStateData(data, providerClient.GetDefaultLabels())
 	responsePayload := map[string]interface{}{}
 
 	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
@@ -125,6 +135,7 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
 
+	r.removeDefaultLabels(responsePayload, data, providerClient.GetDefaultLabels())
 	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
 }
 
@@ -138,6 +149,12 @@ func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{
 
 	submitTelemetryMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "")
 
+	openAPIResource, err := configureResourceInstance(r.openAPIResource, data, openAPIClient)
+	if err != nil {
+		return err
+	}
+	r.openAPIResource = openAPIResource
+
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
@@ -154,6 +171,7 @@ func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{
 		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
 	}
 
+	r.removeDefaultLabels(remoteData, data, openAPIClient.GetDefaultLabels())
 	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
 }
 
@@ -210,6 +228,12 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "")
 
+	openAPIResource, err := configureResourceInstance(r.openAPIResource, data, providerClient)
+	if err != nil {
+		return err
+	}
+	r.openAPIResource = openAPIResource
+
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
@@ -219,7 +243,7 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
 	}
-	requestPayload := r.createPayloadFromLocalStateData(data)
+	requestPayload := r.createPayloadFromLocalStateData(data, providerClient.GetDefaultLabels())
 	if err := r.checkImmutableFields(data, providerClient, parentsIDs...); err != nil {
 		return err
 	}
@@ -254,6 +278,7 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("polling mechanism failed after PUT %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
 
+	r.removeDefaultLabels(responsePayload, data, providerClient.GetDefaultLabels())
 	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
 }
 
@@ -267,6 +292,12 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "")
 
+	openAPIResource, err := configureResourceInstance(r.openAPIResource, data, providerClient)
+	if err != nil {
+		return err
+	}
+	r.openAPIResource = openAPIResource
+
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
@@ -311,6 +342,7 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 
 			results := make([]*schema.ResourceData, 1, 1)
 			results[0] = data
+			var parentIDs []string
 			parentResourceInfo := r.openAPIResource.GetParentResourceInfo()
 			if parentResourceInfo != nil {
 				parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
@@ -333,8 +365,24 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 						return nil, err
 					}
 				}
+				parentIDs = ids[:parentIDsLen]
 				data.SetId(ids[len(ids)-1])
 			}
+			// The instance ID may also be provided as a natural key (e,g: name=my-lb or attr1=x,attr2=y) in which case
+			// the actual instance ID is resolved by looking up the resource via the resource's list operation
+			if importFilters := r.parseImportNaturalKey(data.Id()); importFilters != nil {
+				openAPIResource, err := configureResourceInstance(r.openAPIResource, data, providerClient)
+				if err != nil {
+					return nil, err
+				}
+				importResourceFactory := r
+				importResourceFactory.openAPIResource = openAPIResource
+				id, err := importResourceFactory.resolveImportNaturalKey(data.Id(), importFilters, providerClient, parentIDs...)
+				if err != nil {
+					return nil, err
+				}
+				data.SetId(id)
+			}
 			// If the resources is NOT a sub-resource and just a top level resource then the array passed in will just contain
 			// 	the data object we get from terraform core without any updates.
 			err := r.readWithOptions(data, i, true)
@@ -346,6 +394,67 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 	}
 }
 
+// parseImportNaturalKey returns the filters of the given import ID if it is expressed as a natural key in the form of
+// comma separated attribute=value pairs (e,g: name=my-lb or attr1=x,attr2=y) where all the attributes are primitive
+// properties of the resource schema. Nil is returned otherwise, in which case the import ID is the actual instance ID
+// (which may contain '=' characters too, e,g: base64 encoded IDs).
+func (r resourceFactory) parseImportNaturalKey(importID string) filters {
+	d := newDataSourceFactory(r.openAPIResource)
+	importFilters := filters{}
+	for _, keyValue := range strings.Split(importID, importNaturalKeySeparator) {
+		kv := strings.SplitN(keyValue, importNaturalKeyAttributeSeparator, 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil
+		}
+		if err := d.validateFilterProperty(kv[0]); err != nil {
+			log.Printf("[DEBUG] [resource='%s'] import ID '%s' is not a natural key, '%s' is not a valid attribute: %s", r.openAPIResource.GetResourceName(), importID, kv[0], err)
+			return nil
+		}
+		importFilters = append(importFilters, filter{kv[0], kv[1]})
+	}
+	return importFilters
+}
+
+// resolveImportNaturalKey resolves the given natural key (e,g: name=my-lb or attr1=x,attr2=y) into the actual instance
+// ID. The remote instances are retrieved via the resource's list operation and filtered with the given filters using the
+// same filtering logic as the data sources. An error is returned if the natural key does not match exactly one remote
+// instance.
+func (r resourceFactory) resolveImportNaturalKey(naturalKey string, importFilters filters, providerClient ClientOpenAPI, parentIDs ...string) (string, error) {
+	resourceName := r.openAPIResource.GetResourceName()
+	if r.openAPIResource.getResourceOperations().List == nil {
+		return "", fmt.Errorf("[resource='%s'] can not import using the natural key '%s': resource does not support the list operation", resourceName, naturalKey)
+	}
+	d := newDataSourceFactory(r.openAPIResource)
+
+	resourcePath, err := r.openAPIResource.getResourcePath(parentIDs)
+	if err != nil {
+		return "", err
+	}
+	responsePayload := []map[string]interface{}{}
+	resp, err := providerClient.List(r.openAPIResource, &responsePayload, parentIDs...)
+	if err != nil {
+		return "", err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return "", fmt.Errorf("[resource='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+	}
+
+	filteredResults := d.filterResults(importFilters, responsePayload)
+	if len(filteredResults) == 0 {
+		return "", fmt.Errorf("[resource='%s'] import natural key '%s' did not match any remote instance", resourceName, naturalKey)
+	}
+	if len(filteredResults) > 1 {
+		return "", fmt.Errorf("[resource='%s'] import natural key '%s' matched %d remote instances, please make the natural key more specific so it matches exactly one instance", resourceName, naturalKey, len(filteredResults))
+	}
+
+	id, err := getPayloadID(r.openAPIResource, filteredResults[0])
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[INFO] [resource='%s'] import natural key '%s' resolved to instance ID '%s'", resourceName, naturalKey, id)
+	return id, nil
+}
+
 func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
 	response := operation.responses.getResponse(responseStatusCode)
 
@@ -400,8 +509,12 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 
 func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
+		parentIDs, err := r.getParentIDs(resourceLocalData)
+		if err != nil {
+			return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) parent IDs when waiting: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
+		}
 
-		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
+		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient, parentIDs...)
 		if err != nil {
 			if openapiErr, ok := err.(openapierr.Error); ok {
 				if openapierr.NotFound == openapiErr.Code() {
@@ -426,13 +539,14 @@ func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.R
 	if err != nil {
 		return err
 	}
-	localData := r.createPayloadFromLocalStateData(updatedResourceLocalData)
+	localData := r.createPayloadFromLocalStateData(updatedResourceLocalData, openAPIClient.GetDefaultLabels())
 	s, _ := r.openAPIResource.GetResourceSchema()
 	for _, p := range s.Properties {
 		err := r.validateImmutableProperty(p, remoteData[p.Name], localData[p.Name], false)
 		if err != nil {
 			// Rolling back data so tf values are not stored in the state file; otherwise terraform would store the
 			// data inside the updated (*schema.ResourceData) in the state file
+			r.removeDefaultLabels(remoteData, updatedResourceLocalData, openAPIClient.GetDefaultLabels())
 			updateError := updateStateWithPayloadData(r.openAPIResource, remoteData, updatedResourceLocalData)
 			if updateError != nil {
 				return updateError
@@ -485,6 +599,10 @@ func (r resourceFactory) validateImmutableProperty(property *SpecSchemaDefinitio
 				return fmt.Errorf("user attempted to update an immutable object ('%s') property ('%s'): [user input: %s; actual: %s]", property.Name, objProp.Name, localData, remoteData)
 			}
 		}
+	case TypeMap:
+		if (property.Immutable || checkObjectPropertiesUpdates) && !property.equal(localData, remoteData) {
+			return fmt.Errorf("user attempted to update an immutable map property ('%s'): [user input: %s; actual: %s]", property.Name, localData, remoteData)
+		}
 	default:
 		if property.Immutable || checkObjectPropertiesUpdates { // checkObjectPropertiesUpdates covers the recursive call from objects that are immutable which also make all its properties immutable
 			switch remoteData.(type) {
@@ -516,8 +634,8 @@ func (r resourceFactory) validateImmutableProperty(property *SpecSchemaDefinitio
 // terraform name so the look up in the local state operation works properly. The property names saved in the local state
 // are always converted to terraform compatible names
 // Note the readonly properties will not be posted/put to the API. The payload will always contain the desired state as far
-// as the input is concerned.
-func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *schema.ResourceData) map[string]interface{} {
+// as the input is concerned, including the given default labels (if the resource has a labels property).
+func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *schema.ResourceData, defaultLabels map[string]string) map[string]interface{} {
 	input := map[string]interface{}{}
 	resourceSchema, _ := r.openAPIResource.GetResourceSchema()
 	for _, property := range resourceSchema.Properties {
@@ -536,6 +654,7 @@ func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *sche
 			log.Printf("[DEBUG] [resource='%s'] property payload [propertyName: %s; propertyValue: %+v]", r.openAPIResource.GetResourceName(), propertyName, input[propertyName])
 		}
 	}
+	r.mergeDefaultLabels(input, defaultLabels)
 	log.Printf("[DEBUG] [resource='%s'] createPayloadFromLocalStateData: %s", r.openAPIResource.GetResourceName(), sPrettyPrint(input))
 	return input
 }
@@ -553,6 +672,10 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 	dataValueKind := reflect.TypeOf(dataValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
+		if property.isMapProperty() {
+			input[property.Name] = dataValue
+			return nil
+		}
 		objectInput := map[string]interface{}{}
 		mapValue := dataValue.(map[string]interface{})
 		for propertyName, propertyValue := range mapValue {
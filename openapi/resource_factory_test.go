@@ -1103,6 +1103,98 @@ func TestImporter(t *testing.T) {
 	})
 }
 
+func TestImporterNaturalKey(t *testing.T) {
+	importResource := func(importID string, listOperation *specResourceOperation, responseListPayload []map[string]interface{}) ([]*schema.ResourceData, *clientOpenAPIStub, error) {
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importID)
+		r, resourceData := testCreateResourceFactoryWithID(t, importedIDProperty, stringProperty, boolProperty)
+		r.openAPIResource.(*specStubResource).resourceListOperation = listOperation
+		client := &clientOpenAPIStub{
+			responseListPayload: responseListPayload,
+			responsePayload: map[string]interface{}{
+				stringProperty.Name: "some-name",
+			},
+		}
+		data, err := r.importer().State(resourceData, client)
+		return data, client, err
+	}
+
+	Convey("Given a resource factory configured with a resource that supports the list operation", t, func() {
+		listOperation := &specResourceOperation{}
+		Convey("When the importer State method is invoked with a natural key matching exactly one remote instance", func() {
+			data, client, err := importResource("string_property=some-name", listOperation, []map[string]interface{}{
+				{idProperty.Name: "1234", stringProperty.Name: "some-name"},
+				{idProperty.Name: "5678", stringProperty.Name: "some-other-name"},
+			})
+			Convey("Then the instance ID of the matching remote instance should be imported", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Id(), ShouldEqual, "1234")
+				So(client.idReceived, ShouldEqual, "1234")
+			})
+		})
+		Convey("When the importer State method is invoked with a natural key with multiple attributes matching exactly one remote instance", func() {
+			data, client, err := importResource("string_property=some-name,bool_property=false", listOperation, []map[string]interface{}{
+				{idProperty.Name: "1234", stringProperty.Name: "some-name", boolProperty.Name: true},
+				{idProperty.Name: "5678", stringProperty.Name: "some-name", boolProperty.Name: false},
+			})
+			Convey("Then the instance ID of the matching remote instance should be imported", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Id(), ShouldEqual, "5678")
+				So(client.idReceived, ShouldEqual, "5678")
+			})
+		})
+		Convey("When the importer State method is invoked with a natural key not matching any remote instance", func() {
+			_, _, err := importResource("string_property=non-existing", listOperation, []map[string]interface{}{
+				{idProperty.Name: "1234", stringProperty.Name: "some-name"},
+			})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import natural key 'string_property=non-existing' did not match any remote instance")
+			})
+		})
+		Convey("When the importer State method is invoked with a natural key matching several remote instances", func() {
+			_, _, err := importResource("string_property=some-name", listOperation, []map[string]interface{}{
+				{idProperty.Name: "1234", stringProperty.Name: "some-name"},
+				{idProperty.Name: "5678", stringProperty.Name: "some-name"},
+			})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import natural key 'string_property=some-name' matched 2 remote instances, please make the natural key more specific so it matches exactly one instance")
+			})
+		})
+		Convey("When the importer State method is invoked with an ID containing '=' characters that do not refer to any property", func() {
+			data, client, err := importResource("aWQ9MTIzNA==", listOperation, nil)
+			Convey("Then the import ID should be considered the instance ID", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Id(), ShouldEqual, "aWQ9MTIzNA==")
+				So(client.idReceived, ShouldEqual, "aWQ9MTIzNA==")
+			})
+		})
+		Convey("When the importer State method is invoked with an ID where only some of the attributes are properties of the resource", func() {
+			data, client, err := importResource("string_property=some-name,non_existing_property=some-value", listOperation, nil)
+			Convey("Then the import ID should be considered the instance ID", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Id(), ShouldEqual, "string_property=some-name,non_existing_property=some-value")
+				So(client.idReceived, ShouldEqual, "string_property=some-name,non_existing_property=some-value")
+			})
+		})
+		Convey("When the importer State method is invoked with an ID that is not made of attribute=value pairs", func() {
+			data, client, err := importResource("string_property=some-name,bool_property", listOperation, nil)
+			Convey("Then the import ID should be considered the instance ID", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Id(), ShouldEqual, "string_property=some-name,bool_property")
+				So(client.idReceived, ShouldEqual, "string_property=some-name,bool_property")
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a resource that does not support the list operation", t, func() {
+		Convey("When the importer State method is invoked with a natural key", func() {
+			_, _, err := importResource("string_property=some-name", nil, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] can not import using the natural key 'string_property=some-name': resource does not support the list operation")
+			})
+		})
+	})
+}
+
 func TestHandlePollingIfConfigured(t *testing.T) {
 	Convey("Given a resource factory configured with a resource which has a schema definition containing a status property", t, func() {
 		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
@@ -1948,7 +2040,7 @@ func TestCreatePayloadFromLocalStateData(t *testing.T) {
 		for _, tc := range testCases {
 			r, resourceData := testCreateResourceFactory(t, tc.inputProps...)
 			Convey(fmt.Sprintf("When createPayloadFromLocalStateData method is called: %s", tc.name), func() {
-				payload := r.createPayloadFromLocalStateData(resourceData)
+				payload := r.createPayloadFromLocalStateData(resourceData, nil)
 				Convey("Then the result returned should be the expected one", func() {
 					Println(tc.name)
 					So(payload, ShouldResemble, tc.expectedPayload)
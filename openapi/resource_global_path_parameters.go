@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// globalPathParametersSpecResource is a resource (or data source) whose global path parameters are resolved with the
+// values configured in the resource, which take precedence over the values configured in the provider
+type globalPathParametersSpecResource struct {
+	SpecResource
+	// values contains the global path parameter values keyed by the path parameter name
+	values map[string]string
+}
+
+// getResourcePath returns the resource path with the global path parameters resolved with the resource values, the
+// remaining ones are resolved with the provider values when the resource URL is built
+func (r globalPathParametersSpecResource) getResourcePath(parentIDs []string) (string, error) {
+	resourcePath, err := r.SpecResource.getResourcePath(parentIDs)
+	if err != nil {
+		return "", err
+	}
+	return resolveGlobalPathParameters(resourcePath, r.values)
+}
+
+// configureGlobalPathParameters returns the given resource configured with the global path parameter values set in the
+// resource data. The global path parameters not set in the resource data are populated with the values configured in the
+// provider, so the state keeps track of the values the resource instance belongs to.
+func configureGlobalPathParameters(openAPIResource SpecResource, data *schema.ResourceData, providerClient ClientOpenAPI) (SpecResource, error) {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return openAPIResource, nil
+	}
+	values := map[string]string{}
+	for _, property := range resourceSchema.Properties {
+		if !property.IsGlobalPathParameter {
+			continue
+		}
+		propertyName := property.GetTerraformCompliantPropertyName()
+		value, _ := data.Get(propertyName).(string)
+		if value == "" {
+			value = providerClient.GetGlobalPathParameters()[property.Name]
+			if value == "" {
+				continue
+			}
+			if err := data.Set(propertyName, value); err != nil {
+				return nil, err
+			}
+		}
+		values[property.Name] = value
+	}
+	if len(values) == 0 {
+		return openAPIResource, nil
+	}
+	return globalPathParametersSpecResource{SpecResource: openAPIResource, values: values}, nil
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGlobalPathParameterProperty(name string, value interface{}) *SpecSchemaDefinitionProperty {
+	return &SpecSchemaDefinitionProperty{Name: name, Type: TypeString, Computed: true, ForceNew: true, IsParentProperty: true, IsGlobalPathParameter: true, Default: value}
+}
+
+func TestConfigureGlobalPathParameters(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       *SpecSchemaDefinitionProperty
+		providerValues map[string]string
+		expectedValues map[string]string
+	}{
+		{
+			name:           "global path parameter configured in the resource",
+			property:       newGlobalPathParameterProperty("account_id", "5678"),
+			providerValues: map[string]string{"account_id": "1234"},
+			expectedValues: map[string]string{"account_id": "5678"},
+		},
+		{
+			name:           "global path parameter configured in the provider",
+			property:       newGlobalPathParameterProperty("account_id", nil),
+			providerValues: map[string]string{"account_id": "1234"},
+			expectedValues: map[string]string{"account_id": "1234"},
+		},
+	}
+	for _, tc := range testCases {
+		r, resourceData := testCreateResourceFactory(t, stringProperty, tc.property)
+		openAPIResource, err := configureGlobalPathParameters(r.openAPIResource, resourceData, &clientOpenAPIStub{globalPathParameters: tc.providerValues})
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, globalPathParametersSpecResource{SpecResource: r.openAPIResource, values: tc.expectedValues}, openAPIResource, tc.name)
+		assert.Equal(t, tc.expectedValues["account_id"], resourceData.Get("account_id"), tc.name)
+	}
+}
+
+func TestConfigureGlobalPathParametersNotConfigured(t *testing.T) {
+	// resources without global path parameters are not modified
+	r, resourceData := testCreateResourceFactory(t, stringProperty)
+	openAPIResource, err := configureGlobalPathParameters(r.openAPIResource, resourceData, &clientOpenAPIStub{globalPathParameters: map[string]string{"account_id": "1234"}})
+	require.NoError(t, err)
+	assert.Equal(t, r.openAPIResource, openAPIResource)
+
+	// the path parameters with no value in the resource nor the provider are resolved (and reported if missing) when the
+	// resource URL is built
+	r, resourceData = testCreateResourceFactory(t, stringProperty, newGlobalPathParameterProperty("account_id", nil))
+	openAPIResource, err = configureGlobalPathParameters(r.openAPIResource, resourceData, &clientOpenAPIStub{})
+	require.NoError(t, err)
+	assert.Equal(t, r.openAPIResource, openAPIResource)
+}
+
+func TestGlobalPathParametersSpecResourceGetResourcePath(t *testing.T) {
+	r := globalPathParametersSpecResource{
+		SpecResource: &SpecV2Resource{Path: "/v1/accounts/{account_id}/cdns/{cdn_id}/firewalls", globalPathParameters: []string{"account_id"}},
+		values:       map[string]string{"account_id": "1234"},
+	}
+	resourcePath, err := r.getResourcePath([]string{"cdn1"})
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/accounts/1234/cdns/cdn1/firewalls", resourcePath)
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// regionSpecResource is a resource (or data source) of a multi-region provider. The resource exposes the region argument
+// so the region configured in the provider can be overridden per resource
+type regionSpecResource struct {
+	SpecResource
+	// regions contains the regions supported by the provider
+	regions []string
+	// region contains the region the resource instance is managed in, empty if not resolved yet
+	region string
+}
+
+// NewRegionSpecResource returns the given resource with the region argument added to its schema. The resources that
+// already have a property named region in the OpenAPI document are returned as is.
+func NewRegionSpecResource(openAPIResource SpecResource, regions []string) SpecResource {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return openAPIResource
+	}
+	if property, _ := resourceSchema.getPropertyBasedOnTerraformName(providerPropertyRegion); property != nil {
+		log.Printf("[WARN] resource '%s' already has a '%s' property, the region configured in the provider can not be overridden for this resource", openAPIResource.GetResourceName(), providerPropertyRegion)
+		return openAPIResource
+	}
+	return regionSpecResource{SpecResource: openAPIResource, regions: regions}
+}
+
+// GetResourceSchema returns the resource schema including the region property. The region property is a parent property
+// so it is not sent to the API, and changing it forces the creation of a new resource
+func (r regionSpecResource) GetResourceSchema() (*SpecSchemaDefinition, error) {
+	resourceSchema, err := r.SpecResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	properties := append(SpecSchemaDefinitionProperties{}, resourceSchema.Properties...)
+	properties = append(properties, &SpecSchemaDefinitionProperty{
+		Name:             providerPropertyRegion,
+		Type:             TypeString,
+		Description:      fmt.Sprintf("The region the resource is managed in (%s), if not set the region configured in the provider is used", strings.Join(r.regions, ", ")),
+		Computed:         true,
+		ForceNew:         true,
+		IsParentProperty: true,
+		AllowedValues:    r.regions,
+	})
+	return &SpecSchemaDefinition{Properties: properties}, nil
+}
+
+func (r regionSpecResource) getRegion() string {
+	return r.region
+}
+
+// configureRegion returns the given resource configured with the region set in the resource data. If the region is not
+// set in the resource data, the region configured in the provider is used and stored in the resource data so the state
+// keeps track of the region the resource instance belongs to.
+func configureRegion(openAPIResource SpecResource, data *schema.ResourceData, providerClient ClientOpenAPI) (SpecResource, error) {
+	regionResource, ok := openAPIResource.(regionSpecResource)
+	if !ok {
+		return openAPIResource, nil
+	}
+	region, _ := data.Get(providerPropertyRegion).(string)
+	if region == "" {
+		providerRegion, err := providerClient.GetRegion()
+		if err != nil {
+			return nil, err
+		}
+		if providerRegion == "" {
+			return openAPIResource, nil
+		}
+		if err := data.Set(providerPropertyRegion, providerRegion); err != nil {
+			return nil, err
+		}
+		region = providerRegion
+	}
+	regionResource.region = region
+	return regionResource, nil
+}
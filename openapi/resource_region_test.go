@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegionSpecResource(t *testing.T) {
+	openAPIResource := newSpecStubResource("cdns", "/v1/cdns", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{stringProperty}})
+	regionResource := NewRegionSpecResource(openAPIResource, []string{"rst", "dub"})
+	assert.Equal(t, regionSpecResource{SpecResource: openAPIResource, regions: []string{"rst", "dub"}}, regionResource)
+
+	resourceSchema, err := regionResource.GetResourceSchema()
+	require.NoError(t, err)
+	assert.Equal(t, SpecSchemaDefinitionProperties{
+		stringProperty,
+		{Name: "region", Type: TypeString, Description: "The region the resource is managed in (rst, dub), if not set the region configured in the provider is used", Computed: true, ForceNew: true, IsParentProperty: true, AllowedValues: []string{"rst", "dub"}},
+	}, resourceSchema.Properties)
+	// the schema of the wrapped resource is not modified
+	assert.Len(t, openAPIResource.schemaDefinition.Properties, 1)
+}
+
+func TestNewRegionSpecResourceWithRegionProperty(t *testing.T) {
+	openAPIResource := newSpecStubResource("cdns", "/v1/cdns", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("region", "", true, false, nil)}})
+	assert.Equal(t, openAPIResource, NewRegionSpecResource(openAPIResource, []string{"rst", "dub"}))
+}
+
+func TestConfigureRegion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		region         string
+		providerRegion string
+		expectedRegion string
+	}{
+		{
+			name:           "region configured in the resource",
+			region:         "dub",
+			providerRegion: "rst",
+			expectedRegion: "dub",
+		},
+		{
+			name:           "region configured in the provider",
+			providerRegion: "rst",
+			expectedRegion: "rst",
+		},
+	}
+	for _, tc := range testCases {
+		r, resourceData := testCreateResourceFactory(t, stringProperty)
+		regionResource := NewRegionSpecResource(r.openAPIResource, []string{"rst", "dub"})
+		resourceSchema, err := regionResource.GetResourceSchema()
+		require.NoError(t, err, tc.name)
+		resourceData = newTestSchema(resourceSchema.Properties...).getResourceData(t)
+		if tc.region != "" {
+			require.NoError(t, resourceData.Set("region", tc.region), tc.name)
+		}
+		openAPIResource, err := configureRegion(regionResource, resourceData, &clientOpenAPIStub{region: tc.providerRegion})
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRegion, openAPIResource.getRegion(), tc.name)
+		assert.Equal(t, tc.expectedRegion, resourceData.Get("region"), tc.name)
+	}
+}
+
+func TestConfigureRegionNotMultiRegion(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, stringProperty)
+	openAPIResource, err := configureRegion(r.openAPIResource, resourceData, &clientOpenAPIStub{region: "rst"})
+	require.NoError(t, err)
+	assert.Equal(t, r.openAPIResource, openAPIResource)
+	assert.Empty(t, openAPIResource.getRegion())
+}
@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// remoteInstance represents an object that exists in the service provider's API for a given resource
+type remoteInstance struct {
+	resource SpecResource
+	id       string
+	// parentIDs contains the IDs of the parent instances this instance belongs to (only populated for sub-resources)
+	parentIDs []string
+	payload   map[string]interface{}
+}
+
+// getImportID returns the ID expected by the resource's importer, that is the instance ID for root resources and the
+// parent IDs followed by the instance ID separated by forward slashes for sub-resources (e,g: parentID/id)
+func (i remoteInstance) getImportID() string {
+	return strings.Join(append(append([]string{}, i.parentIDs...), i.id), "/")
+}
+
+// getInstanceParentIDs returns the parent IDs that the sub-resources of this instance belong to
+func (i remoteInstance) getInstanceParentIDs() []string {
+	return append(append([]string{}, i.parentIDs...), i.id)
+}
+
+// createResourceData returns a schema.ResourceData for the instance populated with the instance ID and the parent
+// properties, so the resource operations implemented in the resourceFactory (e,g: delete, polling) can be reused for
+// instances that are not managed by Terraform
+func (i remoteInstance) createResourceData() (*schema.ResourceData, error) {
+	tfResource, err := newResourceFactory(i.resource).createTerraformResource()
+	if err != nil {
+		return nil, err
+	}
+	data := tfResource.Data(&terraform.InstanceState{ID: i.id})
+	if parentResourceInfo := i.resource.GetParentResourceInfo(); parentResourceInfo != nil {
+		for idx, parentPropertyName := range parentResourceInfo.GetParentPropertiesNames() {
+			if idx >= len(i.parentIDs) {
+				break
+			}
+			if err := data.Set(parentPropertyName, i.parentIDs[idx]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
+
+// sortResourcesByParentDepth returns the given resources sorted so parent resources always come before their
+// sub-resources. Resources with the same depth are sorted by name to keep the order deterministic.
+func sortResourcesByParentDepth(resources []SpecResource) []SpecResource {
+	sortedResources := append([]SpecResource{}, resources...)
+	sort.SliceStable(sortedResources, func(i, j int) bool {
+		depthI, depthJ := getResourceParentDepth(sortedResources[i]), getResourceParentDepth(sortedResources[j])
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+		return sortedResources[i].GetResourceName() < sortedResources[j].GetResourceName()
+	})
+	return sortedResources
+}
+
+func getResourceParentDepth(resource SpecResource) int {
+	parentResourceInfo := resource.GetParentResourceInfo()
+	if parentResourceInfo == nil {
+		return 0
+	}
+	return len(parentResourceInfo.parentResourceNames)
+}
+
+// listRemoteInstances enumerates the remote instances of the given resources using their list operations. Sub-resources
+// are walked through the instances of their parents, hence the returned instances are sorted parents first. Resources
+// that do not support the list operation (or whose list operation fails) are skipped with a warning, as well as their
+// sub-resources.
+func listRemoteInstances(providerClient ClientOpenAPI, resources []SpecResource) []remoteInstance {
+	remoteInstances := []remoteInstance{}
+	instancesPerResource := map[string][]remoteInstance{}
+	for _, resource := range sortResourcesByParentDepth(resources) {
+		resourceName := resource.GetResourceName()
+		if resource.getResourceOperations().List == nil {
+			log.Printf("[WARN] [resource='%s'] skipping resource since it does not support the list operation", resourceName)
+			continue
+		}
+
+		parentIDsList := [][]string{{}}
+		if parentResourceInfo := resource.GetParentResourceInfo(); parentResourceInfo != nil {
+			parentInstances, exists := instancesPerResource[parentResourceInfo.fullParentResourceName]
+			if !exists {
+				log.Printf("[WARN] [resource='%s'] skipping sub-resource since the instances of its parent resource '%s' could not be listed", resourceName, parentResourceInfo.fullParentResourceName)
+				continue
+			}
+			parentIDsList = [][]string{}
+			for _, parentInstance := range parentInstances {
+				parentIDsList = append(parentIDsList, parentInstance.getInstanceParentIDs())
+			}
+		}
+
+		resourceInstances := []remoteInstance{}
+		for _, parentIDs := range parentIDsList {
+			instances, err := listResourceRemoteInstances(providerClient, resource, parentIDs...)
+			if err != nil {
+				log.Printf("[WARN] [resource='%s'] skipping remote instances with parent IDs %v: %s", resourceName, parentIDs, err)
+				continue
+			}
+			resourceInstances = append(resourceInstances, instances...)
+		}
+		log.Printf("[INFO] [resource='%s'] found %d remote instances", resourceName, len(resourceInstances))
+		instancesPerResource[resourceName] = resourceInstances
+		remoteInstances = append(remoteInstances, resourceInstances...)
+	}
+	return remoteInstances
+}
+
+// listResourceRemoteInstances returns the remote instances of the given resource that belong to the given parent IDs
+func listResourceRemoteInstances(providerClient ClientOpenAPI, resource SpecResource, parentIDs ...string) ([]remoteInstance, error) {
+	resourcePath, err := resource.getResourcePath(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	responsePayload := []map[string]interface{}{}
+	resp, err := providerClient.List(resource, &responsePayload, parentIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHTTPStatusCode(resource, resp, []int{http.StatusOK}); err != nil {
+		return nil, fmt.Errorf("[resource='%s'] GET %s failed: %s", resource.GetResourceName(), resourcePath, err)
+	}
+	instances := []remoteInstance{}
+	for _, payload := range responsePayload {
+		id, err := getPayloadID(resource, payload)
+		if err != nil {
+			return nil, fmt.Errorf("[resource='%s'] GET %s returned an item with no identifier: %s", resource.GetResourceName(), resourcePath, err)
+		}
+		instances = append(instances, remoteInstance{
+			resource:  resource,
+			id:        id,
+			parentIDs: parentIDs,
+			payload:   payload,
+		})
+	}
+	return instances, nil
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSortResourcesByParentDepth(t *testing.T) {
+	Convey("Given a list of resources containing sub-resources listed before their parents", t, func() {
+		subSubResource := &specStubResource{name: "cdns_v1_firewalls_v1_rules_v1", parentResourceNames: []string{"cdns_v1", "firewalls_v1"}, fullParentResourceName: "cdns_v1_firewalls_v1"}
+		subResource := &specStubResource{name: "cdns_v1_firewalls_v1", parentResourceNames: []string{"cdns_v1"}, fullParentResourceName: "cdns_v1"}
+		rootResource := &specStubResource{name: "cdns_v1"}
+		otherRootResource := &specStubResource{name: "lbs_v1"}
+		resources := []SpecResource{subSubResource, otherRootResource, subResource, rootResource}
+		Convey("When sortResourcesByParentDepth is called", func() {
+			sortedResources := sortResourcesByParentDepth(resources)
+			Convey("Then the resources returned should be sorted parents first and then by name", func() {
+				So(sortedResources, ShouldResemble, []SpecResource{rootResource, otherRootResource, subResource, subSubResource})
+			})
+			Convey("And the original list should not be modified", func() {
+				So(resources, ShouldResemble, []SpecResource{subSubResource, otherRootResource, subResource, rootResource})
+			})
+		})
+	})
+}
+
+func TestListRemoteInstances(t *testing.T) {
+	Convey("Given a provider client and a resource with a sub-resource", t, func() {
+		schemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+			},
+		}
+		rootResource := &specStubResource{name: "cdns_v1", schemaDefinition: schemaDefinition, resourceListOperation: &specResourceOperation{}}
+		subResource := &specStubResource{name: "cdns_v1_firewalls_v1", schemaDefinition: schemaDefinition, resourceListOperation: &specResourceOperation{}, parentResourceNames: []string{"cdns_v1"}, fullParentResourceName: "cdns_v1"}
+		client := &clientOpenAPIStub{
+			funcList: func(resource SpecResource, parentIDs ...string) []map[string]interface{} {
+				if resource.GetResourceName() == "cdns_v1" {
+					return []map[string]interface{}{{"id": "cdn1"}, {"id": "cdn2"}}
+				}
+				return []map[string]interface{}{{"id": parentIDs[0] + "-fw"}}
+			},
+		}
+		Convey("When listRemoteInstances is called", func() {
+			instances := listRemoteInstances(client, []SpecResource{subResource, rootResource})
+			Convey("Then the parent instances should be returned first and the sub-resources should be walked through each of their parents", func() {
+				So(len(instances), ShouldEqual, 4)
+				So(instances[0].resource, ShouldEqual, rootResource)
+				So(instances[0].getImportID(), ShouldEqual, "cdn1")
+				So(instances[1].resource, ShouldEqual, rootResource)
+				So(instances[1].getImportID(), ShouldEqual, "cdn2")
+				So(instances[2].resource, ShouldEqual, subResource)
+				So(instances[2].parentIDs, ShouldResemble, []string{"cdn1"})
+				So(instances[2].getImportID(), ShouldEqual, "cdn1/cdn1-fw")
+				So(instances[3].resource, ShouldEqual, subResource)
+				So(instances[3].getImportID(), ShouldEqual, "cdn2/cdn2-fw")
+			})
+		})
+		Convey("When listRemoteInstances is called and the parent resource does not support the list operation", func() {
+			rootResource.resourceListOperation = nil
+			instances := listRemoteInstances(client, []SpecResource{subResource, rootResource})
+			Convey("Then both the resource and its sub-resources should be skipped", func() {
+				So(instances, ShouldBeEmpty)
+			})
+		})
+		Convey("When listRemoteInstances is called and the API returns a non expected status code", func() {
+			client.returnHTTPCode = 500
+			instances := listRemoteInstances(client, []SpecResource{rootResource})
+			Convey("Then the resource should be skipped", func() {
+				So(instances, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// defaultSweeperAgeAttribute is the property used to determine the age of a remote instance if SweeperConfig.AgeAttribute is not specified
+const defaultSweeperAgeAttribute = "created_at"
+
+// SweeperConfig defines the criteria used to select the remote instances to be swept. An instance is swept only if it
+// matches all the criteria configured.
+type SweeperConfig struct {
+	// AttributePatterns contains the regular expressions keyed by property name that the instance's property values must
+	// match, e,g: {"name": "^tf-acc-test-"} will match the instances whose name starts with 'tf-acc-test-'
+	AttributePatterns map[string]string
+	// OlderThan if greater than zero, only the instances created more than OlderThan ago are matched
+	OlderThan time.Duration
+	// AgeAttribute is the property containing the instance's creation time expressed in RFC3339 format or as a unix
+	// timestamp (seconds). Defaults to created_at
+	AgeAttribute string
+	// DryRun if true the matching instances are only reported but not deleted
+	DryRun bool
+}
+
+// SweptInstance contains the result of sweeping a remote instance
+type SweptInstance struct {
+	// ResourceName is the provider's resource name (e,g: openapi_cdns_v1)
+	ResourceName string
+	// ID is the instance ID, for sub-resources the parent IDs are included (e,g: parentID/id)
+	ID string
+	// Deleted is true if the instance was deleted successfully. It's always false when the sweeper runs in dry run mode
+	Deleted bool
+	// Err contains the error returned when deleting the instance, if any
+	Err error
+}
+
+// Sweep deletes the remote instances of all the resources exposed by the provider that match the given configuration. The
+// remote instances are enumerated using the resources' list operations and deleted children first so sub-resources are
+// always deleted before their parents. The provider is configured using the environment variables matching the provider's
+// property names in upper case (e,g: APIKEY_AUTH).
+func (p *ProviderOpenAPI) Sweep(config SweeperConfig) ([]SweptInstance, error) {
+	if _, err := p.CreateSchemaProvider(); err != nil {
+		return nil, err
+	}
+	providerClient, err := p.createProviderClient()
+	if err != nil {
+		return nil, err
+	}
+	resources, err := p.getProviderResources()
+	if err != nil {
+		return nil, err
+	}
+	sweeper, err := newResourceSweeper(p.ProviderName, resources, providerClient, config)
+	if err != nil {
+		return nil, err
+	}
+	return sweeper.sweep(), nil
+}
+
+type resourceSweeper struct {
+	providerName      string
+	resources         []SpecResource
+	providerClient    ClientOpenAPI
+	attributePatterns map[string]*regexp.Regexp
+	olderThan         time.Duration
+	ageAttribute      string
+	dryRun            bool
+	now               func() time.Time
+}
+
+func newResourceSweeper(providerName string, resources []SpecResource, providerClient ClientOpenAPI, config SweeperConfig) (*resourceSweeper, error) {
+	if len(config.AttributePatterns) == 0 && config.OlderThan <= 0 {
+		return nil, fmt.Errorf("sweeper configuration must specify at least one attribute pattern or a minimum age to prevent sweeping all the remote instances")
+	}
+	attributePatterns := map[string]*regexp.Regexp{}
+	for attribute, pattern := range config.AttributePatterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("sweeper pattern '%s' for attribute '%s' is not a valid regular expression: %s", pattern, attribute, err)
+		}
+		attributePatterns[attribute] = r
+	}
+	ageAttribute := config.AgeAttribute
+	if ageAttribute == "" {
+		ageAttribute = defaultSweeperAgeAttribute
+	}
+	return &resourceSweeper{
+		providerName:      providerName,
+		resources:         resources,
+		providerClient:    providerClient,
+		attributePatterns: attributePatterns,
+		olderThan:         config.OlderThan,
+		ageAttribute:      ageAttribute,
+		dryRun:            config.DryRun,
+		now:               time.Now,
+	}, nil
+}
+
+// sweep deletes the matching remote instances. Since listRemoteInstances returns the instances parents first, the list is
+// walked backwards so sub-resources are deleted before their parents.
+func (s *resourceSweeper) sweep() []SweptInstance {
+	sweptInstances := []SweptInstance{}
+	instances := listRemoteInstances(s.providerClient, s.resources)
+	for i := len(instances) - 1; i >= 0; i-- {
+		instance := instances[i]
+		if !s.matches(instance) {
+			continue
+		}
+		sweptInstance := SweptInstance{
+			ResourceName: fmt.Sprintf("%s_%s", s.providerName, instance.resource.GetResourceName()),
+			ID:           instance.getImportID(),
+		}
+		if s.dryRun {
+			log.Printf("[INFO] [resource='%s'] dry run: instance '%s' would be swept", instance.resource.GetResourceName(), sweptInstance.ID)
+		} else {
+			sweptInstance.Err = s.delete(instance)
+			sweptInstance.Deleted = sweptInstance.Err == nil
+		}
+		sweptInstances = append(sweptInstances, sweptInstance)
+	}
+	return sweptInstances
+}
+
+// delete deletes the given remote instance reusing the resource's delete operation, including the polling mechanism if
+// the resource's delete operation is configured with it
+func (s *resourceSweeper) delete(instance remoteInstance) error {
+	r := newResourceFactory(instance.resource)
+	data, err := instance.createResourceData()
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] [resource='%s'] sweeping instance '%s'", instance.resource.GetResourceName(), instance.getImportID())
+	return r.delete(data, s.providerClient)
+}
+
+// matches checks whether the given instance matches all the attribute patterns and the minimum age configured
+func (s *resourceSweeper) matches(instance remoteInstance) bool {
+	for attribute, pattern := range s.attributePatterns {
+		value, exists := instance.payload[attribute]
+		if !exists || value == nil {
+			return false
+		}
+		if !pattern.MatchString(fmt.Sprintf("%v", value)) {
+			return false
+		}
+	}
+	if s.olderThan > 0 {
+		createdAt, err := s.getCreationTime(instance)
+		if err != nil {
+			log.Printf("[DEBUG] [resource='%s'] instance '%s' can not be matched by age: %s", instance.resource.GetResourceName(), instance.getImportID(), err)
+			return false
+		}
+		if s.now().Sub(createdAt) < s.olderThan {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *resourceSweeper) getCreationTime(instance remoteInstance) (time.Time, error) {
+	switch value := instance.payload[s.ageAttribute].(type) {
+	case string:
+		if createdAt, err := time.Parse(time.RFC3339, value); err == nil {
+			return createdAt, nil
+		}
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Unix(seconds, 0), nil
+		}
+		return time.Time{}, fmt.Errorf("attribute '%s' value '%s' is neither a RFC3339 date nor a unix timestamp", s.ageAttribute, value)
+	case float64:
+		return time.Unix(int64(value), 0), nil
+	case int:
+		return time.Unix(int64(value), 0), nil
+	}
+	return time.Time{}, fmt.Errorf("attribute '%s' not found", s.ageAttribute)
+}
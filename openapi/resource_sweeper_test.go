@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewResourceSweeper(t *testing.T) {
+	Convey("Given a sweeper configuration with no attribute patterns nor minimum age", t, func() {
+		config := SweeperConfig{DryRun: true}
+		Convey("When newResourceSweeper is called", func() {
+			_, err := newResourceSweeper("openapi", nil, nil, config)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "sweeper configuration must specify at least one attribute pattern or a minimum age to prevent sweeping all the remote instances")
+			})
+		})
+	})
+	Convey("Given a sweeper configuration with an invalid attribute pattern", t, func() {
+		config := SweeperConfig{AttributePatterns: map[string]string{"name": "["}}
+		Convey("When newResourceSweeper is called", func() {
+			_, err := newResourceSweeper("openapi", nil, nil, config)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "sweeper pattern '[' for attribute 'name' is not a valid regular expression: error parsing regexp: missing closing ]: `[`")
+			})
+		})
+	})
+	Convey("Given a sweeper configuration with a minimum age and no age attribute", t, func() {
+		config := SweeperConfig{OlderThan: time.Hour}
+		Convey("When newResourceSweeper is called", func() {
+			sweeper, err := newResourceSweeper("openapi", nil, nil, config)
+			Convey("Then the sweeper should use the default age attribute", func() {
+				So(err, ShouldBeNil)
+				So(sweeper.ageAttribute, ShouldEqual, defaultSweeperAgeAttribute)
+			})
+		})
+	})
+}
+
+func TestResourceSweeperMatches(t *testing.T) {
+	now := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		config        SweeperConfig
+		payload       map[string]interface{}
+		expectedMatch bool
+	}{
+		{
+			name:          "name prefix matching",
+			config:        SweeperConfig{AttributePatterns: map[string]string{"name": "^tf-acc-"}},
+			payload:       map[string]interface{}{"name": "tf-acc-cdn"},
+			expectedMatch: true,
+		},
+		{
+			name:          "name prefix not matching",
+			config:        SweeperConfig{AttributePatterns: map[string]string{"name": "^tf-acc-"}},
+			payload:       map[string]interface{}{"name": "prod-cdn"},
+			expectedMatch: false,
+		},
+		{
+			name:          "attribute pattern configured but the attribute is missing",
+			config:        SweeperConfig{AttributePatterns: map[string]string{"name": "^tf-acc-"}},
+			payload:       map[string]interface{}{},
+			expectedMatch: false,
+		},
+		{
+			name:          "older than matching with RFC3339 creation date",
+			config:        SweeperConfig{OlderThan: 12 * time.Hour},
+			payload:       map[string]interface{}{"created_at": "2021-01-01T00:00:00Z"},
+			expectedMatch: true,
+		},
+		{
+			name:          "older than not matching with RFC3339 creation date",
+			config:        SweeperConfig{OlderThan: 48 * time.Hour},
+			payload:       map[string]interface{}{"created_at": "2021-01-01T00:00:00Z"},
+			expectedMatch: false,
+		},
+		{
+			name:          "older than matching with unix timestamp in a custom age attribute",
+			config:        SweeperConfig{OlderThan: 12 * time.Hour, AgeAttribute: "creation_time"},
+			payload:       map[string]interface{}{"creation_time": float64(now.Add(-24 * time.Hour).Unix())},
+			expectedMatch: true,
+		},
+		{
+			name:          "older than configured but the creation date is not valid",
+			config:        SweeperConfig{OlderThan: 12 * time.Hour},
+			payload:       map[string]interface{}{"created_at": "yesterday"},
+			expectedMatch: false,
+		},
+		{
+			name:          "both name prefix and older than configured but only the name matches",
+			config:        SweeperConfig{AttributePatterns: map[string]string{"name": "^tf-acc-"}, OlderThan: 48 * time.Hour},
+			payload:       map[string]interface{}{"name": "tf-acc-cdn", "created_at": "2021-01-01T00:00:00Z"},
+			expectedMatch: false,
+		},
+	}
+	for _, tc := range testCases {
+		sweeper, err := newResourceSweeper("openapi", nil, nil, tc.config)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", tc.name, err)
+		}
+		sweeper.now = func() time.Time { return now }
+		match := sweeper.matches(remoteInstance{resource: &specStubResource{name: "cdns_v1"}, id: "1234", payload: tc.payload})
+		if match != tc.expectedMatch {
+			t.Errorf("%s: expected match %t but got %t", tc.name, tc.expectedMatch, match)
+		}
+	}
+}
+
+func TestResourceSweeperSweep(t *testing.T) {
+	Convey("Given a resourceSweeper configured with a name pattern and a resource with a sub-resource that have remote instances", t, func() {
+		schemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+			},
+		}
+		subResourceSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: TypeString, Required: true, IsParentProperty: true},
+			},
+		}
+		deleteOperation := &specResourceOperation{responses: specResponses{}}
+		rootResource := newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, schemaDefinition, nil, nil, nil, deleteOperation)
+		rootResource.resourceListOperation = &specResourceOperation{}
+		subResource := newSpecStubResourceWithOperations("cdns_v1_firewalls_v1", "/v1/cdns/{id}/v1/firewalls", false, subResourceSchemaDefinition, nil, nil, nil, deleteOperation)
+		subResource.resourceListOperation = &specResourceOperation{}
+		subResource.parentResourceNames = []string{"cdns_v1"}
+		subResource.fullParentResourceName = "cdns_v1"
+
+		deleted := []string{}
+		client := &clientOpenAPIStub{
+			funcList: func(resource SpecResource, parentIDs ...string) []map[string]interface{} {
+				if resource.GetResourceName() == "cdns_v1" {
+					return []map[string]interface{}{{"id": "1", "name": "tf-acc-cdn"}, {"id": "2", "name": "prod-cdn"}}
+				}
+				return []map[string]interface{}{{"id": "fw", "name": fmt.Sprintf("tf-acc-fw-%s", parentIDs[0])}}
+			},
+			funcDelete: func(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+				deleted = append(deleted, strings.Join(append(append([]string{}, parentIDs...), id), "/"))
+				return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			},
+		}
+		config := SweeperConfig{AttributePatterns: map[string]string{"name": "^tf-acc-"}}
+		Convey("When sweep is called", func() {
+			sweeper, err := newResourceSweeper("openapi", []SpecResource{rootResource, subResource}, client, config)
+			So(err, ShouldBeNil)
+			sweptInstances := sweeper.sweep()
+			Convey("Then only the matching instances should be deleted, children before parents", func() {
+				So(deleted, ShouldResemble, []string{"2/fw", "1/fw", "1"})
+				So(sweptInstances, ShouldResemble, []SweptInstance{
+					{ResourceName: "openapi_cdns_v1_firewalls_v1", ID: "2/fw", Deleted: true},
+					{ResourceName: "openapi_cdns_v1_firewalls_v1", ID: "1/fw", Deleted: true},
+					{ResourceName: "openapi_cdns_v1", ID: "1", Deleted: true},
+				})
+			})
+		})
+		Convey("When sweep is called in dry run mode", func() {
+			config.DryRun = true
+			sweeper, err := newResourceSweeper("openapi", []SpecResource{rootResource, subResource}, client, config)
+			So(err, ShouldBeNil)
+			sweptInstances := sweeper.sweep()
+			Convey("Then the matching instances should be reported but not deleted", func() {
+				So(deleted, ShouldBeEmpty)
+				So(sweptInstances, ShouldResemble, []SweptInstance{
+					{ResourceName: "openapi_cdns_v1_firewalls_v1", ID: "2/fw"},
+					{ResourceName: "openapi_cdns_v1_firewalls_v1", ID: "1/fw"},
+					{ResourceName: "openapi_cdns_v1", ID: "1"},
+				})
+			})
+		})
+		Convey("When sweep is called and the API fails to delete an instance", func() {
+			client.funcDelete = func(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+				return nil, fmt.Errorf("some error")
+			}
+			sweeper, err := newResourceSweeper("openapi", []SpecResource{rootResource}, client, config)
+			So(err, ShouldBeNil)
+			sweptInstances := sweeper.sweep()
+			Convey("Then the error should be reported for the instance", func() {
+				So(len(sweptInstances), ShouldEqual, 1)
+				So(sweptInstances[0].Deleted, ShouldBeFalse)
+				So(sweptInstances[0].Err.Error(), ShouldEqual, "some error")
+			})
+		})
+	})
+}
@@ -0,0 +1,388 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// terraformConfigLabelPropertyNames contains the properties (in order of preference) used to build the Terraform resource
+// labels for the generated resource blocks. If the remote instance does not have any of them, the instance ID is used instead.
+var terraformConfigLabelPropertyNames = []string{"name", "label"}
+
+var terraformConfigLabelInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// terraformConfigGenerator generates Terraform configuration files containing resource and import blocks for the remote
+// instances that already exist in the service provider's API.
+type terraformConfigGenerator struct {
+	providerName   string
+	resources      []SpecResource
+	providerClient ClientOpenAPI
+	// labels contains the labels already used per resource, so they are not duplicated
+	labels map[string]map[string]bool
+	// instanceLabels contains the label assigned to each instance keyed by resource name and import ID, it's used to
+	// reference the parent instances from their sub-resources
+	instanceLabels map[string]string
+}
+
+// GenerateConfig enumerates the remote instances of all the resources exposed by the provider and writes into the given
+// output directory one .tf file per resource containing the resource and import blocks for each of the remote instances
+// found. The list of files created is returned. The provider is configured using the environment variables matching
+// the provider's property names in upper case (e,g: APIKEY_AUTH).
+func (p *ProviderOpenAPI) GenerateConfig(outputDir string) ([]string, error) {
+	if _, err := p.CreateSchemaProvider(); err != nil {
+		return nil, err
+	}
+	providerClient, err := p.createProviderClient()
+	if err != nil {
+		return nil, err
+	}
+	resources, err := p.getProviderResources()
+	if err != nil {
+		return nil, err
+	}
+	return newTerraformConfigGenerator(p.ProviderName, resources, providerClient).generate(outputDir)
+}
+
+func newTerraformConfigGenerator(providerName string, resources []SpecResource, providerClient ClientOpenAPI) *terraformConfigGenerator {
+	return &terraformConfigGenerator{
+		providerName:   providerName,
+		resources:      resources,
+		providerClient: providerClient,
+		labels:         map[string]map[string]bool{},
+		instanceLabels: map[string]string{},
+	}
+}
+
+func (g *terraformConfigGenerator) generate(outputDir string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the output directory '%s': %s", outputDir, err)
+	}
+	configPerResource := map[string]*strings.Builder{}
+	resourceNames := []string{}
+	for _, instance := range listRemoteInstances(g.providerClient, g.resources) {
+		resourceName := instance.resource.GetResourceName()
+		config, err := g.renderInstance(instance)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := configPerResource[resourceName]; !exists {
+			configPerResource[resourceName] = &strings.Builder{}
+			resourceNames = append(resourceNames, resourceName)
+		} else {
+			configPerResource[resourceName].WriteString("\n")
+		}
+		configPerResource[resourceName].WriteString(config)
+	}
+
+	files := []string{}
+	for _, resourceName := range resourceNames {
+		fileName := filepath.Join(outputDir, fmt.Sprintf("%s.tf", g.getTerraformResourceType(resourceName)))
+		if err := ioutil.WriteFile(fileName, []byte(configPerResource[resourceName].String()), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write the configuration file '%s': %s", fileName, err)
+		}
+		log.Printf("[INFO] terraform configuration written to %s", fileName)
+		files = append(files, fileName)
+	}
+	return files, nil
+}
+
+func (g *terraformConfigGenerator) getTerraformResourceType(resourceName string) string {
+	return fmt.Sprintf("%s_%s", g.providerName, resourceName)
+}
+
+// renderInstance returns the resource and import blocks for the given remote instance
+func (g *terraformConfigGenerator) renderInstance(instance remoteInstance) (string, error) {
+	resourceName := instance.resource.GetResourceName()
+	resourceSchema, err := instance.resource.GetResourceSchema()
+	if err != nil {
+		return "", err
+	}
+	label := g.createLabel(instance)
+	resourceAddress := fmt.Sprintf("%s.%s", g.getTerraformResourceType(resourceName), label)
+
+	body := &hclBody{}
+	if parentResourceInfo := instance.resource.GetParentResourceInfo(); parentResourceInfo != nil {
+		parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
+		ancestorResourceNames := g.getAncestorResourceNames(instance.resource)
+		for idx, parentPropertyName := range parentPropertyNames {
+			if idx >= len(instance.parentIDs) {
+				break
+			}
+			// the names of the ancestors above an ancestor that is not exposed by the provider can not be resolved, hence
+			// the names are aligned from the immediate parent
+			ancestorIdx := idx - (len(parentPropertyNames) - len(ancestorResourceNames))
+			if ancestorIdx < 0 {
+				body.attribute(parentPropertyName, hclString(instance.parentIDs[idx]))
+				continue
+			}
+			ancestorResourceName := ancestorResourceNames[ancestorIdx]
+			ancestorImportID := strings.Join(instance.parentIDs[:idx+1], "/")
+			if ancestorLabel, exists := g.instanceLabels[g.instanceKey(ancestorResourceName, ancestorImportID)]; exists {
+				body.attribute(parentPropertyName, fmt.Sprintf("%s.%s.id", g.getTerraformResourceType(ancestorResourceName), ancestorLabel))
+				continue
+			}
+			body.attribute(parentPropertyName, hclString(instance.parentIDs[idx]))
+		}
+	}
+	if err := g.renderProperties(body, resourceSchema, instance.payload, true); err != nil {
+		return "", fmt.Errorf("[resource='%s'] failed to generate the configuration for instance '%s': %s", resourceName, instance.id, err)
+	}
+	g.instanceLabels[g.instanceKey(resourceName, instance.getImportID())] = label
+
+	config := &strings.Builder{}
+	fmt.Fprintf(config, "resource %s %s {\n", hclString(g.getTerraformResourceType(resourceName)), hclString(label))
+	body.write(config, 1)
+	config.WriteString("}\n\n")
+	config.WriteString("import {\n")
+	importBody := &hclBody{}
+	importBody.attribute("to", resourceAddress)
+	importBody.attribute("id", hclString(instance.getImportID()))
+	importBody.write(config, 1)
+	config.WriteString("}\n")
+	return config.String(), nil
+}
+
+// getAncestorResourceNames returns the names of the ancestors of the given resource as exposed by the provider, from the
+// top level one to the immediate parent. The names are resolved through the full parent resource names of the resources,
+// so the ancestors renamed in the plugin configuration or prefixed by their swagger document are referenced with the name
+// they are exposed with. The lookup stops at the first ancestor that is not part of the resources.
+func (g *terraformConfigGenerator) getAncestorResourceNames(resource SpecResource) []string {
+	ancestorResourceNames := []string{}
+	for parentResourceInfo := resource.GetParentResourceInfo(); parentResourceInfo != nil; {
+		parentResourceName := parentResourceInfo.fullParentResourceName
+		ancestorResourceNames = append([]string{parentResourceName}, ancestorResourceNames...)
+		parentResource := g.findResource(parentResourceName)
+		if parentResource == nil {
+			break
+		}
+		parentResourceInfo = parentResource.GetParentResourceInfo()
+	}
+	return ancestorResourceNames
+}
+
+func (g *terraformConfigGenerator) findResource(resourceName string) SpecResource {
+	for _, resource := range g.resources {
+		if resource.GetResourceName() == resourceName {
+			return resource
+		}
+	}
+	return nil
+}
+
+// renderProperties adds to the given body the properties that are user-settable, that is, properties that are not
+// read-only, not the resource identifier (if ignoreID is true) and whose value is not the default one. Sensitive properties
+// are not rendered to avoid writing secrets into the configuration files, instead a comment is added for the user to fill them in.
+func (g *terraformConfigGenerator) renderProperties(body *hclBody, schemaDefinition *SpecSchemaDefinition, payload map[string]interface{}, ignoreID bool) error {
+	properties := append([]*SpecSchemaDefinitionProperty{}, schemaDefinition.Properties...)
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].GetTerraformCompliantPropertyName() < properties[j].GetTerraformCompliantPropertyName()
+	})
+	for _, property := range properties {
+		if (ignoreID && property.isPropertyNamedID()) || property.isReadOnly() || property.IsParentProperty {
+			continue
+		}
+		propertyValue, exists := payload[property.Name]
+		if !exists || propertyValue == nil {
+			continue
+		}
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if property.isObjectProperty() || property.isArrayOfObjectsProperty() {
+			items := []interface{}{propertyValue}
+			if property.isArrayOfObjectsProperty() {
+				items, _ = propertyValue.([]interface{})
+			}
+			for _, item := range items {
+				itemPayload, ok := item.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("property '%s' is supposed to be an object", propertyName)
+				}
+				block := &hclBody{}
+				if err := g.renderProperties(block, property.SpecSchemaDefinition, itemPayload, false); err != nil {
+					return err
+				}
+				body.block(propertyName, block)
+			}
+			continue
+		}
+		value, err := convertPayloadToLocalStateDataValue(property, propertyValue)
+		if err != nil {
+			return err
+		}
+		if property.Default != nil && fmt.Sprintf("%v", property.Default) == fmt.Sprintf("%v", value) {
+			continue
+		}
+		if property.Sensitive {
+			body.comment(fmt.Sprintf("%s is sensitive and has not been exported, please provide its value", propertyName))
+			continue
+		}
+		hclValue, err := hclPrimitiveValue(value)
+		if err != nil {
+			return fmt.Errorf("property '%s' %s", propertyName, err)
+		}
+		body.attribute(propertyName, hclValue)
+	}
+	return nil
+}
+
+// createLabel returns a unique terraform compliant label for the given instance based on the instance name (if present)
+// or its ID otherwise
+func (g *terraformConfigGenerator) createLabel(instance remoteInstance) string {
+	resourceName := instance.resource.GetResourceName()
+	label := instance.id
+	for _, propertyName := range terraformConfigLabelPropertyNames {
+		if name, ok := instance.payload[propertyName].(string); ok && name != "" {
+			label = name
+			break
+		}
+	}
+	label = strings.Trim(terraformConfigLabelInvalidChars.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = strings.Trim(fmt.Sprintf("%s_%s", resourceName, label), "_")
+	}
+	if g.labels[resourceName] == nil {
+		g.labels[resourceName] = map[string]bool{}
+	}
+	uniqueLabel := label
+	for i := 2; g.labels[resourceName][uniqueLabel]; i++ {
+		uniqueLabel = fmt.Sprintf("%s_%d", label, i)
+	}
+	g.labels[resourceName][uniqueLabel] = true
+	return uniqueLabel
+}
+
+func (g *terraformConfigGenerator) instanceKey(resourceName, importID string) string {
+	return fmt.Sprintf("%s/%s", resourceName, importID)
+}
+
+// hclBody represents the body of an HCL block, containing attributes, comments and nested blocks
+type hclBody struct {
+	attributes []hclAttribute
+	comments   []string
+	blocks     []hclBlock
+}
+
+type hclAttribute struct {
+	name  string
+	value string
+}
+
+type hclBlock struct {
+	name string
+	body *hclBody
+}
+
+func (b *hclBody) attribute(name, value string) {
+	b.attributes = append(b.attributes, hclAttribute{name: name, value: value})
+}
+
+func (b *hclBody) comment(comment string) {
+	b.comments = append(b.comments, comment)
+}
+
+func (b *hclBody) block(name string, body *hclBody) {
+	b.blocks = append(b.blocks, hclBlock{name: name, body: body})
+}
+
+// write writes the body into the given builder following the terraform fmt conventions (two spaces indentation and
+// attribute equal signs aligned)
+func (b *hclBody) write(builder *strings.Builder, indentLevel int) {
+	indent := strings.Repeat("  ", indentLevel)
+	nameLength := 0
+	for _, attribute := range b.attributes {
+		if len(attribute.name) > nameLength {
+			nameLength = len(attribute.name)
+		}
+	}
+	for _, attribute := range b.attributes {
+		fmt.Fprintf(builder, "%s%-*s = %s\n", indent, nameLength, attribute.name, attribute.value)
+	}
+	for _, comment := range b.comments {
+		fmt.Fprintf(builder, "%s# %s\n", indent, comment)
+	}
+	for idx, block := range b.blocks {
+		if idx > 0 || len(b.attributes) > 0 || len(b.comments) > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(builder, "%s%s {\n", indent, block.name)
+		block.body.write(builder, indentLevel+1)
+		fmt.Fprintf(builder, "%s}\n", indent)
+	}
+}
+
+// hclPrimitiveValue returns the HCL representation of the given primitive value or list of primitive values
+func hclPrimitiveValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return hclString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []interface{}:
+		items := []string{}
+		for _, item := range v {
+			hclItem, err := hclPrimitiveValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, hclItem)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", ")), nil
+	case map[string]interface{}:
+		keys := []string{}
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := []string{}
+		for _, key := range keys {
+			hclItem, err := hclPrimitiveValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s = %s", hclString(key), hclItem))
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(items, ", ")), nil
+	}
+	return "", fmt.Errorf("value type '%T' not supported", value)
+}
+
+// hclString returns the given value as an HCL quoted string, escaping the characters that have special meaning in HCL
+// including the template sequences ${ and %{
+func hclString(value string) string {
+	builder := &strings.Builder{}
+	builder.WriteString(`"`)
+	runes := []rune(value)
+	for i, r := range runes {
+		switch {
+		case r == '"':
+			builder.WriteString(`\"`)
+		case r == '\\':
+			builder.WriteString(`\\`)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case (r == '$' || r == '%') && i+1 < len(runes) && runes[i+1] == '{':
+			builder.WriteRune(r)
+			builder.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(builder, `\u%04x`, r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteString(`"`)
+	return builder.String()
+}
@@ -0,0 +1,190 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTerraformConfigGeneratorGenerate(t *testing.T) {
+	Convey("Given a terraformConfigGenerator with a resource and a sub-resource that have remote instances", t, func() {
+		cdnSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "ips", Type: TypeList, ArrayItemsType: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, Default: float64(80)},
+				&SpecSchemaDefinitionProperty{Name: "created_at", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "password", Type: TypeString, Sensitive: true},
+				&SpecSchemaDefinitionProperty{Name: "objectProperty", PreferredName: "object_property", Type: TypeObject, SpecSchemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						&SpecSchemaDefinitionProperty{Name: "message", Type: TypeString},
+						&SpecSchemaDefinitionProperty{Name: "computed", Type: TypeString, ReadOnly: true},
+					},
+				}},
+			},
+		}
+		firewallSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: TypeString, Required: true, IsParentProperty: true},
+				&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+			},
+		}
+		cdnResource := &specStubResource{name: "cdns_v1", schemaDefinition: cdnSchemaDefinition, resourceListOperation: &specResourceOperation{}}
+		firewallResource := &specStubResource{name: "cdns_v1_firewalls_v1", schemaDefinition: firewallSchemaDefinition, resourceListOperation: &specResourceOperation{}, parentResourceNames: []string{"cdns_v1"}, fullParentResourceName: "cdns_v1"}
+		client := &clientOpenAPIStub{
+			funcList: func(resource SpecResource, parentIDs ...string) []map[string]interface{} {
+				if resource.GetResourceName() == "cdns_v1" {
+					return []map[string]interface{}{
+						{"id": "1234", "name": "My CDN", "ips": []interface{}{"127.0.0.1"}, "port": float64(80), "created_at": "2021-01-01", "password": "secret", "objectProperty": map[string]interface{}{"message": "some ${message}", "computed": "value"}},
+						{"id": "5678", "name": "my_cdn", "ips": []interface{}{}, "port": float64(8080)},
+					}
+				}
+				return []map[string]interface{}{{"id": "fw1", "label": "my-fw"}}
+			},
+		}
+		generator := newTerraformConfigGenerator("openapi", []SpecResource{firewallResource, cdnResource}, client)
+		outputDir, err := ioutil.TempDir("", "generate-config")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(outputDir)
+		Convey("When generate is called", func() {
+			files, err := generator.generate(outputDir)
+			Convey("Then the error returned should be nil and a file per resource should be created", func() {
+				So(err, ShouldBeNil)
+				So(files, ShouldResemble, []string{filepath.Join(outputDir, "openapi_cdns_v1.tf"), filepath.Join(outputDir, "openapi_cdns_v1_firewalls_v1.tf")})
+			})
+			Convey("And the resource configuration should only contain the user-settable attributes and the import blocks", func() {
+				config, err := ioutil.ReadFile(filepath.Join(outputDir, "openapi_cdns_v1.tf"))
+				So(err, ShouldBeNil)
+				So(string(config), ShouldEqual, `resource "openapi_cdns_v1" "my_cdn" {
+  ips  = ["127.0.0.1"]
+  name = "My CDN"
+  # password is sensitive and has not been exported, please provide its value
+
+  object_property {
+    message = "some $${message}"
+  }
+}
+
+import {
+  to = openapi_cdns_v1.my_cdn
+  id = "1234"
+}
+
+resource "openapi_cdns_v1" "my_cdn_2" {
+  ips  = []
+  name = "my_cdn"
+  port = 8080
+}
+
+import {
+  to = openapi_cdns_v1.my_cdn_2
+  id = "5678"
+}
+`)
+			})
+			Convey("And the sub-resource configuration should reference its parent resource and be imported using the parent ID", func() {
+				config, err := ioutil.ReadFile(filepath.Join(outputDir, "openapi_cdns_v1_firewalls_v1.tf"))
+				So(err, ShouldBeNil)
+				So(string(config), ShouldContainSubstring, `resource "openapi_cdns_v1_firewalls_v1" "my_fw" {
+  cdns_v1_id = openapi_cdns_v1.my_cdn.id
+  label      = "my-fw"
+}
+
+import {
+  to = openapi_cdns_v1_firewalls_v1.my_fw
+  id = "1234/fw1"
+}
+`)
+				So(string(config), ShouldContainSubstring, `cdns_v1_id = openapi_cdns_v1.my_cdn_2.id`)
+				So(string(config), ShouldContainSubstring, `id = "5678/fw1"`)
+			})
+		})
+	})
+}
+
+func TestTerraformConfigGeneratorGenerateRenamedParents(t *testing.T) {
+	Convey("Given a terraformConfigGenerator with nested sub-resources whose parents are exposed with a different name (e,g: renamed in the plugin configuration)", t, func() {
+		schemaDefinition := func(parentPropertyNames ...string) *SpecSchemaDefinition {
+			s := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+			}}
+			for _, parentPropertyName := range parentPropertyNames {
+				s.Properties = append(s.Properties, &SpecSchemaDefinitionProperty{Name: parentPropertyName, Type: TypeString, Required: true, IsParentProperty: true})
+			}
+			return s
+		}
+		cdnResource := &specStubResource{name: "cdn", schemaDefinition: schemaDefinition(), resourceListOperation: &specResourceOperation{}}
+		firewallResource := &specStubResource{name: "firewall", schemaDefinition: schemaDefinition("cdns_v1_id"), resourceListOperation: &specResourceOperation{}, parentResourceNames: []string{"cdns_v1"}, fullParentResourceName: "cdn"}
+		ruleResource := &specStubResource{name: "rule", schemaDefinition: schemaDefinition("cdns_v1_id", "firewalls_v1_id"), resourceListOperation: &specResourceOperation{}, parentResourceNames: []string{"cdns_v1", "firewalls_v1"}, fullParentResourceName: "firewall"}
+		client := &clientOpenAPIStub{
+			funcList: func(resource SpecResource, parentIDs ...string) []map[string]interface{} {
+				return []map[string]interface{}{{"id": resource.GetResourceName() + "1", "name": "my-" + resource.GetResourceName()}}
+			},
+		}
+		generator := newTerraformConfigGenerator("openapi", []SpecResource{ruleResource, firewallResource, cdnResource}, client)
+		outputDir, err := ioutil.TempDir("", "generate-config")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(outputDir)
+		Convey("When generate is called", func() {
+			_, err := generator.generate(outputDir)
+			Convey("Then the sub-resources should reference their ancestors with the name they are exposed with", func() {
+				So(err, ShouldBeNil)
+				config, err := ioutil.ReadFile(filepath.Join(outputDir, "openapi_rule.tf"))
+				So(err, ShouldBeNil)
+				So(string(config), ShouldContainSubstring, `cdns_v1_id      = openapi_cdn.my_cdn.id`)
+				So(string(config), ShouldContainSubstring, `firewalls_v1_id = openapi_firewall.my_firewall.id`)
+				So(string(config), ShouldContainSubstring, `id = "cdn1/firewall1/rule1"`)
+			})
+		})
+	})
+}
+
+func TestTerraformConfigGeneratorCreateLabel(t *testing.T) {
+	Convey("Given a terraformConfigGenerator", t, func() {
+		generator := newTerraformConfigGenerator("openapi", nil, nil)
+		resource := &specStubResource{name: "cdns_v1"}
+		Convey("When createLabel is called with an instance that has a name", func() {
+			label := generator.createLabel(remoteInstance{resource: resource, id: "1234", payload: map[string]interface{}{"name": "My-CDN (prod)"}})
+			Convey("Then the label should be the terraform compliant name", func() {
+				So(label, ShouldEqual, "my_cdn_prod")
+			})
+		})
+		Convey("When createLabel is called with an instance that has no name and a numeric ID", func() {
+			label := generator.createLabel(remoteInstance{resource: resource, id: "1234", payload: map[string]interface{}{}})
+			Convey("Then the label should be prefixed with the resource name so it's a valid identifier", func() {
+				So(label, ShouldEqual, "cdns_v1_1234")
+			})
+		})
+	})
+}
+
+func TestHCLString(t *testing.T) {
+	Convey("Given a string containing characters with special meaning in HCL", t, func() {
+		value := "some \"quoted\" value with \\ ${interpolation} %{directive} and\nnew line"
+		Convey("When hclString is called", func() {
+			hclValue := hclString(value)
+			Convey("Then the value returned should be properly escaped", func() {
+				So(hclValue, ShouldEqual, `"some \"quoted\" value with \\ $${interpolation} %%{directive} and\nnew line"`)
+			})
+		})
+	})
+}
+
+func TestHCLPrimitiveValueMap(t *testing.T) {
+	Convey("Given a map of strings", t, func() {
+		value := map[string]interface{}{"team": "network", "cost_center": "cc1"}
+		Convey("When hclPrimitiveValue is called", func() {
+			hclValue, err := hclPrimitiveValue(value)
+			Convey("Then the value returned should be an HCL map with the keys sorted", func() {
+				So(err, ShouldBeNil)
+				So(hclValue, ShouldEqual, `{ "cost_center" = "cc1", "team" = "network" }`)
+			})
+		})
+	})
+}
@@ -124,6 +124,12 @@ func CreateStringSchemaProperty(propertyName string, required bool, defaultValue
 	return createSchema(propertyName, schema.TypeString, required, defaultValue)
 }
 
+// CreateSchemaProperty creates a terraform schema of the given primitive type (string, int or bool) configured based upon
+// the parameters passed in. The default value is converted to the given type by terraform.
+func CreateSchemaProperty(propertyName string, schemaType schema.ValueType, required bool, defaultValue string) *schema.Schema {
+	return createSchema(propertyName, schemaType, required, defaultValue)
+}
+
 // envDefaultFunc is a helper function that returns the value of the first
 // environment variable in the given list 'ks' that returns a non-empty value. The ks are converted to upper case
 // automatically for convenience. If none of the environment variables return a value, the default value is
@@ -215,6 +215,25 @@ func TestCreateStringSchema(t *testing.T) {
 	})
 }
 
+func TestCreateSchemaProperty(t *testing.T) {
+	Convey("Given an optional property of type bool with a default value", t, func() {
+		Convey("When CreateSchemaProperty method is called", func() {
+			s := CreateSchemaProperty("propertyName", schema.TypeBool, false, "true")
+			Convey("Then the schema returned should be of type bool", func() {
+				So(s.Type, ShouldEqual, schema.TypeBool)
+			})
+			Convey("And the schema returned should be optional", func() {
+				So(s.Optional, ShouldBeTrue)
+			})
+			Convey("And the schema default function should return the default value", func() {
+				value, err := s.DefaultFunc()
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "true")
+			})
+		})
+	})
+}
+
 func TestEnvDefaultFunc(t *testing.T) {
 	Convey("Given a property name that has an environment variable set up and nil default value", t, func() {
 		propertyName := "propertyName"
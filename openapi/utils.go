@@ -1,11 +1,16 @@
 package openapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/mitchellh/go-homedir"
 	"io/ioutil"
 	"log"
 	"net/url"
+	"os/exec"
+	"time"
 )
 
 func prettyPrint(v interface{}) {
@@ -47,3 +52,22 @@ func isURL(str string) bool {
 	u, err := url.Parse(str)
 	return err == nil && u.Scheme != "" && u.Host != ""
 }
+
+// runCommand executes the command (in exec form) and returns its stdout. The command is killed if it does not finish
+// within the given timeout. The errors returned do not include the command so the callers can describe it.
+func runCommand(command []string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) // #nosec G204 the commands are configured by the user in the plugin configuration file
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("did not finish executing within the expected time %s (%s)", timeout, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed: %s(%s)", stderr.String(), err)
+	}
+	return stdout.Bytes(), nil
+}
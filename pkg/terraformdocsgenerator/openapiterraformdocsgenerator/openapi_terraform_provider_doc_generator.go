@@ -7,6 +7,7 @@ import (
 	"github.com/mitchellh/hashstructure"
 	"log"
 	"sort"
+	"strings"
 )
 
 // TerraformProviderDocGenerator defines the struct that holds the configuration needed to be able to generate the documentation
@@ -25,6 +26,9 @@ type TerraformProviderDocGenerator struct {
 	// SpecAnalyser analyses the swagger doc and provides helper methods to retrieve all the end points that can
 	// be used as terraform resources.
 	SpecAnalyser openapi.SpecAnalyser
+	// ServiceConfiguration optionally defines the plugin configuration of the service, used to document the type,
+	// sensitivity, description and validation of the provider properties configured in it
+	ServiceConfiguration openapi.ServiceConfiguration
 }
 
 // NewTerraformProviderDocGenerator returns a TerraformProviderDocGenerator populated with the provider documentation which
@@ -65,12 +69,17 @@ func (t TerraformProviderDocGenerator) GenerateDocumentation() (TerraformProvide
 		return TerraformProviderDocumentation{}, err
 	}
 	headers := t.SpecAnalyser.GetAllHeaderParameters()
-	configRegions, configProperties := t.getRequiredProviderConfigurationProperties(regions, globalSecuritySchemes, securityDefinitions, headers)
+	globalPathParameters, err := t.SpecAnalyser.GetGlobalPathParameters()
+	if err != nil {
+		return TerraformProviderDocumentation{}, err
+	}
+	configRegions, configProperties := t.getRequiredProviderConfigurationProperties(regions, globalSecuritySchemes, securityDefinitions, headers, globalPathParameters)
 
 	r, err := t.SpecAnalyser.GetTerraformCompliantResources()
 	if err != nil {
 		return TerraformProviderDocumentation{}, err
 	}
+	r = configureRegions(r, regions)
 	resources, err := t.getProviderResources(r)
 	if err != nil {
 		return TerraformProviderDocumentation{}, err
@@ -79,7 +88,7 @@ func (t TerraformProviderDocGenerator) GenerateDocumentation() (TerraformProvide
 	// ignoring error from getDataSourceInstances bc resource errors will be caught when looping through resources in getProviderResources
 	dataSourceInstances, _ := t.getDataSourceInstances(r)
 
-	compliantDataSources := t.SpecAnalyser.GetTerraformCompliantDataSources()
+	compliantDataSources := configureRegions(t.SpecAnalyser.GetTerraformCompliantDataSources(), regions)
 	dataSourceFilters, err := t.getDataSourceFilters(compliantDataSources)
 	if err != nil {
 		return TerraformProviderDocumentation{}, err
@@ -142,6 +151,19 @@ func getRegions(s openapi.SpecAnalyser) ([]string, error) {
 	return nil, nil
 }
 
+// configureRegions returns the given resources with the region argument if the provider is multi-region (regions is not
+// empty), otherwise the resources are returned as is
+func configureRegions(resources []openapi.SpecResource, regions []string) []openapi.SpecResource {
+	if len(regions) == 0 {
+		return resources
+	}
+	regionResources := []openapi.SpecResource{}
+	for _, resource := range resources {
+		regionResources = append(regionResources, openapi.NewRegionSpecResource(resource, regions))
+	}
+	return regionResources
+}
+
 func getSecurity(s openapi.SpecAnalyser) (openapi.SpecSecuritySchemes, *openapi.SpecSecurityDefinitions, error) {
 	security := s.GetSecurity()
 	if security != nil {
@@ -268,44 +290,68 @@ func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefini
 	}
 }
 
-func (t TerraformProviderDocGenerator) getRequiredProviderConfigurationProperties(regions []string, globalSecuritySchemes openapi.SpecSecuritySchemes, securityDefinitions *openapi.SpecSecurityDefinitions, headers openapi.SpecHeaderParameters) ([]string, []Property) {
+func (t TerraformProviderDocGenerator) getRequiredProviderConfigurationProperties(regions []string, globalSecuritySchemes openapi.SpecSecuritySchemes, securityDefinitions *openapi.SpecSecurityDefinitions, headers openapi.SpecHeaderParameters, globalPathParameters openapi.SpecGlobalPathParameters) ([]string, []Property) {
 	var configProps []Property
 	if securityDefinitions != nil {
 		for _, securityDefinition := range *securityDefinitions {
-			secDefName := securityDefinition.GetTerraformConfigurationName()
-			configProps = append(configProps, Property{
-				Name:        secDefName,
-				Type:        "string",
-				Required:    false,
-				Description: "",
-			})
-		}
-	}
-	// Mark as required the properties that are set in the security schemes (they are mandatory)
-	if globalSecuritySchemes != nil {
-		for _, securityScheme := range globalSecuritySchemes {
-			for idx, configProp := range configProps {
-				if configProp.Name == securityScheme.GetTerraformConfigurationName() {
-					configProps[idx].Required = true
+			required := false
+			// Mark as required the properties that are set in the security schemes (they are mandatory)
+			for _, securityScheme := range globalSecuritySchemes {
+				if securityScheme.GetTerraformConfigurationName() == securityDefinition.GetTerraformConfigurationName() {
+					required = true
 					break
 				}
 			}
+			for _, securityDefinitionProperty := range openapi.GetSecurityDefinitionProperties(securityDefinition) {
+				configProps = append(configProps, t.getProviderConfigurationProperty(securityDefinitionProperty.Name, required && !securityDefinitionProperty.Optional, openapi.ProviderPropertyAttributes{Sensitive: securityDefinitionProperty.Sensitive}))
+			}
 		}
 	}
 
 	if headers != nil {
 		for _, header := range headers {
-			configProps = append(configProps, Property{
-				Name:        header.GetHeaderTerraformConfigurationName(),
-				Type:        "string",
-				Required:    header.IsRequired,
-				Description: "",
-			})
+			configProps = append(configProps, t.getProviderConfigurationProperty(header.GetHeaderTerraformConfigurationName(), header.IsRequired, header.ProviderPropertyAttributes))
 		}
 	}
+
+	for _, globalPathParameter := range globalPathParameters {
+		configProps = append(configProps, t.getProviderConfigurationProperty(globalPathParameter.GetTerraformConfigurationName(), globalPathParameter.IsRequired(), globalPathParameter.ProviderPropertyAttributes))
+	}
 	return regions, configProps
 }
 
+// getProviderConfigurationProperty returns the documentation of the given provider property, with the given attributes
+// overridden by the ones in the service configuration (if any) and the validation appended to the description
+func (t TerraformProviderDocGenerator) getProviderConfigurationProperty(name string, required bool, attributes openapi.ProviderPropertyAttributes) Property {
+	attributes = openapi.GetProviderPropertyAttributes(t.ServiceConfiguration, name, attributes)
+	description := []string{}
+	if attributes.Description != "" {
+		description = append(description, strings.TrimSuffix(attributes.Description, "."))
+	}
+	if len(attributes.AllowedValues) > 0 {
+		description = append(description, fmt.Sprintf("Allowed values: %s", strings.Join(attributes.AllowedValues, ", ")))
+	}
+	if attributes.Pattern != "" {
+		description = append(description, fmt.Sprintf("Must match the pattern: %s", attributes.Pattern))
+	}
+	if attributes.Minimum != nil {
+		description = append(description, fmt.Sprintf("Minimum value: %v", *attributes.Minimum))
+	}
+	if attributes.Maximum != nil {
+		description = append(description, fmt.Sprintf("Maximum value: %v", *attributes.Maximum))
+	}
+	if attributes.Default != "" {
+		description = append(description, fmt.Sprintf("Default value: %s", attributes.Default))
+	}
+	return Property{
+		Name:        name,
+		Type:        attributes.GetTypeName(),
+		Required:    required,
+		IsSensitive: attributes.Sensitive,
+		Description: strings.Join(description, ". "),
+	}
+}
+
 func orderProps(props []Property) []Property {
 	sort.Slice(props, func(i, j int) bool {
 		hash1, _ := hashstructure.Hash(props[i], nil)
@@ -12,6 +12,7 @@ type specAnalyserStub struct {
 	dataSources          func() []openapi.SpecResource
 	security             *specSecurityStub
 	headers              openapi.SpecHeaderParameters
+	globalPathParameters openapi.SpecGlobalPathParameters
 	backendConfiguration func() (*specStubBackendConfiguration, error)
 	error                error
 }
@@ -44,6 +45,10 @@ func (s *specAnalyserStub) GetAllHeaderParameters() openapi.SpecHeaderParameters
 	return nil
 }
 
+func (s *specAnalyserStub) GetGlobalPathParameters() (openapi.SpecGlobalPathParameters, error) {
+	return s.globalPathParameters, nil
+}
+
 func (s *specAnalyserStub) GetAPIBackendConfiguration() (openapi.SpecBackendConfiguration, error) {
 	if s.backendConfiguration != nil {
 		return s.backendConfiguration()
@@ -113,7 +113,7 @@ func TestGenerateDocumentation(t *testing.T) {
 	// ProviderConfiguration assertions
 	assert.Equal(t, providerName, d.ProviderConfiguration.ProviderName)
 	assert.Equal(t, []string{"region1", "region2", "region3"}, d.ProviderConfiguration.Regions)
-	assert.Equal(t, []Property{{Name: "required_token", Type: "string", ArrayItemsType: "", Required: true, Computed: false, Description: "", Schema: nil}}, d.ProviderConfiguration.ConfigProperties)
+	assert.Equal(t, []Property{{Name: "required_token", Type: "string", ArrayItemsType: "", Required: true, Computed: false, IsSensitive: true, Description: "", Schema: nil}}, d.ProviderConfiguration.ConfigProperties)
 	assert.Nil(t, d.ProviderConfiguration.ExampleUsage)
 	assert.Equal(t, ArgumentsReference{Notes: nil}, d.ProviderConfiguration.ArgumentsReference)
 
@@ -126,8 +126,10 @@ func TestGenerateDocumentation(t *testing.T) {
 	assert.Equal(t, "", cdnResource.Description)
 	assert.Equal(t, ArgumentsReference{Notes: []string{}}, cdnResource.ArgumentsReference)
 	cdnResourceProps := cdnResource.Properties
-	assert.Len(t, cdnResourceProps, 1)
+	assert.Len(t, cdnResourceProps, 2)
 	assertProperty(t, cdnResourceProps[0], "id", "string", "", "", false, true, nil)
+	// the resources of multi-region providers expose the region argument
+	assertProperty(t, cdnResourceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", false, true, nil)
 
 	assert.Equal(t, providerName, d.ProviderResources.ProviderName)
 	lbResource := d.ProviderResources.Resources[1]
@@ -135,8 +137,9 @@ func TestGenerateDocumentation(t *testing.T) {
 	assert.Equal(t, "", lbResource.Description)
 	assert.Equal(t, ArgumentsReference{Notes: []string{}}, lbResource.ArgumentsReference)
 	lbResourceProps := lbResource.Properties
-	assert.Len(t, lbResourceProps, 1)
+	assert.Len(t, lbResourceProps, 2)
 	assertProperty(t, lbResourceProps[0], "id", "string", "", "", false, true, nil)
+	assertProperty(t, lbResourceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", false, true, nil)
 
 	// DataSources assertions
 	assert.Equal(t, providerName, d.DataSources.ProviderName)
@@ -147,15 +150,17 @@ func TestGenerateDocumentation(t *testing.T) {
 	assert.Equal(t, "cdn_v1", cdnDataSource.Name)
 	assert.Equal(t, "", cdnDataSource.OtherExample)
 	cdnDataSourceProps := cdnDataSource.Properties
-	assert.Len(t, cdnResourceProps, 1)
+	assert.Len(t, cdnDataSourceProps, 2)
 	assertDataSourceProperty(t, cdnDataSourceProps[0], "id", "string", "", "", nil)
+	assertDataSourceProperty(t, cdnDataSourceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", nil)
 
 	lbDataSource := d.DataSources.DataSources[1]
 	assert.Equal(t, "lb_v1", lbDataSource.Name)
 	assert.Equal(t, "", lbDataSource.OtherExample)
 	lbDataSourceProps := lbDataSource.Properties
-	assert.Len(t, cdnResourceProps, 1)
+	assert.Len(t, lbDataSourceProps, 2)
 	assertDataSourceProperty(t, lbDataSourceProps[0], "id", "string", "", "", nil)
+	assertDataSourceProperty(t, lbDataSourceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", nil)
 
 	// DataSourceInstance assertions
 	assert.Len(t, d.DataSources.DataSourceInstances, 2)
@@ -163,15 +168,17 @@ func TestGenerateDocumentation(t *testing.T) {
 	assert.Equal(t, "cdn_v1_instance", cdnDataSourceInstance.Name)
 	assert.Equal(t, "", cdnDataSourceInstance.OtherExample)
 	cdnDataSourceInstanceProps := cdnDataSourceInstance.Properties
-	assert.Len(t, cdnDataSourceInstanceProps, 1)
+	assert.Len(t, cdnDataSourceInstanceProps, 2)
 	assertDataSourceProperty(t, cdnDataSourceInstanceProps[0], "id", "string", "", "", nil)
+	assertDataSourceProperty(t, cdnDataSourceInstanceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", nil)
 
 	lbDataSourceInstance := d.DataSources.DataSourceInstances[1]
 	assert.Equal(t, "lb_v1_instance", lbDataSourceInstance.Name)
 	assert.Equal(t, "", lbDataSourceInstance.OtherExample)
 	lbDataSourceInstanceProps := lbDataSourceInstance.Properties
-	assert.Len(t, lbDataSourceInstanceProps, 1)
+	assert.Len(t, lbDataSourceInstanceProps, 2)
 	assertDataSourceProperty(t, lbDataSourceInstanceProps[0], "id", "string", "", "", nil)
+	assertDataSourceProperty(t, lbDataSourceInstanceProps[1], "region", "string", "", "The region the resource is managed in (region1, region2, region3), if not set the region configured in the provider is used", nil)
 }
 
 func assertDataSourceProperty(t *testing.T, actualProp Property, expectedName, expectedType, expectedArrayItemsType, expectedDescription string, expectedSchema []Property) {
@@ -843,6 +850,8 @@ func TestGetRequiredProviderConfigurationProperties(t *testing.T) {
 		globalSecuritySchemes openapi.SpecSecuritySchemes
 		securityDefinitions   *openapi.SpecSecurityDefinitions
 		headers               openapi.SpecHeaderParameters
+		globalPathParameters  openapi.SpecGlobalPathParameters
+		serviceConfiguration  openapi.ServiceConfiguration
 		expectedRegions       []string
 		expectedConfigProps   []Property
 		expectedErr           error
@@ -862,6 +871,7 @@ func TestGetRequiredProviderConfigurationProperties(t *testing.T) {
 					ArrayItemsType: "",
 					Required:       true,
 					Computed:       false,
+					IsSensitive:    true,
 					Description:    "",
 					Schema:         nil,
 				},
@@ -879,6 +889,7 @@ func TestGetRequiredProviderConfigurationProperties(t *testing.T) {
 					ArrayItemsType: "",
 					Required:       false,
 					Computed:       false,
+					IsSensitive:    true,
 					Description:    "",
 					Schema:         nil,
 				},
@@ -929,11 +940,81 @@ func TestGetRequiredProviderConfigurationProperties(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path - with typed header with validation",
+			headers: openapi.SpecHeaderParameters{
+				{
+					Name: "retries",
+					ProviderPropertyAttributes: openapi.ProviderPropertyAttributes{
+						Type:          openapi.TypeInt,
+						Description:   "Number of retries.",
+						AllowedValues: []string{"1", "2"},
+					},
+				},
+			},
+			expectedConfigProps: []Property{
+				{
+					Name:        "retries",
+					Type:        "integer",
+					Description: "Number of retries. Allowed values: 1, 2",
+				},
+			},
+		},
+		{
+			name: "happy path - with header configured in the service configuration",
+			headers: openapi.SpecHeaderParameters{
+				{
+					Name: "session_token",
+				},
+			},
+			serviceConfiguration: &openapi.ServiceConfigStub{
+				SchemaConfiguration: []*openapi.ServiceSchemaPropertyConfigurationStub{
+					{
+						SchemaPropertyName: "session_token",
+						Attributes:         &openapi.ProviderPropertyAttributes{Sensitive: true, Description: "The session token", Pattern: "^[a-z]+$"},
+					},
+				},
+			},
+			expectedConfigProps: []Property{
+				{
+					Name:        "session_token",
+					Type:        "string",
+					IsSensitive: true,
+					Description: "The session token. Must match the pattern: ^[a-z]+$",
+				},
+			},
+		},
+		{
+			name: "happy path - with global path parameters",
+			globalPathParameters: openapi.SpecGlobalPathParameters{
+				{
+					Name:                       "account_id",
+					ProviderPropertyAttributes: openapi.ProviderPropertyAttributes{Description: "The account the resources belong to"},
+				},
+				{
+					Name:                       "projectId",
+					ProviderPropertyAttributes: openapi.ProviderPropertyAttributes{Default: "default", AllowedValues: []string{"default", "other"}},
+				},
+			},
+			expectedConfigProps: []Property{
+				{
+					Name:        "account_id",
+					Type:        "string",
+					Required:    true,
+					Description: "The account the resources belong to",
+				},
+				{
+					Name:        "project_id",
+					Type:        "string",
+					Description: "Allowed values: default, other. Default value: default",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
-		dg := TerraformProviderDocGenerator{}
-		regions, configProps := dg.getRequiredProviderConfigurationProperties(tc.regions, tc.globalSecuritySchemes, tc.securityDefinitions, tc.headers)
+		dg := TerraformProviderDocGenerator{ServiceConfiguration: tc.serviceConfiguration}
+		regions, configProps := dg.getRequiredProviderConfigurationProperties(tc.regions, tc.globalSecuritySchemes, tc.securityDefinitions, tc.headers, tc.globalPathParameters)
 		assert.Equal(t, tc.expectedRegions, regions, tc.name)
 		assert.Equal(t, tc.expectedConfigProps, configProps, tc.name)
 	}
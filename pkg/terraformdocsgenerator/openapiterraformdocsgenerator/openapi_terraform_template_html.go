@@ -111,7 +111,7 @@ var ProviderConfigurationTmpl = `{{if or .Regions .ConfigProperties}}
     <pre>
 <span>provider </span><span>"{{.ProviderName}}" </span>{
 {{- range .ConfigProperties}}
-<span>  {{.Name}}  </span>= <span>"..."</span>
+<span>  {{.Name}}  </span>= <span>{{if eq .Type "boolean"}}true{{else if eq .Type "integer"}}1234{{else}}"..."{{end}}</span>
 {{- end}}
 <span>}</span>
 </pre>
@@ -141,7 +141,7 @@ var ProviderConfigurationTmpl = `{{if or .Regions .ConfigProperties}}
         {{- if .Required -}}
             {{- $required = "Required" -}}
         {{end}}
-        <li><span>{{.Name}} [{{.Type}}] - ({{$required}}) {{.Description}}.</span></li></li>
+        <li><span>{{.Name}} [{{.Type}}]{{if .IsSensitive}} (<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{end}} - ({{$required}}) {{.Description}}.</span></li></li>
         {{- end -}}
     {{if .Regions }}
       <li>
@@ -170,6 +170,8 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
         <span>{{.Name}}  </span>= <span>[true, false]</span>
     {{- else if and (eq .Type "list") (eq .ArrayItemsType "number") -}}
         <span>{{.Name}}  </span>= <span>[12.36, 99.45]</span>
+    {{- else if eq .Type "map" -}}
+        <span>{{.Name}}  </span>= <span>{ key = "value" }</span>
     {{- else -}}
         {{- if or (eq .Type "object") (and (eq .Type "list") (eq .ArrayItemsType "object")) -}}
         <span>{{.Name}}  </span><span>{</span>
@@ -302,6 +302,22 @@ func TestProviderConfigurationTmpl(t *testing.T) {
 				Required: true,
 				Type:     "string",
 			},
+			{
+				Name:        "api_key",
+				Type:        "string",
+				IsSensitive: true,
+				Description: "The API key",
+			},
+			{
+				Name:        "debug",
+				Type:        "boolean",
+				Description: "Whether the requests are traced",
+			},
+			{
+				Name:        "retries",
+				Type:        "integer",
+				Description: "Number of retries. Minimum value: 0",
+			},
 		},
 		ExampleUsage: nil,
 		ArgumentsReference: ArgumentsReference{
@@ -314,6 +330,9 @@ func TestProviderConfigurationTmpl(t *testing.T) {
     <pre>
 <span>provider </span><span>"openapi" </span>{
 <span>  token  </span>= <span>"..."</span>
+<span>  api_key  </span>= <span>"..."</span>
+<span>  debug  </span>= <span>true</span>
+<span>  retries  </span>= <span>1234</span>
 <span>}</span>
 </pre>
 
@@ -328,6 +347,9 @@ func TestProviderConfigurationTmpl(t *testing.T) {
     <p dir="ltr">The following arguments are supported:</p>
     <ul dir="ltr">
         <li><span>token [string] - (Required) .</span></li></li>
+        <li><span>api_key [string] (<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>) - (Optional) The API key.</span></li></li>
+        <li><span>debug [boolean] - (Optional) Whether the requests are traced.</span></li></li>
+        <li><span>retries [integer] - (Optional) Number of retries. Minimum value: 0.</span></li></li>
       <li>
           region [string] - (Optional) The region location to be used&nbsp;([rst1]). If region isn't specified, the default is "rst1".
       </li>